@@ -9,13 +9,21 @@ import (
 	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
 )
 
 // References:
@@ -24,6 +32,7 @@ import (
 
 const (
 	sizeDetectMime = 512
+	tarBlockSize   = 512
 )
 
 type (
@@ -39,6 +48,32 @@ type (
 		file io.ReadCloser
 		size int64
 	}
+	// archived file that is stored contiguous and uncompressed (tar, zip "stored")
+	// and can be range-read directly from the containing object
+	cslSection struct {
+		*io.SectionReader
+	}
+
+	// range-reads archived files; compressed (non-seekable) ones get re-extracted
+	// and fast-forwarded to the start of each requested range
+	archRanger struct {
+		goi  *getObjInfo
+		fh   *os.File
+		csl  cos.ReadCloseSizer
+		used bool
+	}
+
+	// appends a file to (r != nil), or deletes one from, an existing archive
+	archObjInfo struct {
+		started  time.Time
+		t        *targetrunner
+		lom      *cluster.LOM
+		r        io.ReadCloser // content of the file to append
+		size     int64         // its size (Content-Length)
+		filename string        // pathname inside the archive
+		mime     string        // user-specified archive format (optional)
+		workFQN  string        // when rewriting the archive
+	}
 
 	detect struct {
 		offset int
@@ -66,6 +101,8 @@ func (csf *cslFile) Read(b []byte) (int, error) { return csf.file.Read(b) }
 func (csf *cslFile) Size() int64                { return csf.size }
 func (csf *cslFile) Close() error               { return csf.file.Close() }
 
+func (*cslSection) Close() error { return nil }
+
 func notFoundInArch(filename, archname string) error {
 	return cmn.NewNotFoundError("file %q in archive %q", filename, archname)
 }
@@ -94,14 +131,18 @@ func (goi *getObjInfo) freadArch(file *os.File) (cos.ReadCloseSizer, error) {
 	}
 }
 
-func (goi *getObjInfo) mime(file *os.File) (m string, err error) {
+func (goi *getObjInfo) mime(file *os.File) (string, error) {
+	return archMime(goi.t.smm, file, goi.archive.mime, goi.lom.ObjName)
+}
+
+func archMime(smm *memsys.MMSA, file *os.File, mime, objName string) (m string, err error) {
 	// either ok or non-empty user-defined mime type (that must work)
-	if m, err = cos.Mime(goi.archive.mime, goi.lom.ObjName); err == nil || goi.archive.mime != "" {
+	if m, err = cos.Mime(mime, objName); err == nil || mime != "" {
 		return
 	}
 	// otherwise, by magic
 	var (
-		buf, slab = goi.t.smm.AllocSize(sizeDetectMime)
+		buf, slab = smm.AllocSize(sizeDetectMime)
 		n         int
 	)
 	n, err = file.Read(buf)
@@ -113,7 +154,7 @@ func (goi *getObjInfo) mime(file *os.File) (m string, err error) {
 	}
 	if m == "" {
 		if err == nil {
-			err = cos.NewUnknownMimeError(goi.lom.ObjName)
+			err = cos.NewUnknownMimeError(objName)
 		} else {
 			err = cos.NewUnknownMimeError(err.Error())
 		}
@@ -127,7 +168,7 @@ func (goi *getObjInfo) mime(file *os.File) (m string, err error) {
 	return
 }
 
-func freadTar(reader io.Reader, filename, archname string) (*cslLimited, error) {
+func freadTar(reader io.Reader, filename, archname string) (cos.ReadCloseSizer, error) {
 	tr := tar.NewReader(reader)
 	for {
 		hdr, err := tr.Next()
@@ -137,28 +178,39 @@ func freadTar(reader io.Reader, filename, archname string) (*cslLimited, error)
 			}
 			return nil, err
 		}
-		if hdr.Name == filename || archNamesEq(hdr.Name, filename) {
-			return &cslLimited{LimitedReader: io.LimitedReader{R: reader, N: hdr.Size}}, nil
+		if hdr.Name != filename && !archNamesEq(hdr.Name, filename) {
+			continue
+		}
+		// tar reader consumes whole 512-byte blocks: the file's current offset
+		// is where the archived file starts
+		if fh, ok := reader.(*os.File); ok && hdr.Typeflag == tar.TypeReg {
+			offset, err := fh.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return nil, err
+			}
+			return &cslSection{io.NewSectionReader(fh, offset, hdr.Size)}, nil
 		}
+		return &cslLimited{LimitedReader: io.LimitedReader{R: reader, N: hdr.Size}}, nil
 	}
 }
 
 func freadTgz(reader io.Reader, filename, archname string) (csc *cslClose, err error) {
 	var (
 		gzr *gzip.Reader
-		csl *cslLimited
+		csl cos.ReadCloseSizer
 	)
 	if gzr, err = gzip.NewReader(reader); err != nil {
 		return
 	}
 	if csl, err = freadTar(gzr, filename, archname); err != nil {
+		gzr.Close()
 		return
 	}
-	csc = &cslClose{gzr: gzr /*to close*/, R: csl /*to read from*/, N: csl.N /*size*/}
+	csc = &cslClose{gzr: gzr /*to close*/, R: csl /*to read from*/, N: csl.Size() /*size*/}
 	return
 }
 
-func freadZip(readerAt cos.ReadReaderAt, filename, archname string, size int64) (csf *cslFile, err error) {
+func freadZip(readerAt cos.ReadReaderAt, filename, archname string, size int64) (csl cos.ReadCloseSizer, err error) {
 	var zr *zip.Reader
 	if zr, err = zip.NewReader(readerAt, size); err != nil {
 		return
@@ -168,16 +220,63 @@ func freadZip(readerAt cos.ReadReaderAt, filename, archname string, size int64)
 		if finfo.IsDir() {
 			continue
 		}
-		if f.FileHeader.Name == filename || archNamesEq(f.FileHeader.Name, filename) {
-			csf = &cslFile{size: finfo.Size()}
-			csf.file, err = f.Open()
+		if f.FileHeader.Name != filename && !archNamesEq(f.FileHeader.Name, filename) {
+			continue
+		}
+		if f.Method == zip.Store {
+			var offset int64
+			if offset, err = f.DataOffset(); err != nil {
+				return
+			}
+			csl = &cslSection{io.NewSectionReader(readerAt, offset, finfo.Size())}
+			return
+		}
+		csf := &cslFile{size: finfo.Size()}
+		if csf.file, err = f.Open(); err != nil {
 			return
 		}
+		csl = csf
+		return
 	}
 	err = notFoundInArch(filename, archname)
 	return
 }
 
+////////////////
+// archRanger //
+////////////////
+
+func (ar *archRanger) open(rrange *cmn.HTTPRange) (io.Reader, error) {
+	if section, ok := ar.csl.(*cslSection); ok {
+		return io.NewSectionReader(section, rrange.Start, rrange.Length), nil
+	}
+	if ar.used {
+		ar.csl.Close()
+		ar.csl = nil
+		if _, err := ar.fh.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		csl, err := ar.goi.freadArch(ar.fh)
+		if err != nil {
+			return nil, err
+		}
+		ar.csl = csl
+	}
+	ar.used = true
+	if rrange.Start > 0 {
+		if _, err := io.CopyN(io.Discard, ar.csl, rrange.Start); err != nil {
+			return nil, err
+		}
+	}
+	return io.LimitReader(ar.csl, rrange.Length), nil
+}
+
+func (ar *archRanger) close() {
+	if ar.csl != nil {
+		ar.csl.Close()
+	}
+}
+
 // NOTE: in re `--absolute-names` (simplified)
 func archNamesEq(n1, n2 string) bool {
 	if n1[0] == filepath.Separator {
@@ -188,3 +287,359 @@ func archNamesEq(n1, n2 string) bool {
 	}
 	return n1 == n2
 }
+
+/////////////////////////////////////////
+// APPEND to (or DELETE from) archive  //
+/////////////////////////////////////////
+
+func (aoi *archObjInfo) modify() (errCode int, err error) {
+	lom := aoi.lom
+	lom.Lock(true)
+	if errCode, err = aoi._modify(); err != nil {
+		lom.Uncache(true /*delDirty*/)
+	}
+	lom.Unlock(true)
+	if err != nil {
+		return
+	}
+	if ecErr := ec.ECM.EncodeObject(lom); ecErr != nil && ecErr != ec.ErrorECDisabled {
+		return http.StatusInternalServerError, ecErr
+	}
+	aoi.t.putMirror(lom)
+
+	delta := time.Since(aoi.started)
+	if aoi.r != nil {
+		aoi.t.statsT.AddMany(
+			stats.NamedVal64{Name: stats.AppendCount, Value: 1},
+			stats.NamedVal64{Name: stats.AppendLatency, Value: int64(delta)},
+		)
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s %s (file %q): %s", aoi, lom, aoi.filename, delta)
+	}
+	return
+}
+
+func (aoi *archObjInfo) String() string {
+	if aoi.r == nil {
+		return "delete-from-arch"
+	}
+	return "append-to-arch"
+}
+
+func (aoi *archObjInfo) archname() string {
+	return filepath.Join(aoi.lom.Bucket().Name, aoi.lom.ObjName)
+}
+
+// NOTE: caller must take the LOM write lock
+func (aoi *archObjInfo) _modify() (int, error) {
+	lom := aoi.lom
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			return http.StatusNotFound, err
+		}
+		return http.StatusInternalServerError, err
+	}
+	fh, err := os.Open(lom.FQN)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	mime, err := archMime(aoi.t.smm, fh, aoi.mime, lom.ObjName)
+	if err != nil {
+		cos.Close(fh)
+		return http.StatusBadRequest, err
+	}
+	return aoi.rewrite(fh, mime)
+}
+
+// Rewrite the archive into a workfile: copy all archived files except the one
+// that is being deleted; append the new one, if any. The workfile then replaces
+// the object (see finalize) - the object itself is never modified in place.
+// Closes `fh`.
+func (aoi *archObjInfo) rewrite(fh *os.File, mime string) (errCode int, err error) {
+	var (
+		wfh       *os.File
+		found     bool
+		cksum     *cos.CksumHash
+		lom       = aoi.lom
+		wc        = &cos.WriterCounter{}
+		cksumType = lom.CksumConf().Type
+	)
+	if wfh, err = lom.CreateFile(aoi.workFQN); err != nil {
+		cos.Close(fh)
+		return http.StatusInternalServerError, err
+	}
+	writers := []io.Writer{wfh, wc}
+	if cksumType != cos.ChecksumNone {
+		cksum = cos.NewCksumHash(cksumType)
+		writers = append(writers, cksum.H)
+	}
+	var (
+		w         = cos.NewWriterMulti(writers...)
+		buf, slab = aoi.t.gmm.Alloc()
+	)
+	switch mime {
+	case cos.ExtTar:
+		if aoi.r != nil {
+			found, err = aoi.appendTar(w, fh, buf)
+		} else {
+			found, err = aoi.rewriteTar(w, fh, buf)
+		}
+	case cos.ExtTarTgz, cos.ExtTgz:
+		found, err = aoi.rewriteTgz(w, fh, buf)
+	case cos.ExtZip:
+		found, err = aoi.rewriteZip(w, fh, buf)
+	default:
+		debug.Assert(false)
+		err = cos.NewUnknownMimeError(mime)
+	}
+	slab.Free(buf)
+	cos.Close(fh)
+	if errC := wfh.Close(); err == nil {
+		err = errC
+	}
+	if err == nil {
+		switch {
+		case aoi.r == nil && !found:
+			errCode, err = http.StatusNotFound, notFoundInArch(aoi.filename, aoi.archname())
+		case aoi.r != nil && found:
+			errCode, err = http.StatusConflict, existsInArch(aoi.filename, aoi.archname())
+		default:
+			if cksum != nil {
+				cksum.Finalize()
+			}
+			errCode, err = aoi.finalize(aoi.workFQN, wc.N, cksum)
+		}
+	}
+	if err != nil {
+		if errV := cos.RemoveFile(aoi.workFQN); errV != nil {
+			glog.Errorf("Nested error: %v => (remove %s => err: %v)", err, aoi.workFQN, errV)
+		}
+		if errCode == 0 {
+			errCode = http.StatusInternalServerError
+		}
+	}
+	return
+}
+
+// Copy the tar up to its end-of-archive marker as is, and write the new file
+// followed by a new marker; returns true if the file already exists.
+func (aoi *archObjInfo) appendTar(w io.Writer, fh *os.File, buf []byte) (found bool, err error) {
+	var end int64
+	if end, found, err = tarEnd(fh, aoi.filename); err != nil || found {
+		return
+	}
+	if _, err = fh.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+	if _, err = io.CopyBuffer(w, io.LimitReader(fh, end), buf); err != nil {
+		return
+	}
+	tw := tar.NewWriter(w)
+	if err = tw.WriteHeader(aoi.tarHeader()); err != nil {
+		return
+	}
+	if _, err = io.CopyBuffer(tw, aoi.r, buf); err != nil {
+		return
+	}
+	err = tw.Close()
+	return
+}
+
+// Copy archived files from `r` to `w` (tar => tar); returns true if the file
+// in question was found - in which case APPEND stops right away.
+func (aoi *archObjInfo) rewriteTar(w io.Writer, r io.Reader, buf []byte) (found bool, err error) {
+	var (
+		tr = tar.NewReader(r)
+		tw = tar.NewWriter(w)
+	)
+	for {
+		var hdr *tar.Header
+		if hdr, err = tr.Next(); err != nil {
+			if err != io.EOF {
+				return
+			}
+			break
+		}
+		if archNamesEq(hdr.Name, aoi.filename) {
+			found = true
+			if aoi.r != nil {
+				return
+			}
+			continue
+		}
+		if err = tw.WriteHeader(hdr); err != nil {
+			return
+		}
+		if _, err = io.CopyBuffer(tw, tr, buf); err != nil {
+			return
+		}
+	}
+	if aoi.r != nil {
+		if err = tw.WriteHeader(aoi.tarHeader()); err != nil {
+			return
+		}
+		if _, err = io.CopyBuffer(tw, aoi.r, buf); err != nil {
+			return
+		}
+	}
+	err = tw.Close()
+	return
+}
+
+func (aoi *archObjInfo) rewriteTgz(w io.Writer, r io.Reader, buf []byte) (found bool, err error) {
+	var gzr *gzip.Reader
+	if gzr, err = gzip.NewReader(r); err != nil {
+		return
+	}
+	gzw := gzip.NewWriter(w)
+	if found, err = aoi.rewriteTar(gzw, gzr, buf); err == nil {
+		err = gzw.Close()
+	}
+	gzr.Close()
+	return
+}
+
+func (aoi *archObjInfo) rewriteZip(w io.Writer, fh *os.File, buf []byte) (found bool, err error) {
+	var zr *zip.Reader
+	if zr, err = zip.NewReader(fh, aoi.lom.SizeBytes()); err != nil {
+		return
+	}
+	zw := zip.NewWriter(w)
+	for _, f := range zr.File {
+		var (
+			zipw  io.Writer
+			rc    io.ReadCloser
+			isDir = f.FileInfo().IsDir()
+		)
+		if !isDir && archNamesEq(f.FileHeader.Name, aoi.filename) {
+			found = true
+			if aoi.r != nil {
+				return
+			}
+			continue
+		}
+		ziphdr := f.FileHeader
+		if zipw, err = zw.CreateHeader(&ziphdr); err != nil {
+			return
+		}
+		if isDir {
+			continue
+		}
+		if rc, err = f.Open(); err != nil {
+			return
+		}
+		_, err = io.CopyBuffer(zipw, rc, buf)
+		rc.Close()
+		if err != nil {
+			return
+		}
+	}
+	if aoi.r != nil {
+		var zipw io.Writer
+		if zipw, err = zw.CreateHeader(aoi.zipHeader()); err != nil {
+			return
+		}
+		if _, err = io.CopyBuffer(zipw, aoi.r, buf); err != nil {
+			return
+		}
+	}
+	if err = zw.SetComment(zr.Comment); err == nil {
+		err = zw.Close()
+	}
+	return
+}
+
+func (aoi *archObjInfo) tarHeader() *tar.Header {
+	return &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     aoi.filename,
+		Size:     aoi.size,
+		Mode:     int64(cos.PermRWR),
+		ModTime:  aoi.started,
+	}
+}
+
+func (aoi *archObjInfo) zipHeader() *zip.FileHeader {
+	return &zip.FileHeader{
+		Name:               aoi.filename,
+		Comment:            aoi.filename,
+		UncompressedSize64: uint64(aoi.size),
+		Modified:           aoi.started,
+	}
+}
+
+// NOTE: caller must take the LOM write lock
+func (aoi *archObjInfo) finalize(fqn string, size int64, cksum *cos.CksumHash) (errCode int, err error) {
+	var (
+		lom      = aoi.lom
+		prevSize = lom.TrackedSize()
+	)
+	lom.SetSize(size)
+	if cksum == nil {
+		lom.SetCksum(cos.NoneCksum)
+	} else {
+		lom.SetCksum(cksum.Clone())
+	}
+	lom.SetAtimeUnix(aoi.started.UnixNano())
+	if lom.Bck().IsRemote() {
+		var (
+			version string
+			poi     = &putObjInfo{t: aoi.t, lom: lom, workFQN: fqn}
+		)
+		if version, errCode, err = poi.putRemote(); err != nil {
+			return
+		}
+		if lom.VersionConf().Enabled {
+			lom.SetVersion(version)
+		}
+	} else if lom.VersionConf().Enabled {
+		if err = lom.IncVersion(); err != nil {
+			glog.Error(err)
+		}
+	}
+	if fqn != lom.FQN {
+		if err = cos.Rename(fqn, lom.FQN); err != nil {
+			err = fmt.Errorf(cmn.FmtErrFailed, aoi.t.si, "rename", lom, err)
+			return
+		}
+	}
+	if lom.HasCopies() {
+		if errdc := lom.DelAllCopies(); errdc != nil {
+			glog.Errorf("%s %s: failed to delete old copies [%v], proceeding anyway...", aoi, lom, errdc)
+		}
+	}
+	if err = lom.Persist(true); err != nil {
+		return
+	}
+	lom.TrackStored(prevSize)
+	return
+}
+
+// offset of the end-of-archive marker (two or more zero blocks) that follows
+// the last archived file
+func tarEnd(fh *os.File, filename string) (end int64, exists bool, err error) {
+	tr := tar.NewReader(fh)
+	for {
+		var hdr *tar.Header
+		if hdr, err = tr.Next(); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return
+		}
+		if archNamesEq(hdr.Name, filename) {
+			exists = true
+			return
+		}
+		// tar reader consumes whole blocks: current offset is where the data starts
+		if end, err = fh.Seek(0, io.SeekCurrent); err != nil {
+			return
+		}
+		end += int64(cos.CeilAlign(uint(hdr.Size), tarBlockSize))
+	}
+}
+
+func existsInArch(filename, archname string) error {
+	return fmt.Errorf("file %q already exists in archive %q", filename, archname)
+}
@@ -43,7 +43,9 @@ type (
 		// list of invalid tokens(revoked or of deleted users)
 		// Authn sends these tokens to primary for broadcasting
 		revokedTokens map[string]bool
-		version       int64
+		// S3 access keys (by access key ID) - pushed by Authn the same way
+		s3Keys  map[string]*authn.S3Key
+		version int64
 	}
 )
 
@@ -57,8 +59,8 @@ func decryptToken(tokenStr string) (*authn.Token, error) {
 	return authn.DecryptToken(tokenStr, secret)
 }
 
-// Add tokens to list of invalid ones. After that it cleans up the list
-// from expired tokens
+// Add tokens to list of invalid ones and S3 access keys to the list of known
+// ones. After that it cleans up the lists from expired (and revoked) tokens
 func (a *authManager) updateRevokedList(tokens *tokenList) {
 	if tokens == nil {
 		return
@@ -82,13 +84,35 @@ func (a *authManager) updateRevokedList(tokens *tokenList) {
 		delete(a.tokens, token)
 	}
 	// clean up the list from obsolete data
+	for _, key := range tokens.S3Keys {
+		a.s3Keys[key.AccessKey] = key
+	}
 	for token := range a.revokedTokens {
 		rec, err := a.extractTokenData(token)
 		if err == nil && rec.Expires.Before(time.Now()) {
 			delete(a.revokedTokens, token)
 		}
 	}
+	for id, key := range a.s3Keys {
+		if _, err := a.extractTokenData(key.Token); err != nil || a.revokedTokens[key.Token] {
+			delete(a.s3Keys, id)
+		}
+	}
+	a.Unlock()
+}
+
+// Returns the S3 access key (ID) provided its token is valid
+func (a *authManager) lookupS3Key(accessKey string) (*authn.S3Key, error) {
+	a.Lock()
+	key, ok := a.s3Keys[accessKey]
 	a.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown S3 access key %q", accessKey)
+	}
+	if _, err := a.validateToken(key.Token); err != nil {
+		return nil, err
+	}
+	return key, nil
 }
 
 // Checks if a token is valid:
@@ -151,6 +175,9 @@ func (a *authManager) revokedTokenList() *tokenList {
 		tlist.Tokens[idx] = token
 		idx++
 	}
+	for _, key := range a.s3Keys {
+		tlist.S3Keys = append(tlist.S3Keys, key)
+	}
 
 	a.Unlock()
 	return &tokenList{TokenList: tlist}
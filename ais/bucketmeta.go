@@ -87,6 +87,7 @@ type (
 		wait         bool
 		needReMirror bool
 		needReEC     bool
+		needReEncode bool
 		terminate    bool
 	}
 )
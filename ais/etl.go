@@ -17,7 +17,6 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
-	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/etl"
 )
 
@@ -29,10 +28,6 @@ var startETL = atomic.NewBool(false)
 
 // [METHOD] /v1/etl
 func (t *targetrunner) etlHandler(w http.ResponseWriter, r *http.Request) {
-	if err := k8s.Detect(); err != nil {
-		t.writeErrSilent(w, r, err)
-		return
-	}
 	switch {
 	case r.Method == http.MethodPost:
 		apiItems, err := t.checkRESTItems(w, r, 1, false, cmn.URLPathETL.L)
@@ -117,11 +112,7 @@ func (t *targetrunner) stopETL(w http.ResponseWriter, r *http.Request) {
 }
 
 func (t *targetrunner) doETL(w http.ResponseWriter, r *http.Request, uuid string, bck *cluster.Bck, objName string) {
-	var (
-		comm etl.Communicator
-		err  error
-	)
-	comm, err = etl.GetCommunicator(uuid, t.si)
+	pipe, err := etl.GetPipeline(uuid, t.si)
 	if err != nil {
 		if _, ok := err.(*cmn.ErrNotFound); ok {
 			smap := t.owner.smap.Get()
@@ -134,12 +125,8 @@ func (t *targetrunner) doETL(w http.ResponseWriter, r *http.Request, uuid string
 		t.writeErr(w, r, err)
 		return
 	}
-	if err := comm.Do(w, r, bck, objName); err != nil {
-		t.writeErr(w, r, cmn.NewETLError(&cmn.ETLErrorContext{
-			UUID:    uuid,
-			PodName: comm.PodName(),
-			SvcName: comm.SvcName(),
-		}, err.Error()))
+	if err := pipe.Do(w, r, bck, objName); err != nil {
+		t.writeErr(w, r, err)
 	}
 }
 
@@ -356,13 +343,13 @@ func (p *proxyrunner) buildETL(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// startETL broadcasts a build or init ETL request and ensures only one ETL is running
+// startETL broadcasts a build or init ETL request and ensures that ETLs get started one at a time
 func (p *proxyrunner) startETL(w http.ResponseWriter, r *http.Request, body []byte, msgID string) (err error) {
 	if !startETL.CAS(false, true) {
-		return cmn.ErrETLOnlyOne
+		return cmn.ErrETLStarting
 	}
 	defer startETL.CAS(true, false)
-	if err := p.ensureNoETLs(); err != nil {
+	if err := p.ensureNoETL(msgID); err != nil {
 		return err
 	}
 
@@ -396,14 +383,16 @@ func (p *proxyrunner) startETL(w http.ResponseWriter, r *http.Request, body []by
 	return err
 }
 
-// ensureOneETL makes sure only no ETLs are running
-func (p *proxyrunner) ensureNoETLs() error {
+// ensureNoETL makes sure that ETL with the given ID is not running
+func (p *proxyrunner) ensureNoETL(id string) error {
 	etls, err := p.listETLs()
 	if err != nil {
 		return err
 	}
-	if len(etls) > 0 {
-		return cmn.ErrETLOnlyOne
+	for _, info := range etls {
+		if info.ID == id {
+			return fmt.Errorf("ETL %q already exists", id)
+		}
 	}
 	return nil
 }
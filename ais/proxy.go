@@ -79,7 +79,8 @@ type (
 			mtx  sync.RWMutex
 			pool nodeRegPool
 		}
-		qm queryMem
+		qm     queryMem
+		quotas quotaCache
 	}
 )
 
@@ -167,6 +168,7 @@ func (p *proxyrunner) Run() error {
 	p.authn = &authManager{
 		tokens:        make(map[string]*authn.Token),
 		revokedTokens: make(map[string]bool),
+		s3Keys:        make(map[string]*authn.S3Key),
 		version:       1,
 	}
 
@@ -335,6 +337,7 @@ func (p *proxyrunner) parseAPI(w http.ResponseWriter, r *http.Request, bckArgs *
 		return
 	}
 	bckArgs.bck = request.bck
+	bckArgs.objName = request.items[1]
 	if len(origURLBck) > 0 {
 		bckArgs.origURLBck = origURLBck[0]
 	}
@@ -477,6 +480,10 @@ func (p *proxyrunner) httpbckget(w http.ResponseWriter, r *http.Request) {
 		p.handleList(w, r, queryBcks, &msg)
 	case cmn.ActSummary:
 		p.bucketSummary(w, r, queryBcks, &msg)
+	case cmn.ActListTrash:
+		p.listTrash(w, r, queryBcks)
+	case cmn.ActQuotaUsage:
+		p.quotaUsage(w, r, queryBcks)
 	default:
 		p.writeErrAct(w, r, msg.Action)
 	}
@@ -571,6 +578,10 @@ func (p *proxyrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	if err := p.checkQuota(bck); err != nil {
+		p.writeErrQuota(w, r, err)
+		return
+	}
 
 	if nodeID == "" {
 		si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
@@ -886,6 +897,10 @@ func (p *proxyrunner) httpbckput(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 		}
+		if err := p.checkQuota(bckTo); err != nil {
+			p.writeErrQuota(w, r, err)
+			return
+		}
 		if _, err := cos.Mime(archiveMsg.Mime, archiveMsg.ArchName); err != nil {
 			p.writeErr(w, r, err)
 			return
@@ -935,6 +950,10 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 		p.hpostCreateBucket(w, r, msg, bck)
 		return
 	}
+	if msg.Action == cmn.ActRestoreBck {
+		p.hpostRestoreBucket(w, r, msg, bck)
+		return
+	}
 	// only the primary can do metasync
 	xactDtor := xaction.XactsDtor[msg.Action]
 	if xactDtor.Metasync {
@@ -1046,6 +1065,11 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 			return
 		}
 
+		if err := p.checkQuota(bckTo); err != nil && !internalMsg.DryRun {
+			p.writeErrQuota(w, r, err)
+			return
+		}
+
 		glog.Infof("%s bucket %s => %s", msg.Action, bck, bckTo)
 
 		var xactID string
@@ -1351,7 +1375,8 @@ func (p *proxyrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	request := &apiRequest{after: 1, prefix: cmn.URLPathObjects.L}
-	if msg.Action == cmn.ActRenameObject {
+	if msg.Action == cmn.ActRenameObject || msg.Action == cmn.ActPresignObject ||
+		msg.Action == cmn.ActRestoreObject {
 		request.after = 2
 	}
 	if err := p.parseAPIRequest(w, r, request); err != nil {
@@ -1389,8 +1414,26 @@ func (p *proxyrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 			p.writeErrMsg(w, r, "source must be an absolute path")
 			return
 		}
+		if err := p.checkQuota(bck); err != nil {
+			p.writeErrQuota(w, r, err)
+			return
+		}
 		p.promoteFQN(w, r, bck, &msg)
 		return
+	case cmn.ActPresignObject:
+		p.presignObj(w, r, bck, request.items[1], &msg)
+		return
+	case cmn.ActRestoreObject:
+		if err := p.checkACL(r.Header, bck, cmn.AccessPUT); err != nil {
+			p.writeErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		if !bck.IsAIS() {
+			p.writeErrActf(w, r, msg.Action, "not supported for remote buckets (%s)", bck)
+			return
+		}
+		p.restoreObj(w, r, bck, request.items[1])
+		return
 	default:
 		p.writeErrAct(w, r, msg.Action)
 	}
@@ -2590,8 +2633,10 @@ func (p *proxyrunner) tokenHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodDelete:
 		p.httpTokenDelete(w, r)
+	case http.MethodPut:
+		p.httpTokenPut(w, r)
 	default:
-		cmn.WriteErr405(w, r, http.MethodDelete)
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodPut)
 	}
 }
 
@@ -5,24 +5,47 @@
 package ais
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/s3compat"
 	"github.com/NVIDIA/aistore/authn"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 )
 
-var errInvalidToken = errors.New("invalid token")
+const presignMaxExpires = 7 * 24 * time.Hour
+
+var (
+	errInvalidToken     = errors.New("invalid token")
+	errInvalidPresigned = errors.New("invalid or expired presigned URL")
+)
 
 func (p *proxyrunner) httpTokenDelete(w http.ResponseWriter, r *http.Request) {
+	p.updateTokens(w, r, "revoke token")
+}
+
+// register S3 access keys (see authn.S3Key)
+func (p *proxyrunner) httpTokenPut(w http.ResponseWriter, r *http.Request) {
+	p.updateTokens(w, r, "register S3 keys")
+}
+
+func (p *proxyrunner) updateTokens(w http.ResponseWriter, r *http.Request, tag string) {
 	tokenList := &tokenList{}
 	if _, err := p.checkRESTItems(w, r, 0, false, cmn.URLPathTokens.L); err != nil {
 		return
 	}
-	if p.forwardCP(w, r, nil, "revoke token") {
+	if p.forwardCP(w, r, nil, tag) {
 		return
 	}
 	if err := cmn.ReadJSON(w, r, tokenList); err != nil {
@@ -55,6 +78,44 @@ func (p *proxyrunner) validateToken(hdr http.Header) (*authn.Token, error) {
 	return auth, nil
 }
 
+// Verifies AWS Signature V4 of an S3 request. S3 access key ID and secret key
+// are issued by AuthN that also registers them with the cluster along with the
+// user's token (see authn.S3Key). Upon success, the request gets converted into
+// a regular token-authenticated one, so that S3 API checks permissions the same
+// way native API does.
+func (p *proxyrunner) authenticateS3(r *http.Request) error {
+	authHdr := r.Header.Get(cmn.HdrAuthorization)
+	if strings.HasPrefix(authHdr, cmn.AuthenticationTypeBearer+" ") {
+		return nil // e.g., forwarded by another proxy - validated by checkACL
+	}
+	var token string
+	_, err := s3compat.VerifySigV4(r, func(accessKey string) (string, error) {
+		key, err := p.authn.lookupS3Key(accessKey)
+		if err != nil {
+			return "", err
+		}
+		token = key.Token
+		return key.SecretKey, nil
+	})
+	if err != nil {
+		return err
+	}
+	r.Header.Set(cmn.HdrAuthorization, cmn.AuthenticationTypeBearer+" "+token)
+	return nil
+}
+
+// S3 API: unlike native API, bucket access attributes are enforced
+// even when AuthN is disabled
+func (p *proxyrunner) checkACLS3(hdr http.Header, bck *cluster.Bck, ace cmn.AccessAttrs) error {
+	if cmn.GCO.Get().Auth.Enabled {
+		return p.checkACL(hdr, bck, ace)
+	}
+	if bck == nil {
+		return nil
+	}
+	return bck.Allow(ace)
+}
+
 // When AuthN is on, accessing a bucket requires two permissions:
 //   - access to the bucket is granted to a user
 //   - bucket ACL allows the required operation
@@ -106,3 +167,109 @@ func (p *proxyrunner) checkACL(hdr http.Header, bck *cluster.Bck, ace cmn.Access
 	}
 	return bck.Allow(ace)
 }
+
+////////////////////
+// presigned URLs //
+////////////////////
+
+// Presigned URL grants access to a single object and a single method (GET or PUT)
+// until it expires. The signature is HMAC-SHA256 of the method, bucket and object
+// names, and expiration time - keyed with a dedicated key derived from the AuthN
+// secret (so that the secret itself is never used to sign anything but tokens).
+func presignSig(secret, method, uname string, expires int64) string {
+	mac := hmac.New(sha256.New, presignKey(secret))
+	mac.Write([]byte(method + "\n" + uname + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func presignKey(secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("presign"))
+	return mac.Sum(nil)
+}
+
+// Validates signature, method, and expiration of a presigned URL
+func verifyPresigned(query url.Values, method, uname string, ace cmn.AccessAttrs, secret string, now time.Time) error {
+	switch {
+	case method == http.MethodGet && ace == cmn.AccessGET:
+	case method == http.MethodPut && ace == cmn.AccessPUT:
+	default: // HEAD, DELETE, APPEND, etc.
+		return errInvalidPresigned
+	}
+	expires, err := strconv.ParseInt(query.Get(cmn.URLParamPresignExpires), 10, 64)
+	if err != nil || now.Unix() > expires {
+		return errInvalidPresigned
+	}
+	expected := presignSig(secret, method, uname, expires)
+	if !hmac.Equal([]byte(expected), []byte(query.Get(cmn.URLParamPresignSig))) {
+		return errInvalidPresigned
+	}
+	return nil
+}
+
+func isPresigned(r *http.Request) bool {
+	return cmn.GCO.Get().Auth.Enabled && r.Header.Get(cmn.HdrAuthorization) == "" &&
+		r.URL.Query().Get(cmn.URLParamPresignSig) != ""
+}
+
+// POST {action: presign} /v1/objects/bucket-name/object-name
+func (p *proxyrunner) presignObj(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string,
+	msg *cmn.ActionMsg) {
+	var (
+		args = cmn.ActValPresign{}
+		ace  cmn.AccessAttrs
+		cfg  = cmn.GCO.Get()
+	)
+	if !cfg.Auth.Enabled {
+		p.writeErrMsg(w, r, "presigned URLs require AuthN to be enabled")
+		return
+	}
+	if err := cos.MorphMarshal(msg.Value, &args); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return
+	}
+	args.Method = strings.ToUpper(args.Method)
+	switch args.Method {
+	case http.MethodGet:
+		ace = cmn.AccessGET
+	case http.MethodPut:
+		ace = cmn.AccessPUT
+	default:
+		p.writeErrf(w, r, "%s: invalid method %q (expecting %s or %s)", msg.Action, args.Method,
+			http.MethodGet, http.MethodPut)
+		return
+	}
+	if args.Expires <= 0 || args.Expires.D() > presignMaxExpires {
+		p.writeErrf(w, r, "%s: invalid expiration %v (must be positive and not exceed %v)", msg.Action,
+			args.Expires, presignMaxExpires)
+		return
+	}
+	if err := p.checkACL(r.Header, bck, ace); err != nil {
+		p.writeErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	var (
+		expires = time.Now().Add(args.Expires.D()).Unix()
+		query   = cmn.AddBckToQuery(nil, bck.Bck)
+	)
+	if query == nil {
+		query = make(url.Values, 2)
+	}
+	query.Set(cmn.URLParamPresignExpires, strconv.FormatInt(expires, 10))
+	query.Set(cmn.URLParamPresignSig, presignSig(cfg.Auth.Secret, args.Method, bck.MakeUname(objName), expires))
+	presigned := p.si.URL(cmn.NetworkPublic) + cmn.URLPathObjects.Join(bck.Name, objName) + "?" + query.Encode()
+	p.writeJSON(w, r, presigned, "presign-object")
+}
+
+// Validates presigned URL in place of the bearer token. Bucket access
+// attributes still apply.
+func (p *proxyrunner) checkPresigned(r *http.Request, bck *cluster.Bck, objName string, ace cmn.AccessAttrs) error {
+	uname := bck.MakeUname(objName)
+	if err := verifyPresigned(r.URL.Query(), r.Method, uname, ace, cmn.GCO.Get().Auth.Secret, time.Now()); err != nil {
+		return err
+	}
+	if err := bck.Allow(ace); err != nil {
+		return fmt.Errorf("presigned %s %s: %v", r.Method, uname, err)
+	}
+	return nil
+}
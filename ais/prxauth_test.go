@@ -0,0 +1,90 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestPresignSig(t *testing.T) {
+	const (
+		secret = "aBitLongSecretKey"
+		uname  = "ais/@#/bck/obj"
+	)
+	var (
+		expires = time.Now().Add(time.Hour).Unix()
+		sig     = presignSig(secret, http.MethodGet, uname, expires)
+	)
+	tassert.Errorf(t, len(sig) == 64, "expected hex-encoded SHA256, got %q", sig)
+	tassert.Errorf(t, sig == presignSig(secret, http.MethodGet, uname, expires), "signature must be deterministic")
+
+	// every signed component matters
+	for _, other := range []string{
+		presignSig("otherSecret", http.MethodGet, uname, expires),
+		presignSig(secret, http.MethodPut, uname, expires),
+		presignSig(secret, http.MethodGet, uname+"2", expires),
+		presignSig(secret, http.MethodGet, uname, expires+1),
+	} {
+		tassert.Errorf(t, other != sig, "expected different signatures")
+	}
+	// the AuthN secret is not used as is
+	tassert.Errorf(t, string(presignKey(secret)) != secret, "expected derived presign key")
+}
+
+func TestVerifyPresigned(t *testing.T) {
+	const (
+		secret = "aBitLongSecretKey"
+		uname  = "ais/@#/bck/obj"
+	)
+	var (
+		now     = time.Now()
+		expires = now.Add(time.Hour).Unix()
+	)
+	newQuery := func(method string, expires int64) url.Values {
+		query := make(url.Values, 2)
+		query.Set(cmn.URLParamPresignExpires, strconv.FormatInt(expires, 10))
+		query.Set(cmn.URLParamPresignSig, presignSig(secret, method, uname, expires))
+		return query
+	}
+	tests := []struct {
+		name   string
+		query  url.Values
+		method string
+		ace    cmn.AccessAttrs
+		now    time.Time
+		ok     bool
+	}{
+		{"get", newQuery(http.MethodGet, expires), http.MethodGet, cmn.AccessGET, now, true},
+		{"put", newQuery(http.MethodPut, expires), http.MethodPut, cmn.AccessPUT, now, true},
+		{"expired", newQuery(http.MethodGet, expires), http.MethodGet, cmn.AccessGET, now.Add(2 * time.Hour), false},
+		{"method-mismatch", newQuery(http.MethodGet, expires), http.MethodPut, cmn.AccessPUT, now, false},
+		{"head", newQuery(http.MethodHead, expires), http.MethodHead, cmn.AccessObjHEAD, now, false},
+		{"delete", newQuery(http.MethodDelete, expires), http.MethodDelete, cmn.AccessObjDELETE, now, false},
+		{
+			"extended-expiration", url.Values{
+				cmn.URLParamPresignExpires: {strconv.FormatInt(expires+3600, 10)},
+				cmn.URLParamPresignSig:     {presignSig(secret, http.MethodGet, uname, expires)},
+			}, http.MethodGet, cmn.AccessGET, now, false,
+		},
+		{"no-expiration", url.Values{cmn.URLParamPresignSig: {"sig"}}, http.MethodGet, cmn.AccessGET, now, false},
+	}
+	for _, test := range tests {
+		err := verifyPresigned(test.query, test.method, uname, test.ace, secret, test.now)
+		if test.ok {
+			tassert.Errorf(t, err == nil, "%s: unexpected error %v", test.name, err)
+		} else {
+			tassert.Errorf(t, err == errInvalidPresigned, "%s: expected %v, got %v", test.name, errInvalidPresigned, err)
+		}
+	}
+	err := verifyPresigned(newQuery(http.MethodGet, expires), http.MethodGet, uname+"2", cmn.AccessGET, secret, now)
+	tassert.Errorf(t, err == errInvalidPresigned, "other object: expected %v, got %v", errInvalidPresigned, err)
+}
@@ -63,6 +63,8 @@ func (p *proxyrunner) httpcluget(w http.ResponseWriter, r *http.Request) {
 		p.ic.writeStatus(w, r)
 	case cmn.GetWhatMountpaths:
 		p.queryClusterMountpaths(w, r, what)
+	case cmn.GetWhatRebPlan:
+		p.rebalancePlan(w, r, what)
 	case cmn.GetWhatRemoteAIS:
 		remoteAIS, err := p.getRemoteAISInfo()
 		if err != nil {
@@ -721,7 +723,7 @@ func (p *proxyrunner) cluputJSON(w http.ResponseWriter, r *http.Request) {
 		_ = p.bcastGroup(args)
 		freeBcastArgs(args)
 		p.unreg(msg.Action)
-	case cmn.ActXactStart, cmn.ActXactStop:
+	case cmn.ActXactStart, cmn.ActXactStop, cmn.ActXactPause, cmn.ActXactResume:
 		p.xactStarStop(w, r, msg)
 	case cmn.ActSendOwnershipTbl:
 		p.sendOwnTbl(w, r, msg)
@@ -797,6 +799,14 @@ func (p *proxyrunner) xactStarStop(w http.ResponseWriter, r *http.Request, msg *
 		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
 		return
 	}
+	if msg.Action == cmn.ActXactPause || msg.Action == cmn.ActXactResume {
+		// NOTE: paused xactions remain paused on the targets - and visible
+		// via xaction stats - regardless of proxies (primary included)
+		if xactMsg.ID == "" && !xaction.IsPausable(xactMsg.Kind) {
+			p.writeErrf(w, r, "%s: cannot %s xaction %q", p.si, msg.Action, xactMsg.Kind)
+			return
+		}
+	}
 	if msg.Action == cmn.ActXactStart {
 		if xactMsg.Kind == cmn.ActRebalance {
 			p.rebalanceCluster(w, r)
@@ -277,7 +277,11 @@ func (p *proxyrunner) validateStartDownloadRequest(w http.ResponseWriter, r *htt
 	}
 	bck := cluster.NewBckEmbed(dlBase.Bck)
 	args := bckInitArgs{p: p, w: w, r: r, reqBody: body, bck: bck, perms: cmn.AccessRW}
-	if _, err = args.initAndTry(bck.Name); err != nil {
+	if bck, err = args.initAndTry(bck.Name); err != nil {
+		return
+	}
+	if err = p.checkQuota(bck); err != nil {
+		p.writeErrQuota(w, r, err)
 		return
 	}
 	ok = true
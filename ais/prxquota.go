@@ -0,0 +1,168 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// Bucket quota (see cmn.QuotaConf): proxy aggregates the usage that targets
+// track locally (see cluster.BckUsage) and caches it for up to quotaRefreshTime.
+// The very first refresh (that has targets walking the bucket) is shared by all
+// concurrent callers; subsequent refreshes happen in the background of serving
+// the cached usage.
+// The quota is enforced by proxies when redirecting PUT and APPEND requests and
+// when starting jobs that write into the bucket (copy, transform, promote,
+// download). Therefore, the quota is "soft": it can be exceeded by the writes
+// made within the refresh interval and by the jobs that are already running.
+
+const quotaRefreshTime = 10 * time.Second
+
+type (
+	quotaEntry struct {
+		usage      cmn.QuotaUsage
+		err        error // (the last refresh)
+		bid        uint64
+		updated    int64         // mono time
+		refreshing chan struct{} // closed upon refresh; nil when not refreshing
+	}
+	quotaCache struct {
+		mu sync.Mutex
+		m  map[string]*quotaEntry // by bucket uname
+	}
+	gatherUsageFunc func(bck *cluster.Bck) (cmn.QuotaUsage, error)
+)
+
+// GET /v1/buckets/bucket-name { "action": "quotausage" }
+func (p *proxyrunner) quotaUsage(w http.ResponseWriter, r *http.Request, queryBcks cmn.QueryBcks) {
+	bck := cluster.NewBckEmbed(cmn.Bck(queryBcks))
+	bckArgs := bckInitArgs{p: p, w: w, r: r, perms: cmn.AccessBckHEAD, bck: bck}
+	bck, err := bckArgs.initAndTry(queryBcks.Name)
+	if err != nil {
+		return
+	}
+	if !bck.Props.Quota.IsSet() {
+		p.writeErrf(w, r, "bucket %s has no quota", bck)
+		return
+	}
+	usage, err := p.quotas.usage(bck, true /*refresh*/, p.gatherUsage)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	p.writeJSON(w, r, usage, "quota_usage")
+}
+
+// Returns an error if the bucket is over its quota.
+func (p *proxyrunner) checkQuota(bck *cluster.Bck) error {
+	return p.quotas.check(bck, p.gatherUsage)
+}
+
+func (p *proxyrunner) writeErrQuota(w http.ResponseWriter, r *http.Request, err error) {
+	p.writeErr(w, r, err, http.StatusInsufficientStorage)
+}
+
+////////////////
+// quotaCache //
+////////////////
+
+func (qc *quotaCache) check(bck *cluster.Bck, gather gatherUsageFunc) error {
+	if bck.Props == nil { // (not yet created)
+		return nil
+	}
+	quota := &bck.Props.Quota
+	if !quota.IsSet() {
+		return nil
+	}
+	usage, err := qc.usage(bck, false, gather)
+	if err != nil {
+		glog.Errorf("failed to get %s usage: %v", bck, err)
+		return nil
+	}
+	if quota.Exceeded(&usage) {
+		return cmn.NewErrBucketQuotaExceeded(bck.Bck, quota, &usage)
+	}
+	return nil
+}
+
+func (qc *quotaCache) usage(bck *cluster.Bck, refresh bool, gather gatherUsageFunc) (usage cmn.QuotaUsage,
+	err error) {
+	var (
+		uname = bck.MakeUname("")
+		now   = mono.NanoTime()
+	)
+	qc.mu.Lock()
+	if qc.m == nil {
+		qc.m = make(map[string]*quotaEntry)
+	}
+	entry, ok := qc.m[uname]
+	if !ok || entry.bid != bck.Props.BID {
+		entry = &quotaEntry{bid: bck.Props.BID}
+		qc.m[uname] = entry
+	}
+	fresh := entry.updated != 0 && now-entry.updated < int64(quotaRefreshTime)
+	// use the cached usage unless it's stale and nobody else is refreshing it
+	if (fresh && !refresh) || (entry.refreshing != nil && entry.updated != 0) {
+		usage = entry.usage
+		qc.mu.Unlock()
+		return
+	}
+	// nothing cached yet: wait for the ongoing (first) refresh
+	if refreshing := entry.refreshing; refreshing != nil {
+		qc.mu.Unlock()
+		<-refreshing
+		qc.mu.Lock()
+		usage, err = entry.usage, entry.err
+		qc.mu.Unlock()
+		return
+	}
+	refreshing := make(chan struct{})
+	entry.refreshing = refreshing
+	qc.mu.Unlock()
+
+	usage, err = gather(bck)
+
+	qc.mu.Lock()
+	entry.refreshing, entry.err = nil, err
+	if err == nil {
+		entry.usage, entry.updated = usage, mono.NanoTime()
+	}
+	qc.mu.Unlock()
+	close(refreshing)
+	return
+}
+
+func (p *proxyrunner) gatherUsage(bck *cluster.Bck) (usage cmn.QuotaUsage, err error) {
+	args := allocBcastArgs()
+	args.req = cmn.ReqArgs{
+		Method: http.MethodGet,
+		Path:   cmn.URLPathBuckets.Join(bck.Name),
+		Query:  cmn.AddBckToQuery(nil, bck.Bck),
+		Body:   cos.MustMarshal(p.newAmsgActVal(cmn.ActQuotaUsage, nil)),
+	}
+	args.timeout = cmn.LongTimeout // (the first request walks the bucket)
+	args.fv = func() interface{} { return &cmn.QuotaUsage{} }
+	results := p.bcastGroup(args)
+	freeBcastArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			err = res.error()
+			break
+		}
+		tusage := res.v.(*cmn.QuotaUsage)
+		usage.Size += tusage.Size
+		usage.Objs += tusage.Objs
+	}
+	freeCallResults(results)
+	return
+}
@@ -0,0 +1,96 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestQuotaCheck(t *testing.T) {
+	var (
+		qc    = &quotaCache{}
+		bck   = cluster.NewBck("quota", cmn.ProviderAIS, cmn.NsGlobal, &cmn.BucketProps{BID: 1})
+		calls atomic.Int32
+		usage = cmn.QuotaUsage{Size: cos.MiB, Objs: 5}
+	)
+	gather := func(*cluster.Bck) (cmn.QuotaUsage, error) {
+		calls.Inc()
+		return usage, nil
+	}
+	// no quota, nothing to check
+	tassert.CheckFatal(t, qc.check(bck, gather))
+	tassert.CheckFatal(t, qc.check(cluster.NewBck("new", cmn.ProviderAIS, cmn.NsGlobal), gather))
+	tassert.Errorf(t, calls.Load() == 0, "expected no usage requests, got %d", calls.Load())
+
+	bck.Props.Quota = cmn.QuotaConf{MaxObjs: 10, MaxSize: 10 * cos.MiB}
+	tassert.CheckFatal(t, qc.check(bck, gather))
+	tassert.Errorf(t, calls.Load() == 1, "expected 1 usage request, got %d", calls.Load())
+
+	// cached for up to quotaRefreshTime
+	usage.Objs = 10
+	tassert.CheckFatal(t, qc.check(bck, gather))
+	tassert.Errorf(t, calls.Load() == 1, "expected cached usage, got %d requests", calls.Load())
+
+	// refreshed upon request
+	u, err := qc.usage(bck, true /*refresh*/, gather)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, u == usage, "expected %+v, got %+v", usage, u)
+	err = qc.check(bck, gather)
+	tassert.Errorf(t, err != nil, "expected quota (objects) exceeded")
+
+	// same bucket name, different BID (re-created bucket)
+	bck.Props.BID = 2
+	usage = cmn.QuotaUsage{Size: 10 * cos.MiB, Objs: 1}
+	err = qc.check(bck, gather)
+	tassert.Errorf(t, err != nil, "expected quota (size) exceeded")
+	tassert.Errorf(t, calls.Load() == 3, "expected 3 usage requests, got %d", calls.Load())
+
+	// usage unknown: the quota is soft
+	bck.Props.BID = 3
+	err = qc.check(bck, func(*cluster.Bck) (cmn.QuotaUsage, error) { return cmn.QuotaUsage{}, errors.New("failed") })
+	tassert.CheckFatal(t, err)
+}
+
+func TestQuotaFirstRefresh(t *testing.T) {
+	const callers = 16
+	var (
+		qc      = &quotaCache{}
+		bck     = cluster.NewBck("quota", cmn.ProviderAIS, cmn.NsGlobal, &cmn.BucketProps{BID: 1})
+		calls   atomic.Int32
+		release = make(chan struct{})
+		wg      = &sync.WaitGroup{}
+		errs    atomic.Int32
+	)
+	bck.Props.Quota = cmn.QuotaConf{MaxObjs: 10}
+	gather := func(*cluster.Bck) (cmn.QuotaUsage, error) {
+		calls.Inc()
+		<-release // (targets walking the bucket)
+		return cmn.QuotaUsage{Objs: 10}, nil
+	}
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := qc.check(bck, gather); err != nil {
+				errs.Inc()
+			}
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	tassert.Errorf(t, calls.Load() == 1, "expected a single usage request, got %d", calls.Load())
+	tassert.Errorf(t, errs.Load() == callers, "expected all %d callers to see exceeded quota, got %d",
+		callers, errs.Load())
+}
@@ -0,0 +1,77 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Rebalance plan (dry run): given a hypothetical change of the cluster
+// membership (see cmn.RebPlanMsg), each target walks its objects to find out
+// which of them would move and where (see ais/tgtrebplan.go). Nothing moves.
+
+func (p *proxyrunner) rebalancePlan(w http.ResponseWriter, r *http.Request, what string) {
+	msg := &cmn.RebPlanMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	smap := p.owner.smap.get()
+	for _, sid := range msg.Remove {
+		if smap.GetTarget(sid) == nil {
+			p.writeErrf(w, r, "%s: target %q is not a member of %s", p.si, sid, smap)
+			return
+		}
+	}
+	for _, sid := range msg.Add {
+		if smap.containsID(sid) {
+			p.writeErrf(w, r, "%s: node %q is already a member of %s", p.si, sid, smap)
+			return
+		}
+	}
+	args := allocBcastArgs()
+	args.req = cmn.ReqArgs{
+		Method: http.MethodGet,
+		Path:   cmn.URLPathDaemon.S,
+		Query:  url.Values{cmn.URLParamWhat: []string{what}},
+		Body:   cos.MustMarshal(msg),
+	}
+	args.timeout = cmn.LongTimeout // (targets walk all their objects)
+	args.fv = func() interface{} { return &cmn.TRebPlan{} }
+	results := p.bcastGroup(args)
+	freeBcastArgs(args)
+
+	plan := &cmn.RebPlan{Targets: make(map[string]*cmn.RebPlanTarget, len(results)+len(msg.Add))}
+	for _, sid := range msg.Add {
+		plan.Target(sid)
+	}
+	for _, res := range results {
+		if res.err != nil {
+			p.writeErr(w, r, res.error())
+			freeCallResults(results)
+			return
+		}
+		var (
+			tplan = res.v.(*cmn.TRebPlan)
+			src   = plan.Target(res.si.ID())
+		)
+		src.Stored.Add(tplan.Stored)
+		for sid, cnt := range tplan.Out {
+			src.Out.Add(cnt)
+			plan.Target(sid).In.Add(cnt)
+			plan.Moved.Add(cnt)
+		}
+	}
+	freeCallResults(results)
+	rate := msg.Rate
+	if rate == 0 {
+		rate = cmn.GCO.Get().Rebalance.Bwidth.Target
+	}
+	plan.Estimate(rate)
+	p.writeJSON(w, r, plan, what)
+}
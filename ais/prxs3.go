@@ -34,6 +34,13 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 		glog.Infof("S3Request: %s - %s", r.Method, r.URL)
 	}
 
+	if cmn.GCO.Get().Auth.Enabled {
+		if err := p.authenticateS3(r); err != nil {
+			p.writeErr(w, r, err, http.StatusForbidden)
+			return
+		}
+	}
+
 	// TODO: Fix the hack, https://github.com/tensorflow/tensorflow/issues/41798
 	cos.ReparseQuery(r)
 	apiItems, err := p.checkRESTItems(w, r, 0, true, cmn.URLPathS3.L)
@@ -55,7 +62,7 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 	case http.MethodGet:
 		if len(apiItems) == 0 {
 			// nothing  - list all the buckets
-			p.bckNamesToS3(w)
+			p.bckNamesToS3(w, r)
 			return
 		}
 		q := r.URL.Query()
@@ -77,6 +84,10 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 			p.bckListS3(w, r, apiItems[0])
 			return
 		}
+		if _, tagging := q[s3compat.URLParamTagging]; tagging {
+			p.redirectObjS3(w, r, apiItems, cmn.AccessObjHEAD)
+			return
+		}
 		// object data otherwise
 		p.getObjS3(w, r, apiItems)
 	case http.MethodPut:
@@ -94,13 +105,26 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 			p.putBckS3(w, r, apiItems[0])
 			return
 		}
+		if _, tagging := r.URL.Query()[s3compat.URLParamTagging]; tagging {
+			p.redirectObjS3(w, r, apiItems, cmn.AccessPATCH)
+			return
+		}
 		p.putObjS3(w, r, apiItems)
 	case http.MethodPost:
-		if len(apiItems) != 1 {
+		if len(apiItems) == 0 {
 			p.writeErr(w, r, errS3Req)
 			return
 		}
 		q := r.URL.Query()
+		if len(apiItems) > 1 {
+			_, mptStart := q[s3compat.URLParamMptUploads]
+			if !mptStart && q.Get(s3compat.URLParamMptUploadID) == "" {
+				p.writeErr(w, r, errS3Req)
+				return
+			}
+			p.redirectObjS3(w, r, apiItems, cmn.AccessPUT)
+			return
+		}
 		if _, multiple := q[s3compat.URLParamMultiDelete]; !multiple {
 			p.writeErr(w, r, errS3Req)
 			return
@@ -121,6 +145,10 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 			p.delBckS3(w, r, apiItems[0])
 			return
 		}
+		if _, tagging := r.URL.Query()[s3compat.URLParamTagging]; tagging {
+			p.redirectObjS3(w, r, apiItems, cmn.AccessPATCH)
+			return
+		}
 		p.delObjS3(w, r, apiItems)
 	default:
 		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodHead,
@@ -129,7 +157,11 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 }
 
 // GET s3/
-func (p *proxyrunner) bckNamesToS3(w http.ResponseWriter) {
+func (p *proxyrunner) bckNamesToS3(w http.ResponseWriter, r *http.Request) {
+	if err := p.checkACLS3(r.Header, nil, cmn.AccessListBuckets); err != nil {
+		p.writeErr(w, r, err, http.StatusForbidden)
+		return
+	}
 	var (
 		bmd   = p.owner.bmd.get()
 		query = cmn.QueryBcks{Provider: cmn.ProviderAIS}
@@ -156,6 +188,10 @@ func (p *proxyrunner) putBckS3(w http.ResponseWriter, r *http.Request, bucket st
 		p.writeErr(w, r, err)
 		return
 	}
+	if err := p.checkACLS3(r.Header, nil, cmn.AccessCreateBucket); err != nil {
+		p.writeErr(w, r, err, http.StatusForbidden)
+		return
+	}
 	msg := cmn.ActionMsg{Action: cmn.ActCreateBck}
 	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
 		return
@@ -178,7 +214,7 @@ func (p *proxyrunner) delBckS3(w http.ResponseWriter, r *http.Request, bucket st
 		p.writeErr(w, r, err, http.StatusNotFound)
 		return
 	}
-	if err := bck.Allow(cmn.AccessDestroyBucket); err != nil {
+	if err := p.checkACLS3(r.Header, bck, cmn.AccessDestroyBucket); err != nil {
 		p.writeErr(w, r, err, http.StatusForbidden)
 		return
 	}
@@ -204,7 +240,7 @@ func (p *proxyrunner) delMultipleObjs(w http.ResponseWriter, r *http.Request, bu
 		p.writeErr(w, r, err, http.StatusNotFound)
 		return
 	}
-	if err := bck.Allow(cmn.AccessObjDELETE); err != nil {
+	if err := p.checkACLS3(r.Header, bck, cmn.AccessObjDELETE); err != nil {
 		p.writeErr(w, r, err, http.StatusForbidden)
 		return
 	}
@@ -248,7 +284,7 @@ func (p *proxyrunner) headBckS3(w http.ResponseWriter, r *http.Request, bucket s
 		p.writeErr(w, r, err, http.StatusNotFound)
 		return
 	}
-	if err := bck.Allow(cmn.AccessBckHEAD); err != nil {
+	if err := p.checkACLS3(r.Header, bck, cmn.AccessBckHEAD); err != nil {
 		p.writeErr(w, r, err, http.StatusForbidden)
 		return
 	}
@@ -271,9 +307,16 @@ func (p *proxyrunner) bckListS3(w http.ResponseWriter, r *http.Request, bucket s
 		p.writeErr(w, r, err)
 		return
 	}
-	smsg := cmn.SelectMsg{UUID: cos.GenUUID(), TimeFormat: time.RFC3339}
+	if err := p.checkACLS3(r.Header, bck, cmn.AccessObjLIST); err != nil {
+		p.writeErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	var (
+		query = r.URL.Query()
+		smsg  = cmn.SelectMsg{UUID: cos.GenUUID(), TimeFormat: time.RFC3339}
+	)
 	smsg.AddProps(cmn.GetPropsSize, cmn.GetPropsChecksum, cmn.GetPropsAtime, cmn.GetPropsVersion)
-	s3compat.FillMsgFromS3Query(r.URL.Query(), &smsg)
+	s3compat.FillMsgFromS3Query(query, &smsg)
 
 	locationIsAIS := bck.IsAIS() || smsg.IsFlagSet(cmn.SelectCached)
 	var (
@@ -290,8 +333,7 @@ func (p *proxyrunner) bckListS3(w http.ResponseWriter, r *http.Request, bucket s
 		return
 	}
 
-	resp := s3compat.NewListObjectResult()
-	resp.ContinuationToken = smsg.ContinuationToken
+	resp := s3compat.NewListObjectResult(query)
 	resp.FillFromAisBckList(objList, &smsg)
 	sgl := memsys.DefaultPageMM().NewSGL(0)
 	resp.MustMarshal(sgl)
@@ -315,7 +357,7 @@ func (p *proxyrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items []
 		p.writeErr(w, r, err)
 		return
 	}
-	if err := bckSrc.Allow(cmn.AccessGET); err != nil {
+	if err := p.checkACLS3(r.Header, bckSrc, cmn.AccessGET); err != nil {
 		p.writeErr(w, r, err, http.StatusForbidden)
 		return
 	}
@@ -333,10 +375,14 @@ func (p *proxyrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items []
 		smap = p.owner.smap.get()
 		err  error
 	)
-	if err = bckDst.Allow(cmn.AccessPUT); err != nil {
+	if err = p.checkACLS3(r.Header, bckDst, cmn.AccessPUT); err != nil {
 		p.writeErr(w, r, err, http.StatusForbidden)
 		return
 	}
+	if err = p.checkQuota(bckDst); err != nil {
+		p.writeErrQuota(w, r, err)
+		return
+	}
 	objName := strings.Trim(parts[1], "/")
 	si, err = cluster.HrwTarget(bckSrc.MakeUname(objName), &smap.Smap)
 	if err != nil {
@@ -367,10 +413,14 @@ func (p *proxyrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, ite
 		smap = p.owner.smap.get()
 		err  error
 	)
-	if err = bck.Allow(cmn.AccessPUT); err != nil {
+	if err = p.checkACLS3(r.Header, bck, cmn.AccessPUT); err != nil {
 		p.writeErr(w, r, err, http.StatusForbidden)
 		return
 	}
+	if err = p.checkQuota(bck); err != nil {
+		p.writeErrQuota(w, r, err)
+		return
+	}
 	objName := path.Join(items[1:]...)
 	si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
@@ -393,6 +443,43 @@ func (p *proxyrunner) putObjS3(w http.ResponseWriter, r *http.Request, items []s
 	p.copyObjS3(w, r, items)
 }
 
+// Redirect object request to the target that owns the object. Used for:
+// - POST s3/bckName/objName?uploads (start) and POST s3/bckName/objName?uploadId=ID (complete)
+//   multipart upload
+// - GET|PUT|DEL s3/bckName/objName?tagging
+func (p *proxyrunner) redirectObjS3(w http.ResponseWriter, r *http.Request, items []string, ace cmn.AccessAttrs) {
+	started := time.Now()
+	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if err := p.checkACLS3(r.Header, bck, ace); err != nil {
+		p.writeErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if ace == cmn.AccessPUT {
+		if err := p.checkQuota(bck); err != nil {
+			p.writeErrQuota(w, r, err)
+			return
+		}
+	}
+	var (
+		smap    = p.owner.smap.get()
+		objName = path.Join(items[1:]...)
+	)
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("AISS3: %s %s/%s => %s", r.Method, bck, objName, si)
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraData)
+	p.s3Redirect(w, r, si, redirectURL, bck.Name)
+}
+
 // GET s3/<bucket-name/<object-name>[?uuid=<etl-uuid>]
 func (p *proxyrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	started := time.Now()
@@ -410,7 +497,7 @@ func (p *proxyrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []s
 		smap = p.owner.smap.get()
 		err  error
 	)
-	if err = bck.Allow(cmn.AccessGET); err != nil {
+	if err = p.checkACLS3(r.Header, bck, cmn.AccessGET); err != nil {
 		p.writeErr(w, r, err, http.StatusForbidden)
 		return
 	}
@@ -439,7 +526,7 @@ func (p *proxyrunner) headObjS3(w http.ResponseWriter, r *http.Request, items []
 		p.writeErr(w, r, err)
 		return
 	}
-	if err := bck.Allow(cmn.AccessObjHEAD); err != nil {
+	if err := p.checkACLS3(r.Header, bck, cmn.AccessObjHEAD); err != nil {
 		p.writeErr(w, r, err, http.StatusForbidden)
 		return
 	}
@@ -473,7 +560,7 @@ func (p *proxyrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []s
 		smap = p.owner.smap.get()
 		err  error
 	)
-	if err = bck.Allow(cmn.AccessObjDELETE); err != nil {
+	if err = p.checkACLS3(r.Header, bck, cmn.AccessObjDELETE); err != nil {
 		p.writeErr(w, r, err, http.StatusForbidden)
 		return
 	}
@@ -497,6 +584,10 @@ func (p *proxyrunner) getBckVersioningS3(w http.ResponseWriter, r *http.Request,
 		p.writeErr(w, r, err)
 		return
 	}
+	if err := p.checkACLS3(r.Header, bck, cmn.AccessBckHEAD); err != nil {
+		p.writeErr(w, r, err, http.StatusForbidden)
+		return
+	}
 	resp := s3compat.NewVersioningConfiguration(bck.Props.Versioning.Enabled)
 	sgl := memsys.DefaultPageMM().NewSGL(0)
 	resp.MustMarshal(sgl)
@@ -531,6 +622,10 @@ func (p *proxyrunner) putBckVersioningS3(w http.ResponseWriter, r *http.Request,
 		p.writeErrMsg(w, r, "bucket does not exist", http.StatusNotFound)
 		return
 	}
+	if err := p.checkACLS3(r.Header, bck, cmn.AccessPATCH); err != nil {
+		p.writeErr(w, r, err, http.StatusForbidden)
+		return
+	}
 	decoder := xml.NewDecoder(r.Body)
 	defer cos.Close(r.Body)
 	vconf := &s3compat.VersioningConfiguration{}
@@ -0,0 +1,151 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Soft delete (see cmn.TrashConf and ais/tgttrash.go)
+
+// GET /v1/buckets[/bucket-name] { "action": "listtrash" }
+func (p *proxyrunner) listTrash(w http.ResponseWriter, r *http.Request, queryBcks cmn.QueryBcks) {
+	if queryBcks.Name == "" {
+		if err := p.checkACL(r.Header, nil, cmn.AccessListBuckets); err != nil {
+			p.writeErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+	} else {
+		bck := cluster.NewBckEmbed(cmn.Bck(queryBcks))
+		if err := bck.Init(p.owner.bmd); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		if err := p.checkACL(r.Header, bck, cmn.AccessObjLIST); err != nil {
+			p.writeErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+	}
+	entries, err := p.gatherTrash(queryBcks)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	p.writeJSON(w, r, entries, "list_trash")
+}
+
+// Returns destroyed buckets (when the bucket name is empty) or deleted objects
+// of a given bucket, sorted by name; for the same name, the most recently
+// deleted entry wins.
+func (p *proxyrunner) gatherTrash(queryBcks cmn.QueryBcks) (entries cmn.TrashEntries, err error) {
+	args := allocBcastArgs()
+	args.req = cmn.ReqArgs{
+		Method: http.MethodGet,
+		Path:   cmn.URLPathBuckets.Join(queryBcks.Name),
+		Query:  cmn.AddBckToQuery(nil, cmn.Bck(queryBcks)),
+		Body:   cos.MustMarshal(p.newAmsgActVal(cmn.ActListTrash, nil)),
+	}
+	args.fv = func() interface{} { return &cmn.TrashEntries{} }
+	results := p.bcastGroup(args)
+	freeBcastArgs(args)
+
+	latest := make(map[string]*cmn.TrashEntry)
+	for _, res := range results {
+		if res.err != nil {
+			err = res.error()
+			freeCallResults(results)
+			return nil, err
+		}
+		for _, entry := range *res.v.(*cmn.TrashEntries) {
+			uname := entry.Bck.MakeUname(entry.ObjName)
+			if prev, ok := latest[uname]; ok && prev.Deleted >= entry.Deleted {
+				continue
+			}
+			latest[uname] = entry
+		}
+	}
+	freeCallResults(results)
+	entries = make(cmn.TrashEntries, 0, len(latest))
+	for _, entry := range latest {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		ui, uj := entries[i].Bck.MakeUname(entries[i].ObjName), entries[j].Bck.MakeUname(entries[j].ObjName)
+		return ui < uj
+	})
+	return
+}
+
+// POST { action: restore_bck } /v1/buckets/bucket-name
+func (p *proxyrunner) hpostRestoreBucket(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg, bck *cluster.Bck) {
+	if err := p.checkACL(r.Header, nil, cmn.AccessCreateBucket); err != nil {
+		p.writeErr(w, r, err, http.StatusUnauthorized)
+		return
+	}
+	if bck.Provider == "" {
+		bck.Provider = cmn.ProviderAIS
+	}
+	if err := bck.Validate(); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if !bck.IsAIS() {
+		p.writeErrf(w, r, "cannot restore %s: only ais buckets can be restored", bck)
+		return
+	}
+	if p.forwardCP(w, r, msg, bck.Name) {
+		return
+	}
+	dbcks, err := p.gatherTrash(cmn.QueryBcks{Provider: bck.Provider, Ns: bck.Ns})
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	var entry *cmn.TrashEntry
+	for _, dbck := range dbcks {
+		if dbck.Bck.Equal(bck.Bck) && (entry == nil || dbck.Deleted > entry.Deleted) {
+			entry = dbck
+		}
+	}
+	if entry == nil || entry.Props == nil {
+		p.writeErr(w, r, cmn.NewNotFoundError("destroyed bucket %s", bck))
+		return
+	}
+	bck.Props = entry.Props.Clone()
+	msg.Value = entry
+	if err := p.createBucket(msg, bck); err != nil {
+		errCode := http.StatusInternalServerError
+		if _, ok := err.(*cmn.ErrBucketAlreadyExists); ok {
+			errCode = http.StatusConflict
+		}
+		p.writeErr(w, r, err, errCode)
+		return
+	}
+	glog.Infof("%s: restored %s (destroyed %v)", p.si, bck, time.Unix(0, entry.Deleted))
+}
+
+// POST { action: restoreobj } /v1/objects/bucket-name/object-name
+func (p *proxyrunner) restoreObj(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string) {
+	started := time.Now()
+	smap := p.owner.smap.get()
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%q %s/%s => %s", cmn.ActRestoreObject, bck.Name, objName, si)
+	}
+	// NOTE: Code 307 is the only way to http-redirect with the original JSON payload.
+	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraControl)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+}
@@ -25,6 +25,7 @@ type bckInitArgs struct {
 	reqBody []byte          // request body of original request
 
 	origURLBck string
+	objName    string // when accessing an object (see also checkPresigned)
 	bck        *cluster.Bck
 	msg        *cmn.ActionMsg
 
@@ -148,6 +149,12 @@ func (args *bckInitArgs) _requiresPermission(perm cmn.AccessAttrs) bool {
 }
 
 func (args *bckInitArgs) _checkACL(bck *cluster.Bck) (errCode int, err error) {
+	if args.objName != "" && isPresigned(args.r) {
+		if err = args.p.checkPresigned(args.r, bck, args.objName, args.perms); err != nil {
+			errCode = http.StatusForbidden
+		}
+		return
+	}
 	if err = args.p.checkACL(args.r.Header, bck, args.perms); err != nil {
 		errCode = http.StatusForbidden
 	}
@@ -314,10 +314,12 @@ func (p *proxyrunner) setBucketProps(msg *cmn.ActionMsg, bck *cluster.Bck, nprop
 	}
 	c.msg.BMDVersion = bmd.version()
 
-	// 4. if remirror|re-EC|TBD-storage-svc
-	if ctx.needReMirror || ctx.needReEC {
+	// 4. if remirror|re-EC|re-encode
+	if ctx.needReMirror || ctx.needReEC || ctx.needReEncode {
 		action := cmn.ActMakeNCopies
-		if ctx.needReEC {
+		if ctx.needReEncode {
+			action = cmn.ActReencode // takes care of both
+		} else if ctx.needReEC {
 			action = cmn.ActECEncode
 		}
 		nl := xaction.NewXactNL(c.uuid, action, &c.smap.Smap, nil, bck.Bck)
@@ -346,6 +348,7 @@ func (p *proxyrunner) _setPropsPre(ctx *bmdModifier, clone *bucketMD) (err error
 	}
 	ctx.needReMirror = reMirror(bprops, ctx.setProps)
 	ctx.needReEC = reEC(bprops, ctx.setProps, bck)
+	ctx.needReEncode = reEncode(bprops, ctx.setProps)
 	clone.set(bck, ctx.setProps)
 	return nil
 }
@@ -984,14 +987,8 @@ func (p *proxyrunner) makeNewBckProps(bck *cluster.Bck, propsToUpdate *cmn.Bucke
 		nprops.Versioning.Enabled = false
 		// TODO: Check if the `RefDirectory` does not overlap with other buckets.
 	}
-	if bprops.EC.Enabled && nprops.EC.Enabled {
-		sameSlices := bprops.EC.DataSlices == nprops.EC.DataSlices && bprops.EC.ParitySlices == nprops.EC.ParitySlices
-		sameLimit := bprops.EC.ObjSizeLimit == nprops.EC.ObjSizeLimit
-		if !sameSlices || (!sameLimit && !propsToUpdate.Force) {
-			err = fmt.Errorf("%s: once enabled, EC configuration can be only disabled but cannot change", p.si)
-			return
-		}
-	} else if nprops.EC.Enabled {
+	// NOTE: changing EC configuration of an EC-enabled bucket triggers re-encoding (see reEncode)
+	if !bprops.EC.Enabled && nprops.EC.Enabled {
 		if nprops.EC.DataSlices == 0 {
 			nprops.EC.DataSlices = 1
 		}
@@ -1008,9 +1005,10 @@ func (p *proxyrunner) makeNewBckProps(bck *cluster.Bck, propsToUpdate *cmn.Bucke
 	}
 
 	// cannot run make-n-copies and EC on the same bucket at the same time
+	// (re-encode, if needed, takes care of both)
 	remirror := reMirror(bprops, nprops)
 	reec := reEC(bprops, nprops, bck)
-	if len(creating) == 0 && remirror && reec {
+	if len(creating) == 0 && remirror && reec && !reEncode(bprops, nprops) {
 		err = cmn.NewErrBckIsBusy(bck.Bck)
 		return
 	}
@@ -15,6 +15,22 @@ const (
 	URLParamPolicy      = "policy"
 	URLParamACL         = "acl"
 	URLParamMultiDelete = "delete"
+	URLParamTagging     = "tagging"
+
+	// list objects
+	URLParamListType          = "list-type"
+	URLParamMaxKeys           = "max-keys"
+	URLParamPrefix            = "prefix"
+	URLParamDelimiter         = "delimiter"
+	URLParamMarker            = "marker"
+	URLParamContinuationToken = "continuation-token"
+	URLParamStartAfter        = "start-after"
+	URLParamFetchOwner        = "fetch-owner"
+
+	// multipart upload
+	URLParamMptUploads    = "uploads"
+	URLParamMptUploadID   = "uploadId"
+	URLParamMptPartNumber = "partNumber"
 
 	versioningEnabled  = "Enabled"
 	versioningDisabled = "Suspended"
@@ -23,6 +39,9 @@ const (
 	// TODO: can it be omitted? // storageClass = "STANDARD"
 
 	// Headers
-	headerETag   = "ETag"
-	HeaderObjSrc = "x-amz-copy-source"
+	HeaderETag         = "ETag"
+	HeaderObjSrc       = "x-amz-copy-source"
+	HeaderMetaPrefix   = "x-amz-meta-"
+	HeaderTagging      = "x-amz-tagging"
+	HeaderTaggingCount = "x-amz-tagging-count"
 )
@@ -0,0 +1,158 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// S3 user-defined metadata (x-amz-meta-*) and object tags are stored as LOM
+// custom metadata - the former under the original (lowercase) header names,
+// the latter with `tagMDPrefix` prepended to the tag key
+
+const (
+	maxTags        = 10 // as per AWS docs
+	maxTagKeyLen   = 128
+	maxTagValueLen = 256
+
+	tagMDPrefix = "x-amz-tag-"
+)
+
+type (
+	// Get object tagging response and put object tagging request
+	Tagging struct {
+		XMLName xml.Name `xml:"Tagging"`
+		Ns      string   `xml:"xmlns,attr,omitempty"`
+		TagSet  []*Tag   `xml:"TagSet>Tag"`
+	}
+	Tag struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	}
+)
+
+// Returns custom metadata to store with a new object: user-defined metadata
+// from the request headers and tags from the x-amz-tagging header
+func CustomMDFromHeader(header http.Header) (cos.SimpleKVs, error) {
+	var md cos.SimpleKVs
+	for k, v := range header {
+		k = strings.ToLower(k)
+		if !strings.HasPrefix(k, HeaderMetaPrefix) || len(v) == 0 {
+			continue
+		}
+		if md == nil {
+			md = make(cos.SimpleKVs, len(header))
+		}
+		md[k] = strings.Join(v, ",")
+	}
+	tagging := header.Get(HeaderTagging)
+	if tagging == "" {
+		return md, nil
+	}
+	query, err := url.ParseQuery(tagging)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s header %q: %v", HeaderTagging, tagging, err)
+	}
+	tags := &Tagging{TagSet: make([]*Tag, 0, len(query))}
+	for k, v := range query {
+		tags.TagSet = append(tags.TagSet, &Tag{Key: k, Value: strings.Join(v, ",")})
+	}
+	if err := tags.validate(); err != nil {
+		return nil, err
+	}
+	if md == nil {
+		md = make(cos.SimpleKVs, len(tags.TagSet))
+	}
+	for _, tag := range tags.TagSet {
+		md[tagMDPrefix+tag.Key] = tag.Value
+	}
+	return md, nil
+}
+
+// Sets S3 response headers: ETag, user-defined metadata, and the number of tags
+func SetObjHeaders(header http.Header, lom *cluster.LOM) {
+	SetETag(header, lom)
+	var numTags int
+	for k, v := range lom.Custom() {
+		switch {
+		case strings.HasPrefix(k, HeaderMetaPrefix):
+			header.Set(k, v)
+		case strings.HasPrefix(k, tagMDPrefix):
+			numTags++
+		}
+	}
+	if numTags > 0 {
+		header.Set(HeaderTaggingCount, strconv.Itoa(numTags))
+	}
+}
+
+func NewTagging(custom cos.SimpleKVs) *Tagging {
+	tags := &Tagging{Ns: s3Namespace, TagSet: make([]*Tag, 0, 4)}
+	for k, v := range custom {
+		if strings.HasPrefix(k, tagMDPrefix) {
+			tags.TagSet = append(tags.TagSet, &Tag{Key: strings.TrimPrefix(k, tagMDPrefix), Value: v})
+		}
+	}
+	return tags
+}
+
+// Returns a copy of custom metadata with all the tags replaced with the given ones
+// (nil tagging removes all tags)
+func (tags *Tagging) ApplyTo(custom cos.SimpleKVs) (cos.SimpleKVs, error) {
+	var l int
+	if tags != nil {
+		if err := tags.validate(); err != nil {
+			return nil, err
+		}
+		l = len(tags.TagSet)
+	}
+	md := make(cos.SimpleKVs, len(custom)+l)
+	for k, v := range custom {
+		if !strings.HasPrefix(k, tagMDPrefix) {
+			md[k] = v
+		}
+	}
+	if tags != nil {
+		for _, tag := range tags.TagSet {
+			md[tagMDPrefix+tag.Key] = tag.Value
+		}
+	}
+	return md, nil
+}
+
+func (tags *Tagging) validate() error {
+	if len(tags.TagSet) > maxTags {
+		return fmt.Errorf("too many tags (%d, max %d)", len(tags.TagSet), maxTags)
+	}
+	keys := make(cos.StringSet, len(tags.TagSet))
+	for _, tag := range tags.TagSet {
+		if tag.Key == "" || len(tag.Key) > maxTagKeyLen {
+			return fmt.Errorf("invalid tag key %q (must be non-empty, max %d characters)", tag.Key, maxTagKeyLen)
+		}
+		if len(tag.Value) > maxTagValueLen {
+			return fmt.Errorf("tag %q: value is too long (max %d characters)", tag.Key, maxTagValueLen)
+		}
+		if keys.Contains(tag.Key) {
+			return fmt.Errorf("duplicate tag key %q", tag.Key)
+		}
+		keys.Add(tag.Key)
+	}
+	return nil
+}
+
+func (tags *Tagging) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(tags)
+	cos.AssertNoErr(err)
+}
@@ -0,0 +1,270 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// NOTE: in-progress multipart uploads are kept in memory by the target that
+// owns (via HRW) the object in question, while the uploaded parts are stored
+// as the target's workfiles; target restart aborts all uploads in progress.
+// Uploads that are neither completed nor aborted expire (along with their
+// parts) after mptUploadTTL of inactivity - see HousekeepUploads.
+
+const (
+	maxPartNum = 10000 // as per AWS docs
+
+	MptHKName     = "s3-mpt-uploads"
+	MptHKInterval = time.Hour
+	mptUploadTTL  = 24 * time.Hour
+)
+
+type (
+	// Create multipart upload response
+	InitiateMptUploadResult struct {
+		XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+		Ns       string   `xml:"xmlns,attr"`
+		Bucket   string   `xml:"Bucket"`
+		Key      string   `xml:"Key"`
+		UploadID string   `xml:"UploadId"`
+	}
+
+	// Complete multipart upload request and response
+	CompleteMptUpload struct {
+		XMLName xml.Name    `xml:"CompleteMultipartUpload"`
+		Parts   []*PartInfo `xml:"Part"`
+	}
+	CompleteMptUploadResult struct {
+		XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+		Ns      string   `xml:"xmlns,attr"`
+		Bucket  string   `xml:"Bucket"`
+		Key     string   `xml:"Key"`
+		ETag    string   `xml:"ETag"`
+	}
+
+	// List parts response
+	ListPartsResult struct {
+		XMLName  xml.Name    `xml:"ListPartsResult"`
+		Ns       string      `xml:"xmlns,attr"`
+		Bucket   string      `xml:"Bucket"`
+		Key      string      `xml:"Key"`
+		UploadID string      `xml:"UploadId"`
+		Parts    []*PartInfo `xml:"Part"`
+	}
+	PartInfo struct {
+		PartNumber int64  `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+		Size       int64  `xml:"Size,omitempty"`
+	}
+
+	// uploaded part (workfile)
+	MptPart struct {
+		MD5  string
+		FQN  string
+		Size int64
+		Num  int64
+	}
+	mptUpload struct {
+		uname  string        // bucket/object being uploaded
+		parts  []*MptPart    // sorted by part number
+		custom cos.SimpleKVs // user metadata and tags of the object
+		mtime  time.Time     // last activity (see HousekeepUploads)
+	}
+)
+
+var ups = struct {
+	sync.Mutex
+	m map[string]*mptUpload // by upload ID
+}{m: make(map[string]*mptUpload)}
+
+func NewUploadID() string { return cos.GenUUID() }
+
+// Start a new multipart upload
+func InitUpload(id, uname string, custom cos.SimpleKVs) {
+	ups.Lock()
+	ups.m[id] = &mptUpload{uname: uname, parts: make([]*MptPart, 0, 16), custom: custom, mtime: time.Now()}
+	ups.Unlock()
+}
+
+// NOTE: caller must hold the lock
+func getUpload(id, uname string) (*mptUpload, error) {
+	upload, ok := ups.m[id]
+	if !ok || upload.uname != uname {
+		return nil, cmn.NewNotFoundError("upload %q", id)
+	}
+	upload.mtime = time.Now()
+	return upload, nil
+}
+
+// Add a part to the upload; returns the previously uploaded part with the same number, if any
+// (to be removed by the caller)
+func AddPart(id, uname string, npart *MptPart) (prev *MptPart, err error) {
+	ups.Lock()
+	defer ups.Unlock()
+	upload, err := getUpload(id, uname)
+	if err != nil {
+		return nil, err
+	}
+	i := sort.Search(len(upload.parts), func(i int) bool { return upload.parts[i].Num >= npart.Num })
+	if i < len(upload.parts) && upload.parts[i].Num == npart.Num {
+		prev = upload.parts[i]
+		upload.parts[i] = npart
+		return
+	}
+	upload.parts = append(upload.parts, nil)
+	copy(upload.parts[i+1:], upload.parts[i:])
+	upload.parts[i] = npart
+	return
+}
+
+// Validate the client-provided list of parts against the uploaded ones and
+// return the latter in the requested order, along with the object's custom metadata
+func CheckParts(id, uname string, parts []*PartInfo) ([]*MptPart, cos.SimpleKVs, error) {
+	ups.Lock()
+	defer ups.Unlock()
+	upload, err := getUpload(id, uname)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(parts) == 0 {
+		return nil, nil, fmt.Errorf("upload %q: empty list of parts", id)
+	}
+	res := make([]*MptPart, 0, len(parts))
+	for i, part := range parts {
+		if i > 0 && part.PartNumber <= parts[i-1].PartNumber {
+			return nil, nil, fmt.Errorf("upload %q: parts must be listed in ascending order (%d after %d)",
+				id, part.PartNumber, parts[i-1].PartNumber)
+		}
+		j := sort.Search(len(upload.parts), func(j int) bool { return upload.parts[j].Num >= part.PartNumber })
+		if j == len(upload.parts) || upload.parts[j].Num != part.PartNumber {
+			return nil, nil, cmn.NewNotFoundError("upload %q: part %d", id, part.PartNumber)
+		}
+		mpart := upload.parts[j]
+		if etag := strings.Trim(part.ETag, "\""); etag != "" && etag != mpart.MD5 {
+			return nil, nil, fmt.Errorf("upload %q: part %d ETag mismatch (%q vs %q)", id, part.PartNumber,
+				etag, mpart.MD5)
+		}
+		res = append(res, mpart)
+	}
+	return res, upload.custom, nil
+}
+
+// Remove the upload from memory and return all its parts (for the caller to cleanup)
+func FinishUpload(id, uname string) ([]*MptPart, error) {
+	ups.Lock()
+	defer ups.Unlock()
+	upload, err := getUpload(id, uname)
+	if err != nil {
+		return nil, err
+	}
+	delete(ups.m, id)
+	return upload.parts, nil
+}
+
+func ListParts(id, uname string) ([]*PartInfo, error) {
+	ups.Lock()
+	defer ups.Unlock()
+	upload, err := getUpload(id, uname)
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]*PartInfo, 0, len(upload.parts))
+	for _, part := range upload.parts {
+		parts = append(parts, &PartInfo{PartNumber: part.Num, ETag: QuoteETag(part.MD5), Size: part.Size})
+	}
+	return parts, nil
+}
+
+// Remove expired uploads and their parts (housekeeping callback - see hk.Reg)
+func HousekeepUploads() time.Duration {
+	var (
+		expired []*MptPart
+		now     = time.Now()
+	)
+	ups.Lock()
+	for id, upload := range ups.m {
+		if now.Sub(upload.mtime) > mptUploadTTL {
+			expired = append(expired, upload.parts...)
+			delete(ups.m, id)
+			glog.Infof("multipart upload %q (%s) expired", id, upload.uname)
+		}
+	}
+	ups.Unlock()
+	for _, part := range expired {
+		if err := cos.RemoveFile(part.FQN); err != nil {
+			glog.Errorf("failed to remove expired part %q: %v", part.FQN, err)
+		}
+	}
+	return MptHKInterval
+}
+
+// S3 ETags are quoted
+func QuoteETag(etag string) string { return "\"" + etag + "\"" }
+
+func ParsePartNum(s string) (int64, error) {
+	num, err := strconv.ParseInt(s, 10, 16)
+	if err != nil || num < 1 || num > maxPartNum {
+		return 0, fmt.Errorf("invalid part number %q (must be in [1, %d] range)", s, maxPartNum)
+	}
+	return num, nil
+}
+
+// S3 multipart ETag: MD5 of the concatenated binary MD5s of the parts, followed
+// by the number of parts
+func MptETag(parts []*MptPart) (string, error) {
+	h := cos.NewCksumHash(cos.ChecksumMD5)
+	for _, part := range parts {
+		b, err := hex.DecodeString(part.MD5)
+		if err != nil {
+			return "", err
+		}
+		h.H.Write(b)
+	}
+	h.Finalize()
+	return h.Value() + "-" + strconv.Itoa(len(parts)), nil
+}
+
+func NewInitiateMptUploadResult(bucket, key, id string) *InitiateMptUploadResult {
+	return &InitiateMptUploadResult{Ns: s3Namespace, Bucket: bucket, Key: key, UploadID: id}
+}
+
+func NewCompleteMptUploadResult(bucket, key, etag string) *CompleteMptUploadResult {
+	return &CompleteMptUploadResult{Ns: s3Namespace, Bucket: bucket, Key: key, ETag: etag}
+}
+
+func NewListPartsResult(bucket, key, id string, parts []*PartInfo) *ListPartsResult {
+	return &ListPartsResult{Ns: s3Namespace, Bucket: bucket, Key: key, UploadID: id, Parts: parts}
+}
+
+func (r *InitiateMptUploadResult) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	cos.AssertNoErr(err)
+}
+
+func (r *CompleteMptUploadResult) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	cos.AssertNoErr(err)
+}
+
+func (r *ListPartsResult) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	cos.AssertNoErr(err)
+}
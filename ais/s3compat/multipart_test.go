@@ -0,0 +1,52 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestHousekeepUploads(t *testing.T) {
+	const uname = "bck/obj"
+	cos.InitShortID(0)
+	var (
+		dir  = t.TempDir()
+		ids  = []string{NewUploadID(), NewUploadID()}
+		fqns = make([]string, 0, len(ids))
+	)
+	for i, id := range ids {
+		InitUpload(id, uname, nil)
+		fqn := filepath.Join(dir, id)
+		tassert.CheckFatal(t, os.WriteFile(fqn, []byte("part"), cos.PermRWR))
+		_, err := AddPart(id, uname, &MptPart{MD5: "md5", FQN: fqn, Size: 4, Num: int64(i + 1)})
+		tassert.CheckFatal(t, err)
+		fqns = append(fqns, fqn)
+	}
+	ups.Lock()
+	ups.m[ids[0]].mtime = time.Now().Add(-mptUploadTTL - time.Minute)
+	ups.Unlock()
+
+	HousekeepUploads()
+
+	_, err := ListParts(ids[0], uname)
+	tassert.Errorf(t, err != nil, "expected upload %q to expire", ids[0])
+	_, err = os.Stat(fqns[0])
+	tassert.Errorf(t, os.IsNotExist(err), "expected expired part to be removed")
+
+	parts, err := ListParts(ids[1], uname)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(parts) == 1 && parts[0].ETag == `"md5"`, "unexpected parts %+v", parts)
+	_, err = os.Stat(fqns[1])
+	tassert.Errorf(t, err == nil, "expected active part to remain")
+	mparts, err := FinishUpload(ids[1], uname)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(mparts) == 1, "expected 1 part, got %d", len(mparts))
+}
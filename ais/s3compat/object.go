@@ -21,23 +21,38 @@ import (
 const defaultLastModified = 0 // When an object was not accessed yet
 
 type (
-	// List objects response
+	// List objects response (both ListObjects and ListObjectsV2)
 	ListObjectResult struct {
-		Ns                    string     `xml:"xmlns,attr"`
-		Prefix                string     `xml:"Prefix"`
-		KeyCount              int        `xml:"KeyCount"` // number of objects in the response
-		MaxKeys               int        `xml:"MaxKeys"`
-		IsTruncated           bool       `xml:"IsTruncated"`           // true if there are more pages to read
-		ContinuationToken     string     `xml:"ContinuationToken"`     // original ContinuationToken
-		NextContinuationToken string     `xml:"NextContinuationToken"` // NextContinuationToken to read the next page
-		Contents              []*ObjInfo `xml:"Contents"`              // list of objects
+		Ns                    string          `xml:"xmlns,attr"`
+		Prefix                string          `xml:"Prefix"`
+		Delimiter             string          `xml:"Delimiter,omitempty"`
+		KeyCount              int             `xml:"KeyCount"` // number of objects and common prefixes in the response
+		MaxKeys               int             `xml:"MaxKeys"`
+		IsTruncated           bool            `xml:"IsTruncated"`              // true if there are more pages to read
+		StartAfter            string          `xml:"StartAfter,omitempty"`     // V2 only
+		ContinuationToken     string          `xml:"ContinuationToken"`        // original ContinuationToken
+		NextContinuationToken string          `xml:"NextContinuationToken"`    // NextContinuationToken to read the next page
+		Marker                string          `xml:"Marker,omitempty"`         // V1 only
+		NextMarker            string          `xml:"NextMarker,omitempty"`     // V1 only
+		Contents              []*ObjInfo      `xml:"Contents"`                 // list of objects
+		CommonPrefixes        []*CommonPrefix `xml:"CommonPrefixes,omitempty"` // "directories" when delimiter is given
+		fetchOwner            bool
+		v2                    bool
+	}
+	CommonPrefix struct {
+		Prefix string `xml:"Prefix"`
+	}
+	ObjOwner struct {
+		ID          string `xml:"ID"`
+		DisplayName string `xml:"DisplayName"`
 	}
 	ObjInfo struct {
-		Key          string `xml:"Key"`
-		LastModified string `xml:"LastModified"`
-		ETag         string `xml:"ETag"`
-		Size         int64  `xml:"Size"`
-		Class        string `xml:"StorageClass"`
+		Key          string    `xml:"Key"`
+		LastModified string    `xml:"LastModified"`
+		ETag         string    `xml:"ETag"`
+		Size         int64     `xml:"Size"`
+		Class        string    `xml:"StorageClass"`
+		Owner        *ObjOwner `xml:"Owner,omitempty"`
 	}
 
 	// Response for object copy request
@@ -47,31 +62,53 @@ type (
 	}
 )
 
+// Map S3 ListObjects (V1) and ListObjectsV2 query onto `cmn.SelectMsg`
 func FillMsgFromS3Query(query url.Values, msg *cmn.SelectMsg) {
-	mxStr := query.Get("max-keys")
+	mxStr := query.Get(URLParamMaxKeys)
 	if pageSize, err := strconv.Atoi(mxStr); err == nil && pageSize > 0 {
 		msg.PageSize = uint(pageSize)
 	}
-	if prefix := query.Get("prefix"); prefix != "" {
+	if prefix := query.Get(URLParamPrefix); prefix != "" {
 		msg.Prefix = prefix
 	}
+	if query.Get(URLParamListType) != "2" {
+		// V1: AIS continuation token is the name of the last listed object,
+		// so the marker works as one
+		msg.ContinuationToken = query.Get(URLParamMarker)
+		return
+	}
 	var token string
-	if token = query.Get("continuation-token"); token != "" {
+	if token = query.Get(URLParamContinuationToken); token != "" {
 		msg.ContinuationToken = token
 	}
 	// start-after makes sense only on first call. For the next call,
 	// when continuation-token is set, start-after is ignored
-	if after := query.Get("start-after"); after != "" && token == "" {
+	if after := query.Get(URLParamStartAfter); after != "" && token == "" {
 		msg.StartAfter = after
 	}
 }
 
-func NewListObjectResult() *ListObjectResult {
-	return &ListObjectResult{
-		Ns:       s3Namespace,
-		MaxKeys:  1000,
-		Contents: make([]*ObjInfo, 0),
+func NewListObjectResult(query url.Values) *ListObjectResult {
+	r := &ListObjectResult{
+		Ns:         s3Namespace,
+		MaxKeys:    1000,
+		Contents:   make([]*ObjInfo, 0),
+		Prefix:     query.Get(URLParamPrefix),
+		Delimiter:  query.Get(URLParamDelimiter),
+		v2:         query.Get(URLParamListType) == "2",
+		fetchOwner: cos.IsParseBool(query.Get(URLParamFetchOwner)),
 	}
+	if mxKeys, err := strconv.Atoi(query.Get(URLParamMaxKeys)); err == nil && mxKeys > 0 {
+		r.MaxKeys = mxKeys
+	}
+	if r.v2 {
+		r.ContinuationToken = query.Get(URLParamContinuationToken)
+		r.StartAfter = query.Get(URLParamStartAfter)
+	} else {
+		r.Marker = query.Get(URLParamMarker)
+		r.fetchOwner = true // V1 always returns the owner
+	}
+	return r
 }
 
 func (r *ListObjectResult) MustMarshal(sgl *memsys.SGL) {
@@ -81,7 +118,33 @@ func (r *ListObjectResult) MustMarshal(sgl *memsys.SGL) {
 }
 
 func (r *ListObjectResult) Add(entry *cmn.BucketEntry, smsg *cmn.SelectMsg) {
-	r.Contents = append(r.Contents, entryToS3(entry, smsg))
+	objInfo := entryToS3(entry, smsg)
+	if r.fetchOwner {
+		objInfo.Owner = &ObjOwner{ID: AISSever, DisplayName: AISSever}
+	}
+	r.Contents = append(r.Contents, objInfo)
+}
+
+// Returns the common prefix ("directory") the entry rolls up into, if any.
+// Entries under the prefix that was already returned by the previous page
+// (the one that ends with `marker`) are skipped.
+func (r *ListObjectResult) commonPrefix(name, marker string) (cp string, skip bool) {
+	if r.Delimiter == "" {
+		return
+	}
+	rest := strings.TrimPrefix(name, r.Prefix)
+	i := strings.Index(rest, r.Delimiter)
+	if i < 0 {
+		return
+	}
+	cp = name[:len(name)-len(rest)+i+len(r.Delimiter)]
+	if marker != "" && strings.HasPrefix(marker, cp) {
+		skip = true
+	}
+	if l := len(r.CommonPrefixes); l > 0 && r.CommonPrefixes[l-1].Prefix == cp {
+		skip = true
+	}
+	return
 }
 
 func entryToS3(entry *cmn.BucketEntry, smsg *cmn.SelectMsg) *ObjInfo {
@@ -100,12 +163,29 @@ func entryToS3(entry *cmn.BucketEntry, smsg *cmn.SelectMsg) *ObjInfo {
 }
 
 func (r *ListObjectResult) FillFromAisBckList(bckList *cmn.BucketList, smsg *cmn.SelectMsg) {
-	r.KeyCount = len(bckList.Entries)
-	r.IsTruncated = bckList.ContinuationToken != ""
-	r.ContinuationToken = bckList.ContinuationToken
+	marker := smsg.ContinuationToken
+	if marker == "" {
+		marker = smsg.StartAfter
+	}
 	for _, e := range bckList.Entries {
+		if cp, skip := r.commonPrefix(e.Name, marker); cp != "" {
+			if !skip {
+				r.CommonPrefixes = append(r.CommonPrefixes, &CommonPrefix{Prefix: cp})
+			}
+			continue
+		}
 		r.Add(e, smsg)
 	}
+	r.KeyCount = len(r.Contents) + len(r.CommonPrefixes)
+	r.IsTruncated = bckList.ContinuationToken != ""
+	if !r.IsTruncated {
+		return
+	}
+	if r.v2 {
+		r.NextContinuationToken = bckList.ContinuationToken
+	} else {
+		r.NextMarker = bckList.ContinuationToken
+	}
 }
 
 func FormatTime(t time.Time) string {
@@ -127,7 +207,7 @@ func lomMD5(lom *cluster.LOM) string {
 
 func SetETag(header http.Header, lom *cluster.LOM) {
 	if md5val := lomMD5(lom); md5val != "" {
-		header.Set(headerETag, md5val)
+		header.Set(HeaderETag, md5val)
 	}
 }
 
@@ -0,0 +1,368 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// AWS Signature Version 4 (SigV4) request verification, both "Authorization"
+// header and presigned URL styles. See:
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
+//
+// The payload is verified against the (signed) x-amz-content-sha256 while being
+// read - see VerifyPayload. Streaming (chunked) signatures are not supported.
+
+const (
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	sigV4Terminator = "aws4_request"
+	sigV4TimeFormat = "20060102T150405Z"
+	sigV4MaxSkew    = 15 * time.Minute
+	sigV4MaxExpires = 7 * 24 * time.Hour
+
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	emptySHA256     = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	headerAmzDate          = "X-Amz-Date"
+	headerAmzContentSHA256 = "X-Amz-Content-Sha256"
+
+	// presigned URL query
+	URLParamAmzAlgorithm     = "X-Amz-Algorithm"
+	URLParamAmzCredential    = "X-Amz-Credential"
+	URLParamAmzDate          = "X-Amz-Date"
+	URLParamAmzExpires       = "X-Amz-Expires"
+	URLParamAmzSignedHeaders = "X-Amz-SignedHeaders"
+	URLParamAmzSignature     = "X-Amz-Signature"
+)
+
+type (
+	// returns the secret key for a given access key
+	SecretKeyFunc func(accessKey string) (secretKey string, err error)
+
+	sigV4 struct {
+		accessKey     string
+		date          string // yyyymmdd
+		region        string
+		service       string
+		signedHeaders []string
+		signature     string
+		amzDate       string
+		payloadHash   string
+		presigned     bool
+	}
+
+	// verifies SHA256 of the payload upon reading it in its entirety
+	payloadReader struct {
+		r        io.ReadCloser
+		h        hash.Hash
+		expected string
+	}
+)
+
+var (
+	ErrSigV4Missing   = errors.New("missing AWS Signature V4 credentials")
+	ErrPayloadHash    = fmt.Errorf("SigV4: payload does not match %s", headerAmzContentSHA256)
+	errSigV4Signature = errors.New("SigV4: signature does not match")
+)
+
+// Verifies SigV4 signature of the request and returns the access key.
+// Upon success, the request body (if any) is wrapped to be verified against
+// the signed payload hash (see VerifyPayload).
+func VerifySigV4(r *http.Request, secretFn SecretKeyFunc) (string, error) {
+	return verifySigV4(r, secretFn, time.Now())
+}
+
+func verifySigV4(r *http.Request, secretFn SecretKeyFunc, now time.Time) (string, error) {
+	sig, err := parseSigV4(r)
+	if err != nil {
+		return "", err
+	}
+	if err := sig.validateTime(r, now); err != nil {
+		return "", err
+	}
+	secretKey, err := secretFn(sig.accessKey)
+	if err != nil {
+		return "", err
+	}
+	expected := sig.sign(r, secretKey)
+	if !hmac.Equal([]byte(expected), []byte(sig.signature)) {
+		return "", errSigV4Signature
+	}
+	if !sig.presigned {
+		verifyPayload(r, sig.payloadHash)
+	}
+	return sig.accessKey, nil
+}
+
+// VerifyPayload wraps the request body (if any) so that reading it fails with
+// ErrPayloadHash unless its SHA256 matches x-amz-content-sha256. Used by targets
+// that receive the payload of a (redirected) request signed by the client.
+func VerifyPayload(r *http.Request) error {
+	payloadHash := r.Header.Get(headerAmzContentSHA256)
+	if payloadHash == "" {
+		return nil
+	}
+	if err := validatePayloadHash(payloadHash); err != nil {
+		return err
+	}
+	verifyPayload(r, payloadHash)
+	return nil
+}
+
+func verifyPayload(r *http.Request, payloadHash string) {
+	if payloadHash == unsignedPayload || r.Body == nil || r.Body == http.NoBody {
+		return
+	}
+	r.Body = &payloadReader{r: r.Body, h: sha256.New(), expected: payloadHash}
+}
+
+func validatePayloadHash(payloadHash string) error {
+	if payloadHash == unsignedPayload {
+		return nil
+	}
+	if b, err := hex.DecodeString(payloadHash); err != nil || len(b) != sha256.Size {
+		return fmt.Errorf("SigV4: unsupported %s %q", headerAmzContentSHA256, payloadHash)
+	}
+	return nil
+}
+
+func parseSigV4(r *http.Request) (*sigV4, error) {
+	if auth := r.Header.Get(cmn.HdrAuthorization); auth != "" {
+		return parseSigV4Header(r, auth)
+	}
+	if r.URL.Query().Get(URLParamAmzSignature) != "" {
+		return parseSigV4Query(r)
+	}
+	return nil, ErrSigV4Missing
+}
+
+// Authorization: AWS4-HMAC-SHA256 Credential=AKID/20130524/us-east-1/s3/aws4_request,
+//                SignedHeaders=host;range;x-amz-date, Signature=fe5f80f77d5fa3beca038a248ff027
+func parseSigV4Header(r *http.Request, auth string) (*sigV4, error) {
+	if !strings.HasPrefix(auth, sigV4Algorithm+" ") {
+		return nil, fmt.Errorf("SigV4: unsupported authorization type %q", strings.SplitN(auth, " ", 2)[0])
+	}
+	var (
+		sig  = &sigV4{}
+		cred string
+	)
+	for _, kv := range strings.Split(auth[len(sigV4Algorithm)+1:], ",") {
+		kv = strings.TrimSpace(kv)
+		i := strings.IndexByte(kv, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("SigV4: invalid authorization header %q", kv)
+		}
+		switch kv[:i] {
+		case "Credential":
+			cred = kv[i+1:]
+		case "SignedHeaders":
+			sig.signedHeaders = strings.Split(kv[i+1:], ";")
+		case "Signature":
+			sig.signature = kv[i+1:]
+		}
+	}
+	if err := sig.parseCredential(cred); err != nil {
+		return nil, err
+	}
+	if sig.signature == "" || len(sig.signedHeaders) == 0 {
+		return nil, errors.New("SigV4: missing signature or signed headers")
+	}
+	sig.amzDate = r.Header.Get(headerAmzDate)
+	if sig.amzDate == "" {
+		return nil, fmt.Errorf("SigV4: missing %s header", headerAmzDate)
+	}
+	sig.payloadHash = r.Header.Get(headerAmzContentSHA256)
+	if sig.payloadHash == "" {
+		if r.ContentLength != 0 {
+			return nil, fmt.Errorf("SigV4: missing %s header", headerAmzContentSHA256)
+		}
+		sig.payloadHash = emptySHA256
+	}
+	if err := validatePayloadHash(sig.payloadHash); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+func parseSigV4Query(r *http.Request) (*sigV4, error) {
+	var (
+		query = r.URL.Query()
+		sig   = &sigV4{presigned: true, payloadHash: unsignedPayload}
+	)
+	if algo := query.Get(URLParamAmzAlgorithm); algo != sigV4Algorithm {
+		return nil, fmt.Errorf("SigV4: unsupported algorithm %q", algo)
+	}
+	if err := sig.parseCredential(query.Get(URLParamAmzCredential)); err != nil {
+		return nil, err
+	}
+	sig.signature = query.Get(URLParamAmzSignature)
+	sig.signedHeaders = strings.Split(query.Get(URLParamAmzSignedHeaders), ";")
+	sig.amzDate = query.Get(URLParamAmzDate)
+	return sig, nil
+}
+
+// request time must be within the allowed skew or else, in case of presigned URL,
+// the URL must not be expired
+func (sig *sigV4) validateTime(r *http.Request, now time.Time) error {
+	signedAt, err := time.Parse(sigV4TimeFormat, sig.amzDate)
+	if err != nil {
+		return fmt.Errorf("SigV4: invalid date %q", sig.amzDate)
+	}
+	if !sig.presigned {
+		if skew := now.Sub(signedAt); skew > sigV4MaxSkew || skew < -sigV4MaxSkew {
+			return fmt.Errorf("SigV4: request time %s is too skewed", sig.amzDate)
+		}
+		return nil
+	}
+	v := r.URL.Query().Get(URLParamAmzExpires)
+	expires, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || expires < 0 || time.Duration(expires)*time.Second > sigV4MaxExpires {
+		return fmt.Errorf("SigV4: invalid %s %q", URLParamAmzExpires, v)
+	}
+	if now.After(signedAt.Add(time.Duration(expires) * time.Second)) {
+		return errors.New("SigV4: presigned request has expired")
+	}
+	return nil
+}
+
+// AKID/20130524/us-east-1/s3/aws4_request
+func (sig *sigV4) parseCredential(cred string) error {
+	parts := strings.Split(cred, "/")
+	if len(parts) != 5 || parts[0] == "" || parts[4] != sigV4Terminator {
+		return fmt.Errorf("SigV4: invalid credential %q", cred)
+	}
+	sig.accessKey, sig.date, sig.region, sig.service = parts[0], parts[1], parts[2], parts[3]
+	return nil
+}
+
+func (sig *sigV4) scope() string {
+	return strings.Join([]string{sig.date, sig.region, sig.service, sigV4Terminator}, "/")
+}
+
+func (sig *sigV4) sign(r *http.Request, secretKey string) string {
+	var (
+		creq = sig.canonicalRequest(r)
+		hash = sha256.Sum256([]byte(creq))
+		sts  = strings.Join([]string{sigV4Algorithm, sig.amzDate, sig.scope(), hex.EncodeToString(hash[:])}, "\n")
+	)
+	key := hmacSHA256([]byte("AWS4"+secretKey), sig.date)
+	key = hmacSHA256(key, sig.region)
+	key = hmacSHA256(key, sig.service)
+	key = hmacSHA256(key, sigV4Terminator)
+	return hex.EncodeToString(hmacSHA256(key, sts))
+}
+
+func (sig *sigV4) canonicalRequest(r *http.Request) string {
+	var sb strings.Builder
+	sb.WriteString(r.Method)
+	sb.WriteByte('\n')
+	sb.WriteString(uriEncode(r.URL.Path, false /*encode slash*/))
+	sb.WriteByte('\n')
+	sb.WriteString(sig.canonicalQuery(r.URL.Query()))
+	sb.WriteByte('\n')
+	for _, name := range sig.signedHeaders {
+		sb.WriteString(name)
+		sb.WriteByte(':')
+		sb.WriteString(headerValue(r, name))
+		sb.WriteByte('\n')
+	}
+	sb.WriteByte('\n')
+	sb.WriteString(strings.Join(sig.signedHeaders, ";"))
+	sb.WriteByte('\n')
+	sb.WriteString(sig.payloadHash)
+	return sb.String()
+}
+
+func (sig *sigV4) canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		if sig.presigned && k == URLParamAmzSignature {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			pairs = append(pairs, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+func headerValue(r *http.Request, name string) string {
+	switch name {
+	case "host":
+		return r.Host
+	case "content-length":
+		if v := r.Header.Get(name); v != "" {
+			return v
+		}
+		return strconv.FormatInt(r.ContentLength, 10)
+	}
+	values := r.Header.Values(name)
+	trimmed := make([]string, 0, len(values))
+	for _, v := range values {
+		trimmed = append(trimmed, strings.Join(strings.Fields(v), " "))
+	}
+	return strings.Join(trimmed, ",")
+}
+
+// URI-encode as per AWS spec: everything except unreserved characters
+// (and, optionally, the forward slash)
+func uriEncode(s string, encodeSlash bool) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			sb.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			sb.WriteByte(c)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
+
+///////////////////
+// payloadReader //
+///////////////////
+
+func (pr *payloadReader) Read(b []byte) (n int, err error) {
+	n, err = pr.r.Read(b)
+	pr.h.Write(b[:n])
+	if err == io.EOF && !strings.EqualFold(hex.EncodeToString(pr.h.Sum(nil)), pr.expected) {
+		err = ErrPayloadHash
+	}
+	return
+}
+
+func (pr *payloadReader) Close() error { return pr.r.Close() }
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/ais/backend"
+	"github.com/NVIDIA/aistore/ais/s3compat"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -31,6 +32,7 @@ import (
 	"github.com/NVIDIA/aistore/etl"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/health"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/reb"
@@ -272,6 +274,9 @@ func (t *targetrunner) Run() error {
 	if err := fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{}); err != nil {
 		cos.ExitLogf("%v", err)
 	}
+	if err := fs.CSM.RegisterContentType(fs.ObjVersionType, &fs.ObjVersionContentResolver{}); err != nil {
+		cos.ExitLogf("%v", err)
+	}
 
 	// Init meta-owners and load local instances
 	t.owner.bmd.init()
@@ -330,6 +335,9 @@ func (t *targetrunner) Run() error {
 
 	ec.Init(t)
 
+	hk.Reg(lifecycleHKName, t.lifecycleHK, lifecycleInterval)
+	hk.Reg(s3compat.MptHKName, s3compat.HousekeepUploads, s3compat.MptHKInterval)
+
 	marked := xreg.GetResilverMarked()
 	if marked.Interrupted || daemon.resilver.required {
 		go func() {
@@ -345,6 +353,8 @@ func (t *targetrunner) Run() error {
 	dsort.InitManagers(driver)
 	dsort.RegisterNode(t.owner.smap, t.owner.bmd, t.si, t, t.statsT)
 
+	go t.resumeMirrorJobs()
+
 	defer etl.StopAll(t) // Always try to stop running ETLs.
 
 	err = t.httprunner.run()
@@ -484,6 +494,10 @@ func (t *targetrunner) httpbckget(w http.ResponseWriter, r *http.Request) {
 		t.handleList(w, r, queryBcks, msg)
 	case cmn.ActSummary:
 		t.handleSummary(w, r, queryBcks, msg)
+	case cmn.ActListTrash:
+		t.listTrash(w, r, queryBcks)
+	case cmn.ActQuotaUsage:
+		t.quotaUsage(w, r, queryBcks)
 	default:
 		t.writeErrAct(w, r, msg.Action)
 	}
@@ -794,6 +808,7 @@ func (t *targetrunner) getObject(w http.ResponseWriter, r *http.Request, query u
 			filename: query.Get(cmn.URLParamArchpath),
 			mime:     query.Get(cmn.URLParamArchmime),
 		}
+		goi.version = query.Get(cmn.URLParamObjVersion)
 		goi.isGFN = cos.IsParseBool(query.Get(cmn.URLParamIsGFNRequest))
 		goi.chunked = config.Net.HTTP.Chunked
 	}
@@ -868,13 +883,17 @@ func (t *targetrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	lom.SetAtimeUnix(started.UnixNano())
-	appendTy := query.Get(cmn.URLParamAppendType)
-	if appendTy == "" {
+	switch appendTy := query.Get(cmn.URLParamAppendType); appendTy {
+	case "":
 		if errCode, err := t.doPut(r, lom, started); err != nil {
 			t.fsErr(err, lom.FQN)
 			t.writeErr(w, r, err, errCode)
 		}
-	} else {
+	case cmn.AppendArchOp:
+		if errCode, err := t.doAppendArch(r, lom, started); err != nil {
+			t.writeErr(w, r, err, errCode)
+		}
+	default:
 		if handle, errCode, err := t.doAppend(r, lom, started); err != nil {
 			t.writeErr(w, r, err, errCode)
 		} else {
@@ -909,6 +928,20 @@ func (t *targetrunner) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		t.writeErr(w, r, err)
 		return
 	}
+	if filename := query.Get(cmn.URLParamArchpath); filename != "" {
+		aoi := &archObjInfo{
+			started:  time.Now(),
+			t:        t,
+			lom:      lom,
+			filename: filename,
+			mime:     query.Get(cmn.URLParamArchmime),
+			workFQN:  fs.CSM.GenContentFQN(lom, fs.WorkfileType, fs.WorkfileArchMod),
+		}
+		if errCode, err := aoi.modify(); err != nil {
+			t.writeErr(w, r, err, errCode)
+		}
+		return
+	}
 
 	errCode, err := t.DeleteObject(lom, evict)
 	if err != nil {
@@ -918,10 +951,7 @@ func (t *targetrunner) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		} else {
 			t.writeErr(w, r, err, errCode)
 		}
-		return
 	}
-	// EC cleanup if EC is enabled
-	ec.ECM.CleanupObject(lom)
 }
 
 // POST /v1/objects/bucket-name/object-name
@@ -940,6 +970,12 @@ func (t *targetrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		t.objMv(w, r, &msg)
+	case cmn.ActRestoreObject:
+		if isRedirect(query) == "" {
+			t.writeErrf(w, r, "%s: %s-%s(obj) is expected to be redirected", t.si, r.Method, msg.Action)
+			return
+		}
+		t.restoreObj(w, r)
 	case cmn.ActPromote:
 		if isRedirect(query) == "" && !t.isIntraCall(r.Header) {
 			t.writeErrf(w, r, "%s: %s-%s(obj) is expected to be redirected or intra-called",
@@ -985,7 +1021,7 @@ func (t *targetrunner) headObject(w http.ResponseWriter, r *http.Request, query
 		checkExists    = cos.IsParseBool(query.Get(cmn.URLParamCheckExists))
 		checkExistsAny = cos.IsParseBool(query.Get(cmn.URLParamCheckExistsAny))
 		silent         = cos.IsParseBool(query.Get(cmn.URLParamSilent))
-		addedEC        bool
+		addedEC, isVer bool
 	)
 	if silent {
 		invalidHandler = t.writeErrSilent
@@ -1022,6 +1058,27 @@ func (t *targetrunner) headObject(w http.ResponseWriter, r *http.Request, query
 		return
 	}
 
+	// previous version of the object
+	if version := query.Get(cmn.URLParamObjVersion); version != "" && (!exists || version != lom.Version()) {
+		if !lom.Bck().IsAIS() {
+			invalidHandler(w, r, fmt.Errorf("%s: versions are retained only in ais buckets", lom))
+			return
+		}
+		lom.Lock(false)
+		vlom, err := lom.LoadVersion(version)
+		lom.Unlock(false)
+		if err != nil {
+			if cmn.IsObjNotExist(err) {
+				invalidHandler(w, r, err, http.StatusNotFound)
+			} else {
+				invalidHandler(w, r, err)
+			}
+			return
+		}
+		defer cluster.FreeLOM(vlom)
+		lom, exists, isVer = vlom, true, true
+	}
+
 	// 1. add cmn.HdrObj* props
 	if lom.Bck().IsAIS() {
 		if !exists {
@@ -1054,7 +1111,7 @@ func (t *targetrunner) headObject(w http.ResponseWriter, r *http.Request, query
 	}
 	if exists {
 		objProps.NumCopies = lom.NumCopies()
-		if lom.Bck().Props.EC.Enabled {
+		if lom.Bck().Props.ECUsed() && !isVer {
 			if md, err := ec.ObjectMetadata(lom.Bck(), lom.ObjName); err == nil {
 				addedEC = true
 				objProps.DataSlices = md.Data
@@ -1311,6 +1368,33 @@ func (t *targetrunner) doAppend(r *http.Request, lom *cluster.LOM, started time.
 	return aoi.appendObject()
 }
 
+// APPEND a file to an existing archive (`lom`)
+func (t *targetrunner) doAppendArch(r *http.Request, lom *cluster.LOM, started time.Time) (errCode int, err error) {
+	var (
+		query    = r.URL.Query()
+		filename = query.Get(cmn.URLParamArchpath)
+	)
+	if filename == "" {
+		return http.StatusBadRequest, fmt.Errorf("%s: missing %q (pathname inside archive %s)",
+			t.si, cmn.URLParamArchpath, lom)
+	}
+	if r.ContentLength < 0 {
+		return http.StatusLengthRequired, fmt.Errorf("%s: append %q to archive %s: unknown size",
+			t.si, filename, lom)
+	}
+	aoi := &archObjInfo{
+		started:  started,
+		t:        t,
+		lom:      lom,
+		r:        r.Body,
+		size:     r.ContentLength,
+		filename: filename,
+		mime:     query.Get(cmn.URLParamArchmime),
+		workFQN:  fs.CSM.GenContentFQN(lom, fs.WorkfileType, fs.WorkfileArchMod),
+	}
+	return aoi.modify()
+}
+
 // PUT new version and update object metadata
 // ais bucket:
 //  - if ais bucket versioning is enabled, the version is auto-incremented
@@ -1404,8 +1488,22 @@ func (t *targetrunner) DeleteObject(lom *cluster.LOM, evict bool) (int, error) {
 		}
 	}
 	if delFromAIS {
-		size := lom.SizeBytes()
-		aisErr = lom.Remove()
+		var (
+			size = lom.SizeBytes()
+			soft = lom.KeepsDeleted() && !evict
+		)
+		// soft delete retains previous versions and EC slices, to be removed when
+		// the deleted object gets purged (see lru)
+		if lom.KeepsHistory() && !soft {
+			if err := lom.DelAllVersions(); err != nil {
+				glog.Errorf("%s: failed to delete previous versions: %v", lom, err)
+			}
+		}
+		if soft {
+			aisErr = lom.MoveToDeleted()
+		} else {
+			aisErr = lom.Remove()
+		}
 		if aisErr != nil {
 			if !os.IsNotExist(aisErr) {
 				if backendErr != nil {
@@ -1413,12 +1511,18 @@ func (t *targetrunner) DeleteObject(lom *cluster.LOM, evict bool) (int, error) {
 				}
 				return 0, aisErr
 			}
-		} else if evict {
-			cos.Assert(lom.Bck().IsRemote())
-			t.statsT.AddMany(
-				stats.NamedVal64{Name: stats.LruEvictCount, Value: 1},
-				stats.NamedVal64{Name: stats.LruEvictSize, Value: size},
-			)
+		} else {
+			// EC cleanup if EC is enabled (all internal callers, including lifecycle and mirror jobs)
+			if !soft {
+				ec.ECM.CleanupObject(lom)
+			}
+			if evict {
+				cos.Assert(lom.Bck().IsRemote())
+				t.statsT.AddMany(
+					stats.NamedVal64{Name: stats.LruEvictCount, Value: 1},
+					stats.NamedVal64{Name: stats.LruEvictSize, Value: size},
+				)
+			}
 		}
 	}
 	if backendErr != nil {
@@ -1448,7 +1552,7 @@ func (t *targetrunner) objMv(w http.ResponseWriter, r *http.Request, msg *cmn.Ac
 		t.writeErrf(w, r, "%s: cannot rename object %s from a remote bucket", t.si, lom)
 		return
 	}
-	if lom.Bck().Props.EC.Enabled {
+	if lom.Bck().Props.ECUsed() {
 		t.writeErrf(w, r, "%s: cannot rename erasure-coded object %s", t.si, lom)
 		return
 	}
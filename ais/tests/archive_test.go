@@ -7,9 +7,11 @@ package integration
 import (
 	"fmt"
 	"math/rand"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/NVIDIA/aistore/api"
@@ -135,7 +137,130 @@ func TestGetFromArchive(t *testing.T) {
 					n, err := api.GetObject(baseParams, m.bck, objname, getOptions)
 					tlog.Logf("%s/%s?%s=%s(%dB)\n", m.bck.Name, objname, cmn.URLParamArchpath, randomName, n)
 					tassert.CheckFatal(t, err)
+
+					// range-read the archived file
+					getOptions.Header = cmn.RangeHdr(0, 1)
+					n, err = api.GetObject(baseParams, m.bck, objname, getOptions)
+					tassert.CheckFatal(t, err)
+					tassert.Errorf(t, n == 1, "range-read %s/%s?%s=%s: expected 1 byte, got %d",
+						m.bck.Name, objname, cmn.URLParamArchpath, randomName, n)
+				}
+			})
+		}
+	})
+}
+
+// APPEND to and DELETE from
+func TestAppendToArch(t *testing.T) {
+	const tmpDir = "/tmp"
+	runProviderTests(t, func(t *testing.T, bck *cluster.Bck) {
+		var (
+			m = ioContext{
+				t:   t,
+				bck: bck.Bck,
+			}
+			baseParams  = tutils.BaseAPIParams(m.proxyURL)
+			errCh       = make(chan error, m.num)
+			numArchived = 10
+			numAppended = 3
+		)
+		for _, ext := range []string{cos.ExtTar, cos.ExtTarTgz, cos.ExtZip} {
+			t.Run(ext, func(t *testing.T) {
+				var (
+					err         error
+					fsize       = rand.Intn(10*cos.KiB) + 1
+					archName    = tmpDir + "/" + cos.GenTie() + ext
+					randomNames = make([]string, numArchived)
+				)
+				for i := 0; i < numArchived; i++ {
+					randomNames[i] = fmt.Sprintf("%d.txt", rand.Int())
+				}
+				if ext == cos.ExtZip {
+					err = archive.CreateZipWithRandomFiles(archName, numArchived, fsize, randomNames)
+				} else {
+					err = archive.CreateTarWithRandomFiles(archName, numArchived, fsize, false, nil, randomNames)
+				}
+				tassert.CheckFatal(t, err)
+				defer os.Remove(archName)
+
+				objName := filepath.Base(archName)
+				reader, err := readers.NewFileReaderFromFile(archName, cos.ChecksumNone)
+				tassert.CheckFatal(t, err)
+				tutils.Put(m.proxyURL, m.bck, objName, reader, errCh)
+				tassert.SelectErr(t, errCh, "put", true)
+				defer tutils.Del(m.proxyURL, m.bck, objName, nil, nil, true)
+
+				for i := 0; i < numAppended; i++ {
+					props, err := api.HeadObject(baseParams, m.bck, objName)
+					tassert.CheckFatal(t, err)
+
+					var (
+						size     = int64(rand.Intn(10*cos.KiB) + 1)
+						archPath = fmt.Sprintf("appended/%d.txt", i)
+					)
+					reader, err := readers.NewRandReader(size, cos.ChecksumNone)
+					tassert.CheckFatal(t, err)
+					args := api.AppendToArchArgs{
+						BaseParams: baseParams,
+						Bck:        m.bck,
+						Object:     objName,
+						ArchPath:   archPath,
+						Reader:     reader,
+						Size:       size,
+					}
+					err = api.AppendToArch(args)
+					tassert.CheckFatal(t, err)
+
+					newProps, err := api.HeadObject(baseParams, m.bck, objName)
+					tassert.CheckFatal(t, err)
+					tassert.Errorf(t, newProps.Size > props.Size, "%s: expected size to grow (%d => %d)",
+						objName, props.Size, newProps.Size)
+					if props.Checksum.Type != cos.ChecksumNone {
+						tassert.Errorf(t, newProps.Checksum.Value != props.Checksum.Value,
+							"%s: expected checksum to change", objName)
+					}
+					if m.bck.IsAIS() && props.Version != "" {
+						tassert.Errorf(t, newProps.Version != props.Version, "%s: expected version to change (%s)",
+							objName, props.Version)
+					}
+
+					getOptions := api.GetObjectInput{
+						Query: url.Values{cmn.URLParamArchpath: []string{archPath}},
+					}
+					n, err := api.GetObject(baseParams, m.bck, objName, getOptions)
+					tassert.CheckFatal(t, err)
+					tassert.Errorf(t, n == size, "%s/%s: expected %dB, got %d", objName, archPath, size, n)
+
+					// appending the same file again must fail
+					reader, err = readers.NewRandReader(size, cos.ChecksumNone)
+					tassert.CheckFatal(t, err)
+					args.Reader = reader
+					err = api.AppendToArch(args)
+					tassert.Errorf(t, err != nil, "%s/%s: expected duplicate append to fail", objName, archPath)
+				}
+
+				// original content is still there
+				for _, randomName := range randomNames {
+					getOptions := api.GetObjectInput{
+						Query: url.Values{cmn.URLParamArchpath: []string{randomName}},
+					}
+					_, err := api.GetObject(baseParams, m.bck, objName, getOptions)
+					tassert.CheckFatal(t, err)
+				}
+
+				// delete
+				err = api.DeleteFromArch(baseParams, m.bck, objName, randomNames[0])
+				tassert.CheckFatal(t, err)
+				getOptions := api.GetObjectInput{
+					Query: url.Values{cmn.URLParamArchpath: []string{randomNames[0]}},
 				}
+				_, err = api.GetObject(baseParams, m.bck, objName, getOptions)
+				httpErr, ok := err.(*cmn.ErrHTTP)
+				tassert.Fatalf(t, ok && httpErr.Status == http.StatusNotFound,
+					"%s/%s: expected 404 after delete, got %v", objName, randomNames[0], err)
+				getOptions.Query.Set(cmn.URLParamArchpath, randomNames[1])
+				_, err = api.GetObject(baseParams, m.bck, objName, getOptions)
+				tassert.CheckFatal(t, err)
 			})
 		}
 	})
@@ -245,6 +370,24 @@ func _testArchiveListRange(t *testing.T, bck *cluster.Bck) {
 			num := len(objList.Entries)
 			tassert.Errorf(t, num == numArchs, "expected %d, have %d", numArchs, num)
 
+			if !test.list {
+				// list archived content (see also cmn.SelectArchDir)
+				msg.SetFlag(cmn.SelectArchDir)
+				objList, err := api.ListObjects(baseParams, toBck, msg, 0)
+				tassert.CheckFatal(t, err)
+				var numInside int
+				for _, entry := range objList.Entries {
+					if entry.IsInsideArch() {
+						numInside++
+						archName := entry.Name[:strings.Index(entry.Name, test.ext)+len(test.ext)]
+						_, ok := extractFiles[archName]
+						tassert.Errorf(t, ok, "unexpected archived file %q", entry.Name)
+					}
+				}
+				tassert.Errorf(t, numInside == numArchs*numInArch, "expected %d archived files, have %d",
+					numArchs*numInArch, numInside)
+			}
+
 			for objName, fileList := range extractFiles {
 				mime := "application/x-" + test.ext[1:]
 				for _, fileName := range fileList {
@@ -912,6 +912,7 @@ func initFS() {
 
 	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
 	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.ObjVersionType, &fs.ObjVersionContentResolver{})
 	_ = fs.CSM.RegisterContentType(fs.ECSliceType, &ec.SliceSpec{})
 	_ = fs.CSM.RegisterContentType(fs.ECMetaType, &ec.MetaSpec{})
 }
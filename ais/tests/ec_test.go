@@ -538,8 +538,8 @@ func damageMetadataCksum(t *testing.T, slicePath string) {
 	tassert.CheckFatal(t, err)
 }
 
-// Short test to make sure that EC options cannot be changed after
-// EC is enabled
+// Short test to make sure that EC options can be changed after EC is enabled
+// (which re-encodes the bucket)
 func TestECChange(t *testing.T) {
 	var (
 		proxyURL = tutils.RandomProxyURL()
@@ -591,11 +591,14 @@ func TestECChange(t *testing.T) {
 	_, err = api.SetBucketProps(baseParams, bck, bucketProps)
 	tassert.Errorf(t, err == nil, "Enabling EC failed: %v", err)
 
-	tlog.Logln("Trying to modify EC options when EC is enabled")
+	tlog.Logln("Modifying EC options when EC is enabled")
 	bucketProps.EC.Enabled = api.Bool(true)
 	bucketProps.EC.ObjSizeLimit = api.Int64(300000)
-	_, err = api.SetBucketProps(baseParams, bck, bucketProps)
-	tassert.Errorf(t, err != nil, "Modifiying EC properties must fail")
+	xactID, err := api.SetBucketProps(baseParams, bck, bucketProps)
+	tassert.CheckFatal(t, err)
+	xactArgs := api.XactReqArgs{ID: xactID, Kind: cmn.ActReencode, Timeout: rebalanceTimeout}
+	_, err = api.WaitForXaction(baseParams, xactArgs)
+	tassert.CheckFatal(t, err)
 
 	tlog.Logln("Resetting bucket properties")
 	_, err = api.ResetBucketProps(baseParams, bck)
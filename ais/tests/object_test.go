@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
@@ -1114,6 +1116,10 @@ func TestRangeRead(t *testing.T) {
 		verifyValidRangesQuery(t, proxyURL, bck.Bck, objName, fmt.Sprintf("bytes=-%d", m.fileSize), int64(m.fileSize))
 		verifyValidRangesQuery(t, proxyURL, bck.Bck, objName, fmt.Sprintf("bytes=-%d", m.fileSize+2), int64(m.fileSize))
 
+		tlog.Logln("Valid multi-range query...")
+		verifyMultiRangesQuery(t, proxyURL, bck.Bck, objName, "bytes=1-2,4-6", []int64{2, 3})
+		verifyMultiRangesQuery(t, proxyURL, bck.Bck, objName, "bytes=0-9,-10,100-", []int64{10, 10, int64(m.fileSize - 100)})
+
 		tlog.Logln("======================================================================")
 		tlog.Logln("Invalid range query:")
 		verifyInvalidRangesQuery(t, proxyURL, bck.Bck, objName, "potatoes=0-1")
@@ -1125,7 +1131,6 @@ func TestRangeRead(t *testing.T) {
 		verifyInvalidRangesQuery(t, proxyURL, bck.Bck, objName, "bytes=-1-0")
 		verifyInvalidRangesQuery(t, proxyURL, bck.Bck, objName, "bytes=-1-2")
 		verifyInvalidRangesQuery(t, proxyURL, bck.Bck, objName, "bytes=10--1")
-		verifyInvalidRangesQuery(t, proxyURL, bck.Bck, objName, "bytes=1-2,4-6")
 		verifyInvalidRangesQuery(t, proxyURL, bck.Bck, objName, "bytes=--1")
 	})
 }
@@ -1234,6 +1239,36 @@ func verifyValidRangesQuery(t *testing.T, proxyURL string, bck cmn.Bck, objName,
 	tassert.Errorf(t, contentRange != "", "%q header should be set", cmn.HdrContentRange)
 }
 
+func verifyMultiRangesQuery(t *testing.T, proxyURL string, bck cmn.Bck, objName, rangeQuery string, lengths []int64) {
+	var (
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		hdr        = http.Header{cmn.HdrRange: {rangeQuery}}
+		w          = bytes.NewBuffer(nil)
+		options    = api.GetObjectInput{Header: hdr, Writer: w}
+	)
+	resp, _, err := api.GetObjectWithResp(baseParams, bck, objName, options) // nolint:bodyclose // it's closed inside
+	tassert.CheckFatal(t, err)
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get(cmn.HdrContentType))
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, mediaType == cmn.ContentMultiRange, "expected %q, got %q", cmn.ContentMultiRange, mediaType)
+
+	mr := multipart.NewReader(w, params["boundary"])
+	for i := 0; ; i++ {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			tassert.Errorf(t, i == len(lengths), "expected %d parts, got %d", len(lengths), i)
+			break
+		}
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, i < len(lengths), "too many parts (%d)", i+1)
+		tassert.Errorf(t, part.Header.Get(cmn.HdrContentRange) != "", "part %d: %q header should be set",
+			i, cmn.HdrContentRange)
+		n, err := io.Copy(io.Discard, part)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, n == lengths[i], "part %d: expected %d bytes, got %d", i, lengths[i], n)
+	}
+}
+
 func verifyInvalidRangesQuery(t *testing.T, proxyURL string, bck cmn.Bck, objName, rangeQuery string) {
 	var (
 		baseParams = tutils.BaseAPIParams(proxyURL)
@@ -299,6 +299,8 @@ func (t *targetrunner) httpdaeget(w http.ResponseWriter, r *http.Request) {
 		tstats := t.statsT.(*stats.Trunner)
 		msg.Capacity = tstats.MPCap
 		t.writeJSON(w, r, msg, httpdaeWhat)
+	case cmn.GetWhatRebPlan:
+		t.rebalancePlan(w, r, httpdaeWhat)
 	case cmn.GetWhatDiskStats:
 		diskStats := make(ios.AllDiskStats)
 		fs.FillDiskStats(diskStats)
@@ -607,7 +609,16 @@ func (t *targetrunner) _applyBMD(newBMD *bucketMD, msg *aisMsg, payload msPayloa
 		if _, present := bmd.Get(bck); present {
 			return false
 		}
-		errs := fs.CreateBucket("recv-bmd-"+msg.Action, bck.Bck, bmd.version() == 0 /*nilbmd*/)
+		var errs []error
+		if msg.Action == cmn.ActRestoreBck {
+			entry := &cmn.TrashEntry{}
+			if err := cos.MorphMarshal(msg.Value, entry); err == nil && entry.Props != nil && entry.Bck.Equal(bck.Bck) {
+				errs = fs.RestoreBucket("recv-bmd-"+msg.Action, bck.Bck, entry.Props.BID)
+				createErrs = append(createErrs, errs...)
+				return false
+			}
+		}
+		errs = fs.CreateBucket("recv-bmd-"+msg.Action, bck.Bck, bmd.version() == 0 /*nilbmd*/)
 		createErrs = append(createErrs, errs...)
 		return false
 	})
@@ -637,11 +648,21 @@ func (t *targetrunner) _applyBMD(newBMD *bucketMD, msg *aisMsg, payload msPayloa
 			if obck.Props.EC.Enabled && !nbck.Props.EC.Enabled {
 				xreg.DoAbort(cmn.ActECEncode, nbck)
 			}
+			if obck.Props.Quota.IsSet() && (!nbck.Props.Quota.IsSet() || obck.Props.BID != nbck.Props.BID) {
+				cluster.ForgetBckUsage(obck.Props.BID)
+			}
 			return true
 		})
 		if !present {
+			var errD error
 			rmbcks = append(rmbcks, obck)
-			if errD := fs.DestroyBucket("recv-bmd-"+msg.Action, obck.Bck, obck.Props.BID); errD != nil {
+			cluster.ForgetBckUsage(obck.Props.BID)
+			if msg.Action == cmn.ActDestroyBck && obck.IsAIS() && obck.Props.Trash.Retention > 0 {
+				errD = fs.TrashBucket("recv-bmd-"+msg.Action, obck.Bck, obck.Props, time.Now().UnixNano())
+			} else {
+				errD = fs.DestroyBucket("recv-bmd-"+msg.Action, obck.Bck, obck.Props.BID)
+			}
+			if errD != nil {
 				destroyErrs = append(destroyErrs, errD)
 			}
 		}
@@ -89,15 +89,7 @@ func (t *targetrunner) downloadHandler(w http.ResponseWriter, r *http.Request) {
 			glog.Infof("Downloading: %s", dlJob.ID())
 		}
 
-		dlJob.AddNotif(&downloader.NotifDownload{
-			NotifBase: nl.NotifBase{
-				When:     cluster.UponProgress,
-				Interval: progressInterval,
-				Dsts:     []string{equalIC},
-				F:        t.callerNotifyFin,
-				P:        t.callerNotifyProgress,
-			},
-		}, dlJob)
+		t.addDlNotif(dlJob, progressInterval)
 		response, statusCode, respErr = downloaderXact.Download(dlJob)
 	case http.MethodGet:
 		if _, err := t.checkRESTItems(w, r, 0, false, cmn.URLPathDownload.L); err != nil {
@@ -169,3 +161,26 @@ func (t *targetrunner) downloadHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 }
+
+func (t *targetrunner) addDlNotif(dlJob downloader.DlJob, progressInterval time.Duration) {
+	dlJob.AddNotif(&downloader.NotifDownload{
+		NotifBase: nl.NotifBase{
+			When:     cluster.UponProgress,
+			Interval: progressInterval,
+			Dsts:     []string{equalIC},
+			F:        t.callerNotifyFin,
+			P:        t.callerNotifyProgress,
+		},
+	}, dlJob)
+}
+
+// resume mirror download jobs interrupted by the target's restart (see downloader/mirror.go)
+func (t *targetrunner) resumeMirrorJobs() {
+	for !t.ClusterStarted() {
+		if daemon.stopping.Load() {
+			return
+		}
+		time.Sleep(time.Second)
+	}
+	downloader.ResumeMirrorJobs(t, t.statsT, t.addDlNotif)
+}
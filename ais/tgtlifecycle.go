@@ -0,0 +1,72 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xreg"
+)
+
+// Lifecycle rules (see cmn.LifecycleRule and xs/lifecycle.go): each target
+// periodically applies the rules to its (local) objects of all the buckets
+// that have any.
+
+const (
+	lifecycleHKName   = "lifecycle"
+	lifecycleInterval = time.Hour
+)
+
+func (t *targetrunner) lifecycleHK() time.Duration {
+	if !t.ClusterStarted() {
+		return lifecycleInterval
+	}
+	bmd := t.owner.bmd.get()
+	bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+		if len(bck.Props.Lifecycle.Rules) == 0 {
+			return false
+		}
+		rns := xreg.RenewLifecycle(t, bck, cos.GenUUID(), false /*dry-run*/)
+		if rns.Err != nil {
+			glog.Errorf("%s: %s %s: %v", t.si, cmn.ActLifecycle, bck, rns.Err)
+		} else if rns.UUID == "" {
+			go rns.Entry.Get().Run()
+		}
+		return false
+	})
+	return lifecycleInterval
+}
+
+// on demand (via api.StartXaction)
+func (t *targetrunner) runLifecycle(bck *cluster.Bck, uuid string, dryRun bool) error {
+	if len(bck.Props.Lifecycle.Rules) == 0 {
+		return fmt.Errorf("%s: bucket %s has no lifecycle rules", t.si, bck)
+	}
+	rns := xreg.RenewLifecycle(t, bck, uuid, dryRun)
+	if rns.Err != nil {
+		return rns.Err
+	}
+	if rns.UUID != "" {
+		return fmt.Errorf("%s: %s %s is already running", t.si, cmn.ActLifecycle, bck)
+	}
+	xact := rns.Entry.Get()
+	xact.AddNotif(&xaction.NotifXact{
+		NotifBase: nl.NotifBase{
+			When: cluster.UponTerm,
+			Dsts: []string{equalIC},
+			F:    t.callerNotifyFin,
+		},
+		Xact: xact,
+	})
+	go xact.Run()
+	return nil
+}
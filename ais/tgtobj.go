@@ -11,7 +11,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
 	"os"
 	"strconv"
@@ -59,6 +61,7 @@ type (
 		ctx     context.Context // context used when getting object from remote backend (access creds)
 		ranges  rangesQuery     // range read query
 		archive archiveQuery    // archive query
+		version string          // previous version of the object, if requested
 		isGFN   bool            // is GFN request
 		chunked bool            // chunked transfer (en)coding: https://tools.ietf.org/html/rfc7230#page-36
 	}
@@ -185,10 +188,20 @@ func (poi *putObjInfo) tryFinalize() (errCode int, err error) {
 		lom.Lock(true)
 		defer lom.Unlock(true)
 	}
+	var (
+		prevSize = lom.TrackedSize() // (bucket quota)
+		verFQN   string              // (previous version)
+	)
 	// ais versioning
 	if bck.IsAIS() && lom.VersionConf().Enabled {
 		// TODO: copy cloud-bucket => ais-bucket and similar scenarios where IncVersion()
 		//       won't work (disambiguate - store cloud version separately in custom-md)
+		if poi.recvType == cluster.RegularPut && lom.KeepsHistory() {
+			var errV error
+			if verFQN, errV = lom.ArchiveCurrent(); errV != nil {
+				glog.Errorf("PUT %s: failed to retain the previous version: %v", lom, errV)
+			}
+		}
 		if poi.recvType == cluster.RegularPut || lom.Version(true) == "" {
 			if err = lom.IncVersion(); err != nil {
 				glog.Error(err)
@@ -196,9 +209,15 @@ func (poi *putObjInfo) tryFinalize() (errCode int, err error) {
 		}
 	}
 	if err = cos.Rename(poi.workFQN, lom.FQN); err != nil {
+		if verFQN != "" {
+			lom.AbortArchived(verFQN)
+		}
 		err = fmt.Errorf(cmn.FmtErrFailed, poi.t.si, "rename", lom, err)
 		return
 	}
+	if verFQN != "" {
+		lom.CommitArchived(verFQN)
+	}
 	if lom.HasCopies() {
 		// TODO: recover
 		if errdc := lom.DelAllCopies(); errdc != nil {
@@ -208,7 +227,9 @@ func (poi *putObjInfo) tryFinalize() (errCode int, err error) {
 	err = lom.Persist(true)
 	if err != nil {
 		lom.Uncache(true /*delDirty*/)
+		return
 	}
+	lom.TrackStored(prevSize)
 	return
 }
 
@@ -343,6 +364,11 @@ func (goi *getObjInfo) getObject() (errCode int, err error) {
 		cs                                            fs.CapStatus
 		doubleCheck, retry, retried, coldGet, capRead bool
 	)
+	if goi.version != "" {
+		if done, errCode, err := goi.getVersion(); done {
+			return errCode, err
+		}
+	}
 	// under lock: lom init, restore from cluster
 	goi.lom.Lock(false)
 do:
@@ -444,6 +470,58 @@ get:
 	return
 }
 
+// GET a previous (non-current) version of the object; returns done == false
+// if the requested version is, in fact, the current one
+func (goi *getObjInfo) getVersion() (done bool, errCode int, err error) {
+	var (
+		vlom *cluster.LOM
+		lmfh *os.File
+		lom  = goi.lom
+	)
+	if !lom.Bck().IsAIS() {
+		return true, http.StatusBadRequest, fmt.Errorf("%s: versions are retained only in ais buckets", lom)
+	}
+	if goi.ranges.Range != "" || goi.archive.filename != "" {
+		return true, http.StatusBadRequest,
+			fmt.Errorf("%s: range and archive reads of previous versions are not supported", lom)
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err = lom.Load(true /*cache it*/, true /*locked*/); err == nil && lom.Version() == goi.version {
+		return false, 0, nil
+	}
+	if vlom, err = lom.LoadVersion(goi.version); err != nil {
+		if cmn.IsObjNotExist(err) {
+			errCode = http.StatusNotFound
+		}
+		return true, errCode, err
+	}
+	defer cluster.FreeLOM(vlom)
+	if lmfh, err = os.Open(vlom.FQN); err != nil {
+		goi.t.fsErr(err, vlom.FQN)
+		return true, http.StatusInternalServerError, err
+	}
+	defer cos.Close(lmfh)
+	if resp, ok := goi.w.(http.ResponseWriter); ok {
+		hdr := resp.Header()
+		cmn.ToHTTPHdr(vlom, hdr)
+		hdr.Set(cmn.HdrContentLength, strconv.FormatInt(vlom.SizeBytes(), 10))
+	}
+	buf, slab := goi.t.gmm.AllocSize(vlom.SizeBytes())
+	written, err := io.CopyBuffer(cos.WriterOnly{Writer: goi.w}, lmfh, buf)
+	slab.Free(buf)
+	if err != nil {
+		glog.Errorf(cmn.FmtErrFailed, goi.t.si, "GET", vlom.FQN, err)
+		return true, 0, errSendingResp
+	}
+	goi.t.statsT.AddMany(
+		stats.NamedVal64{Name: stats.GetThroughput, Value: written},
+		stats.NamedVal64{Name: stats.GetLatency, Value: mono.SinceNano(goi.nanotim)},
+		stats.NamedVal64{Name: stats.GetCount, Value: 1},
+	)
+	return true, 0, nil
+}
+
 // validate checksum; if corrupted try to recover from other replicas or EC slices
 func (goi *getObjInfo) tryRecoverObject() (coldGet bool, code int, err error) {
 	var (
@@ -468,7 +546,7 @@ retry:
 	}
 
 	glog.Warning(err)
-	redundant := lom.HasCopies() || lom.Bprops().EC.Enabled
+	redundant := lom.HasCopies() || lom.Bprops().ECUsed()
 	//
 	// return err if there's no redundancy OR already recovered once (and failed)
 	//
@@ -498,7 +576,7 @@ retry:
 			goto retry
 		}
 	}
-	if lom.Bprops().EC.Enabled {
+	if lom.Bprops().ECUsed() {
 		retried = true
 		goi.lom.Unlock(false)
 		cos.RemoveFile(lom.FQN)
@@ -531,7 +609,7 @@ func (goi *getObjInfo) tryRestoreObject() (doubleCheck bool, errCode int, err er
 		marked               = xreg.GetResilverMarked()
 		interrupted, running = marked.Interrupted, marked.Xact != nil
 		gfnActive            = goi.t.gfn.local.active()
-		ecEnabled            = goi.lom.Bprops().EC.Enabled
+		ecEnabled            = goi.lom.Bprops().ECUsed()
 	)
 	tsi, err = cluster.HrwTarget(goi.lom.Uname(), &smap.Smap, true /*include maintenance*/)
 	if err != nil {
@@ -551,7 +629,7 @@ func (goi *getObjInfo) tryRestoreObject() (doubleCheck bool, errCode int, err er
 	// we might be able to restore it if it was replicated. In this case even
 	// just one additional target might be sufficient. This won't succeed if
 	// an object was sliced, neither will ecmanager.RestoreObject(lom)
-	enoughECRestoreTargets := goi.lom.Bprops().EC.RequiredRestoreTargets() <= smap.CountActiveTargets()
+	enoughECRestoreTargets := goi.lom.ECConf().RequiredRestoreTargets() <= smap.CountActiveTargets()
 
 	// cluster-wide lookup ("get from neighbor")
 	marked = xreg.GetRebMarked()
@@ -655,7 +733,7 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 		slab    *memsys.Slab
 		buf     []byte
 		reader  io.Reader
-		csl     cos.ReadCloseSizer
+		ar      *archRanger
 		hdr     http.Header // if it is http request we will write also header
 		written int64
 	)
@@ -669,8 +747,8 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 		if sgl != nil {
 			sgl.Free()
 		}
-		if csl != nil {
-			csl.Close()
+		if ar != nil {
+			ar.close()
 		}
 	}()
 	if resp, ok := goi.w.(http.ResponseWriter); ok {
@@ -695,58 +773,64 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 	}
 
 	var (
-		rrange     *cmn.HTTPRange
+		ranges     []cmn.HTTPRange
 		size       = goi.lom.SizeBytes()
 		cksumConf  = goi.lom.CksumConf()
 		cksumRange bool
 	)
+	// set reader
+	reader = lmfh
+	if goi.archive.filename != "" {
+		var csl cos.ReadCloseSizer
+		csl, err = goi.freadArch(lmfh)
+		if err != nil {
+			if _, ok := err.(*cmn.ErrNotFound); ok {
+				errCode = http.StatusNotFound
+			} else {
+				err = fmt.Errorf(cmn.FmtErrFailed, goi.t.si,
+					"extract "+goi.archive.filename+" from", goi.lom, err)
+			}
+			return
+		}
+		ar = &archRanger{goi: goi, fh: lmfh, csl: csl}
+		reader, size = csl, csl.Size() // Content-Length
+		//
+		// TODO: support checksumming extracted files
+		//
+	}
+
 	// parse, validate, set response header
 	if hdr != nil {
-		// read range
+		// read range(s) - of the object or, respectively, the archived file
 		if goi.ranges.Range != "" {
 			rsize := size
-			if goi.ranges.Size > 0 {
+			if goi.ranges.Size > 0 && ar == nil {
 				rsize = goi.ranges.Size
 			}
-			if rrange, errCode, err = goi.parseRange(hdr, rsize); err != nil {
+			if ranges, errCode, err = goi.parseRange(hdr, rsize); err != nil {
 				return
 			}
-			if rrange != nil {
-				cksumRange = cksumConf.Type != cos.ChecksumNone && cksumConf.EnableReadRange
-				size = rrange.Length // Content-Length
-			}
+			cksumRange = len(ranges) > 0 && cksumConf.Type != cos.ChecksumNone && cksumConf.EnableReadRange
 		}
 		cmn.ToHTTPHdr(goi.lom, hdr)
 	}
 
-	// set reader
 	w := goi.w
-	if rrange == nil {
-		reader = lmfh
-		if goi.archive.filename != "" {
-			csl, err = goi.freadArch(lmfh)
-			if err != nil {
-				if _, ok := err.(*cmn.ErrNotFound); ok {
-					errCode = http.StatusNotFound
-				} else {
-					err = fmt.Errorf(cmn.FmtErrFailed, goi.t.si,
-						"extract "+goi.archive.filename+" from", goi.lom, err)
-				}
-				return
-			}
-			reader, size = csl, csl.Size() // Content-Length
-			//
-			// TODO: support checksumming extracted files
-			//
-		}
+	switch len(ranges) {
+	case 0:
 		if goi.chunked {
 			// NOTE: hide `ReadFrom` of the `http.ResponseWriter` (in re: sendfile)
 			w = cos.WriterOnly{Writer: goi.w}
 			buf, slab = goi.t.gmm.AllocSize(size)
 		}
-	} else {
+	case 1:
+		rrange := &ranges[0]
+		size = rrange.Length // Content-Length
 		buf, slab = goi.t.gmm.AllocSize(rrange.Length)
-		reader = io.NewSectionReader(lmfh, rrange.Start, rrange.Length)
+		if reader, err = goi.rangeReader(lmfh, ar, rrange); err != nil {
+			errCode = http.StatusInternalServerError
+			return
+		}
 		if cksumRange {
 			var (
 				cksum *cos.CksumHash
@@ -761,6 +845,12 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 			hdr.Set(cmn.HdrObjCksumType, cksumConf.Type)
 			reader = sgl
 		}
+	default:
+		buf, slab = goi.t.gmm.Alloc()
+		if written, errCode, err = goi.sendMultiRange(w, hdr, lmfh, ar, ranges, size, cksumRange, buf); err != nil {
+			return
+		}
+		goto fin
 	}
 	// set Content-Length
 	if hdr != nil {
@@ -781,10 +871,14 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 		return
 	}
 
+fin:
 	// GFN: atime must be already set
 	if !coldGet && !goi.isGFN {
 		goi.lom.Load(false /*cache it*/, true /*locked*/)
 		goi.lom.SetAtimeUnix(goi.started.UnixNano())
+		if goi.lom.Bprops().LRU.CountsAccess() {
+			goi.lom.IncAccessCnt()
+		}
 		goi.lom.ReCache(true) // GFN and cold GETs already did this
 	}
 
@@ -805,9 +899,7 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 }
 
 // parse, validate, set response header
-func (goi *getObjInfo) parseRange(hdr http.Header, size int64) (rrange *cmn.HTTPRange, errCode int, err error) {
-	var ranges []cmn.HTTPRange
-
+func (goi *getObjInfo) parseRange(hdr http.Header, size int64) (ranges []cmn.HTTPRange, errCode int, err error) {
 	ranges, err = cmn.ParseMultiRange(goi.ranges.Range, size)
 	if err != nil {
 		if _, ok := err.(*cmn.ErrRangeNoOverlap); ok {
@@ -820,22 +912,113 @@ func (goi *getObjInfo) parseRange(hdr http.Header, size int64) (rrange *cmn.HTTP
 	if len(ranges) == 0 {
 		return
 	}
-	if len(ranges) > 1 {
-		err = fmt.Errorf(cmn.FmtErrUnsupported, goi.t.Snode(), "multi-range")
-		errCode = http.StatusRequestedRangeNotSatisfiable
-		return
+	hdr.Set(cmn.HdrAcceptRanges, "bytes")
+	if len(ranges) == 1 {
+		hdr.Set(cmn.HdrContentRange, ranges[0].ContentRange(size))
 	}
-	if goi.archive.filename != "" {
-		err = fmt.Errorf(cmn.FmtErrUnsupported, goi.t.Snode(), "range-reading archived files")
-		errCode = http.StatusRequestedRangeNotSatisfiable
-		return
+	return
+}
+
+func (goi *getObjInfo) rangeReader(lmfh *os.File, ar *archRanger, rrange *cmn.HTTPRange) (io.Reader, error) {
+	if ar != nil {
+		return ar.open(rrange)
+	}
+	return io.NewSectionReader(lmfh, rrange.Start, rrange.Length), nil
+}
+
+// multi-range read: respond with `multipart/byteranges` (RFC 7233, Appendix A)
+// with each part carrying its own Content-Range and, if configured, range checksum
+func (goi *getObjInfo) sendMultiRange(w io.Writer, hdr http.Header, lmfh *os.File, ar *archRanger,
+	ranges []cmn.HTTPRange, size int64, cksumRange bool, buf []byte) (written int64, errCode int, err error) {
+	var (
+		cksums    []*cos.CksumHash
+		cksumConf = goi.lom.CksumConf()
+		mw        = multipart.NewWriter(w)
+		cnt       = &cos.WriterCounter{}
+	)
+	// 1st pass: range checksums (that go into the parts' headers)
+	if cksumRange {
+		cksums = make([]*cos.CksumHash, len(ranges))
+		for i := range ranges {
+			var reader io.Reader
+			if reader, err = goi.rangeReader(lmfh, ar, &ranges[i]); err != nil {
+				errCode = http.StatusInternalServerError
+				return
+			}
+			if _, cksums[i], err = cos.CopyAndChecksum(io.Discard, reader, buf, cksumConf.Type); err != nil {
+				errCode = http.StatusInternalServerError
+				return
+			}
+		}
+	}
+	// compute Content-Length
+	if hdr != nil {
+		var (
+			total int64
+			cmw   = multipart.NewWriter(cnt)
+		)
+		cmw.SetBoundary(mw.Boundary())
+		for i := range ranges {
+			cmw.CreatePart(partHeader(&ranges[i], size, cksums, i, cksumConf.Type))
+			total += ranges[i].Length
+		}
+		cmw.Close()
+		total += cnt.N
+		if cksumRange {
+			hdr.Del(cmn.HdrObjCksumType)
+			hdr.Del(cmn.HdrObjCksumVal)
+		}
+		hdr.Set(cmn.HdrContentType, cmn.ContentMultiRange+"; boundary="+mw.Boundary())
+		hdr.Set(cmn.HdrContentLength, strconv.FormatInt(total, 10))
+		if rw, ok := w.(http.ResponseWriter); ok {
+			rw.WriteHeader(http.StatusPartialContent)
+		}
+	}
+	// 2nd pass: transmit
+	for i := range ranges {
+		var (
+			pw     io.Writer
+			reader io.Reader
+			n      int64
+		)
+		if pw, err = mw.CreatePart(partHeader(&ranges[i], size, cksums, i, cksumConf.Type)); err != nil {
+			break
+		}
+		if reader, err = goi.rangeReader(lmfh, ar, &ranges[i]); err != nil {
+			break
+		}
+		n, err = io.CopyBuffer(pw, reader, buf)
+		written += n
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = mw.Close()
+	}
+	if err != nil {
+		if !cos.IsErrConnectionReset(err) {
+			goi.t.fsErr(err, lmfh.Name())
+			goi.t.statsT.Add(stats.ErrGetCount, 1)
+		}
+		glog.Errorf(cmn.FmtErrFailed, goi.t.si, "multi-range GET", goi.lom, err)
+		err = errSendingResp
 	}
-	rrange = &ranges[0]
-	hdr.Set(cmn.HdrAcceptRanges, "bytes")
-	hdr.Set(cmn.HdrContentRange, rrange.ContentRange(size))
 	return
 }
 
+func partHeader(rrange *cmn.HTTPRange, size int64, cksums []*cos.CksumHash, i int, cksumType string) textproto.MIMEHeader {
+	ph := textproto.MIMEHeader{
+		cmn.HdrContentType:  {cmn.ContentBinary},
+		cmn.HdrContentRange: {rrange.ContentRange(size)},
+	}
+	if cksums != nil {
+		ph.Set(cmn.HdrObjCksumType, cksumType)
+		ph.Set(cmn.HdrObjCksumVal, cksums[i].Value())
+	}
+	return ph
+}
+
 ///////////////////
 // APPEND OBJECT //
 ///////////////////
@@ -17,13 +17,15 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/devtools/readers"
+	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/stats"
 )
 
 const (
-	testMountpath = "/tmp/ais-test-mpath" // mpath is created and deleted during the test
-	testBucket    = "bck"
+	testMountpath   = "/tmp/ais-test-mpath" // mpath is created and deleted during the test
+	testBucket      = "bck"
+	testTrashBucket = "bck-trash" // versioned, erasure-coded, and with soft delete
 )
 
 var (
@@ -59,6 +61,9 @@ func TestMain(m *testing.M) {
 	fs.DisableFsIDCheck()
 	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
 	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.ObjVersionType, &fs.ObjVersionContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.ECSliceType, &ec.SliceSpec{})
+	_ = fs.CSM.RegisterContentType(fs.ECMetaType, &ec.MetaSpec{})
 
 	// target
 	config := cmn.GCO.Get()
@@ -82,8 +87,16 @@ func TestMain(m *testing.M) {
 			Type: cos.ChecksumNone,
 		},
 	})
+	trashBck := cluster.NewBck(testTrashBucket, cmn.ProviderAIS, cmn.NsGlobal)
+	bmd.add(trashBck, &cmn.BucketProps{
+		Cksum:      cmn.CksumConf{Type: cos.ChecksumNone},
+		Versioning: cmn.VersionConf{Enabled: true, KeepVersions: 2},
+		EC:         cmn.ECConf{Enabled: true, DataSlices: 1, ParitySlices: 1},
+		Trash:      cmn.TrashConf{Retention: cos.Duration(time.Hour)},
+	})
 	t.owner.bmd.putPersist(bmd, nil)
 	fs.CreateBucket("test", bck.Bck, false /*nilbmd*/)
+	fs.CreateBucket("test", trashBck.Bck, false /*nilbmd*/)
 
 	m.Run()
 }
@@ -0,0 +1,32 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// GET /v1/buckets/bucket-name { "action": "quotausage" }
+// (see cluster.BckUsage and ais/prxquota.go)
+func (t *targetrunner) quotaUsage(w http.ResponseWriter, r *http.Request, queryBcks cmn.QueryBcks) {
+	bck := cluster.NewBckEmbed(cmn.Bck(queryBcks))
+	if err := bck.Init(t.owner.bmd); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	if !bck.Props.Quota.IsSet() {
+		t.writeErrf(w, r, "%s: bucket %s has no quota", t.si, bck)
+		return
+	}
+	usage, err := cluster.BckUsage(bck)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	t.writeJSON(w, r, usage, "quota_usage")
+}
@@ -0,0 +1,120 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Rebalance plan (dry run): applies the hypothetical change of the cluster
+// membership to the current Smap and walks all local objects to count the ones
+// that would have to be sent to other targets - by destination.
+// Only main replicas are counted - mirrored copies get recreated by the
+// receiving targets and EC slices are not included. Likewise, only inter-target
+// movement is counted (local, mountpath-to-mountpath moves are not).
+
+func (t *targetrunner) rebalancePlan(w http.ResponseWriter, r *http.Request, what string) {
+	msg := &cmn.RebPlanMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	smap := t.owner.smap.get().clone()
+	for _, sid := range msg.Remove {
+		if smap.GetTarget(sid) == nil {
+			t.writeErrf(w, r, "%s: target %q is not a member of %s", t.si, sid, smap)
+			return
+		}
+		smap.delTarget(sid)
+	}
+	for _, sid := range msg.Add {
+		if smap.containsID(sid) {
+			t.writeErrf(w, r, "%s: node %q is already a member of %s", t.si, sid, smap)
+			return
+		}
+		tsi := &cluster.Snode{}
+		tsi.Init(sid, cmn.Target)
+		smap.addTarget(tsi)
+	}
+	tplan, err := t.walkRebPlan(&smap.Smap)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	t.writeJSON(w, r, tplan, what)
+}
+
+func (t *targetrunner) walkRebPlan(smap *cluster.Smap) (*cmn.TRebPlan, error) {
+	var (
+		availablePaths, _ = fs.Get()
+		tplan             = &cmn.TRebPlan{Out: make(map[string]cmn.RebPlanCount)}
+		mu                sync.Mutex
+		wg                = &sync.WaitGroup{}
+		errCh             = make(chan error, len(availablePaths))
+		bmd               = t.owner.bmd.get()
+	)
+	for _, mi := range availablePaths {
+		wg.Add(1)
+		go func(mi *fs.MountpathInfo) {
+			defer wg.Done()
+			var (
+				stored cmn.RebPlanCount
+				out    = make(map[string]cmn.RebPlanCount)
+			)
+			bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+				cb := func(fqn string, de fs.DirEntry) error {
+					if de.IsDir() {
+						return nil
+					}
+					lom := cluster.AllocLOMbyFQN(fqn)
+					defer cluster.FreeLOM(lom)
+					if err := lom.Init(bck.Bck); err != nil {
+						return nil
+					}
+					if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil || lom.IsCopy() {
+						return nil
+					}
+					cnt := cmn.RebPlanCount{Objs: 1, Size: lom.SizeBytes()}
+					stored.Add(cnt)
+					tsi, err := cluster.HrwTarget(lom.Uname(), smap)
+					if err != nil {
+						return err
+					}
+					if tsi.ID() != t.si.ID() {
+						c := out[tsi.ID()]
+						c.Add(cnt)
+						out[tsi.ID()] = c
+					}
+					return nil
+				}
+				opts := &fs.Options{Mpath: mi, Bck: bck.Bck, CTs: []string{fs.ObjectType}, Callback: cb}
+				if err := fs.Walk(opts); err != nil && !os.IsNotExist(err) {
+					errCh <- err
+					return true
+				}
+				return false
+			})
+			mu.Lock()
+			tplan.Stored.Add(stored)
+			for sid, cnt := range out {
+				c := tplan.Out[sid]
+				c.Add(cnt)
+				tplan.Out[sid] = c
+			}
+			mu.Unlock()
+		}(mi)
+	}
+	wg.Wait()
+	close(errCh)
+	if err := <-errCh; err != nil {
+		return nil, err
+	}
+	return tplan, nil
+}
@@ -0,0 +1,82 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestWalkRebPlan(tst *testing.T) {
+	const (
+		objCnt  = 100
+		objSize = cos.KiB
+	)
+	var (
+		bck  = cmn.Bck{Name: testBucket, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+		tsi  = &cluster.Snode{}
+		smap = &cluster.Smap{Tmap: cluster.NodeMap{t.si.ID(): t.si}}
+		fqns = make([]string, 0, objCnt)
+	)
+	defer func() {
+		for _, fqn := range fqns {
+			os.Remove(fqn)
+		}
+	}()
+	tsi.Init("newtarget", cmn.Target)
+	for i := 0; i < objCnt; i++ {
+		lom := cluster.AllocLOM(fmt.Sprintf("rebplan/obj-%d", i))
+		tassert.CheckFatal(tst, lom.Init(bck))
+		fh, err := cos.CreateFile(lom.FQN)
+		tassert.CheckFatal(tst, err)
+		fqns = append(fqns, lom.FQN)
+		_, err = fh.Write(make([]byte, objSize))
+		fh.Close()
+		tassert.CheckFatal(tst, err)
+		lom.SetSize(objSize)
+		tassert.CheckFatal(tst, lom.Persist())
+		cluster.FreeLOM(lom)
+	}
+
+	// no membership change - nothing moves
+	smap.InitDigests()
+	tplan, err := t.walkRebPlan(smap)
+	tassert.CheckFatal(tst, err)
+	tassert.Errorf(tst, tplan.Stored.Objs == objCnt && tplan.Stored.Size == objCnt*objSize,
+		"expected %d objects stored, got %+v", objCnt, tplan.Stored)
+	tassert.Errorf(tst, len(tplan.Out) == 0, "expected nothing to send, got %+v", tplan.Out)
+
+	// a target joins - it receives its HRW share
+	smap.Tmap[tsi.ID()] = tsi
+	smap.InitDigests()
+	var expected int64
+	for i := 0; i < objCnt; i++ {
+		uname := bck.MakeUname(fmt.Sprintf("rebplan/obj-%d", i))
+		si, err := cluster.HrwTarget(uname, smap)
+		tassert.CheckFatal(tst, err)
+		if si.ID() == tsi.ID() {
+			expected++
+		}
+	}
+	tplan, err = t.walkRebPlan(smap)
+	tassert.CheckFatal(tst, err)
+	out := tplan.Out[tsi.ID()]
+	tassert.Errorf(tst, len(tplan.Out) <= 1 && out.Objs == expected && out.Size == expected*objSize,
+		"expected %d objects to move to %s, got %+v", expected, tsi, tplan.Out)
+
+	// this target leaves - everything moves
+	delete(smap.Tmap, t.si.ID())
+	tplan, err = t.walkRebPlan(smap)
+	tassert.CheckFatal(tst, err)
+	out = tplan.Out[tsi.ID()]
+	tassert.Errorf(tst, out.Objs == objCnt && out.Size == objCnt*objSize,
+		"expected %d objects to move to %s, got %+v", objCnt, tsi, tplan.Out)
+}
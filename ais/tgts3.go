@@ -5,6 +5,7 @@
 package ais
 
 import (
+	"encoding/xml"
 	"net/http"
 	"path"
 	"strings"
@@ -14,7 +15,6 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
-	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
 )
@@ -25,18 +25,63 @@ func (t *targetrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	// the payload of a signed request (redirected by proxy) must match its signature
+	if err := s3compat.VerifyPayload(r); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
 
+	var (
+		query       = r.URL.Query()
+		_, mptStart = query[s3compat.URLParamMptUploads]
+		mpt         = query.Get(s3compat.URLParamMptUploadID) != ""
+		_, tagging  = query[s3compat.URLParamTagging]
+	)
 	switch r.Method {
 	case http.MethodHead:
 		t.headObjS3(w, r, apiItems)
 	case http.MethodGet:
+		if tagging {
+			t.getObjTaggingS3(w, r, apiItems)
+			return
+		}
+		if mpt {
+			t.listPartsS3(w, r, apiItems, query)
+			return
+		}
 		t.getObjS3(w, r, apiItems)
 	case http.MethodPut:
+		if tagging {
+			t.putObjTaggingS3(w, r, apiItems)
+			return
+		}
+		if mpt {
+			t.putObjPartS3(w, r, apiItems, query)
+			return
+		}
 		t.putObjS3(w, r, apiItems)
+	case http.MethodPost:
+		switch {
+		case mptStart:
+			t.startMptS3(w, r, apiItems)
+		case mpt:
+			t.completeMptS3(w, r, apiItems, query)
+		default:
+			t.writeErr(w, r, errS3Req)
+		}
 	case http.MethodDelete:
+		if tagging {
+			t.putObjTaggingS3(w, r, apiItems)
+			return
+		}
+		if mpt {
+			t.abortMptS3(w, r, apiItems, query)
+			return
+		}
 		t.delObjS3(w, r, apiItems)
 	default:
-		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodHead, http.MethodPut)
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodHead,
+			http.MethodPost, http.MethodPut)
 	}
 }
 
@@ -132,10 +177,16 @@ func (t *targetrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, it
 			return
 		}
 	}
+	custom, err := s3compat.CustomMDFromHeader(r.Header)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
 	if lom.Bck().IsAIS() && lom.VersionConf().Enabled {
 		lom.Load(true /*cache it*/, false /*locked*/) // load it to see the current version
 	}
 	lom.SetAtimeUnix(started.UnixNano())
+	lom.SetCustom(custom) // S3 PUT replaces the object along with its metadata
 
 	// TODO: dual checksumming, e.g. lom.SetCustom(cluster.SourceAmazonObjMD, ...)
 
@@ -168,8 +219,11 @@ func (t *targetrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []
 		return
 	}
 	lom := cluster.AllocLOM(path.Join(items[1:]...))
+	// S3 headers (etag/md5, user metadata) must be set before writing the body
+	if err := lom.Init(bck.Bck); err == nil && lom.Load(true /*cache it*/, false /*locked*/) == nil {
+		s3compat.SetObjHeaders(w.Header(), lom)
+	}
 	t.getObject(w, r, r.URL.Query(), bck, lom)
-	s3compat.SetETag(w.Header(), lom) // add etag/md5
 	cluster.FreeLOM(lom)
 }
 
@@ -188,7 +242,7 @@ func (t *targetrunner) headObjS3(w http.ResponseWriter, r *http.Request, items [
 
 	lom := cluster.AllocLOM(objName)
 	t.headObject(w, r, r.URL.Query(), bck, lom)
-	s3compat.SetETag(w.Header(), lom) // add etag/md5
+	s3compat.SetObjHeaders(w.Header(), lom) // add etag/md5 and user metadata
 	cluster.FreeLOM(lom)
 }
 
@@ -218,8 +272,74 @@ func (t *targetrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []
 		} else {
 			t.writeErrStatusf(w, r, errCode, "error deleting %s: %v", lom, err)
 		}
+	}
+}
+
+// GET s3/bckName/objName?tagging
+func (t *targetrunner) getObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom, err := t.initS3LOM(r, items)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			t.writeErr(w, r, err, http.StatusNotFound)
+		} else {
+			t.writeErr(w, r, err)
+		}
+		return
+	}
+	tags := s3compat.NewTagging(lom.Custom())
+	sgl := memsys.DefaultPageMM().NewSGL(0)
+	tags.MustMarshal(sgl)
+	w.Header().Set(cmn.HdrContentType, cmn.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// PUT s3/bckName/objName?tagging - replace object tags
+// DEL s3/bckName/objName?tagging - remove all object tags
+func (t *targetrunner) putObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	var tags *s3compat.Tagging
+	if r.Method == http.MethodPut {
+		tags = &s3compat.Tagging{}
+		err := xml.NewDecoder(r.Body).Decode(tags)
+		cos.Close(r.Body)
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+	}
+	lom, err := t.initS3LOM(r, items)
+	if err != nil {
+		t.writeErr(w, r, err)
 		return
 	}
-	// EC cleanup if EC is enabled
-	ec.ECM.CleanupObject(lom)
+	defer cluster.FreeLOM(lom)
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			t.writeErr(w, r, err, http.StatusNotFound)
+		} else {
+			t.writeErr(w, r, err)
+		}
+		return
+	}
+	custom, err := tags.ApplyTo(lom.Custom())
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	lom.SetCustom(custom)
+	if err := lom.Persist(); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	if r.Method == http.MethodDelete {
+		w.WriteHeader(http.StatusNoContent)
+	}
 }
@@ -0,0 +1,283 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/s3compat"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// S3 multipart upload: parts are stored as workfiles and, upon completion,
+// get assembled into the destination object (see also s3compat/multipart.go)
+
+func (t *targetrunner) initS3LOM(r *http.Request, items []string) (lom *cluster.LOM, err error) {
+	if len(items) < 2 {
+		return nil, errS3Obj
+	}
+	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
+	if err = bck.Init(t.owner.bmd); err != nil {
+		return
+	}
+	lom = cluster.AllocLOM(path.Join(items[1:]...))
+	if err = lom.Init(bck.Bck); err != nil {
+		if cmn.IsErrRemoteBckNotFound(err) {
+			t.BMDVersionFixup(r)
+			err = lom.Init(bck.Bck)
+		}
+		if err != nil {
+			cluster.FreeLOM(lom)
+			lom = nil
+		}
+	}
+	return
+}
+
+// POST s3/bckName/objName?uploads
+func (t *targetrunner) startMptS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom, err := t.initS3LOM(r, items)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	custom, err := s3compat.CustomMDFromHeader(r.Header)
+	if err != nil {
+		cluster.FreeLOM(lom)
+		t.writeErr(w, r, err)
+		return
+	}
+	id := s3compat.NewUploadID()
+	s3compat.InitUpload(id, lom.Uname(), custom)
+	result := s3compat.NewInitiateMptUploadResult(lom.Bucket().Name, lom.ObjName, id)
+	cluster.FreeLOM(lom)
+
+	sgl := memsys.DefaultPageMM().NewSGL(0)
+	result.MustMarshal(sgl)
+	w.Header().Set(cmn.HdrContentType, cmn.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// PUT s3/bckName/objName?partNumber=N&uploadId=ID
+func (t *targetrunner) putObjPartS3(w http.ResponseWriter, r *http.Request, items []string, query url.Values) {
+	if cs := fs.GetCapStatus(); cs.OOS {
+		t.writeErr(w, r, cs.Err)
+		return
+	}
+	partNum, err := s3compat.ParsePartNum(query.Get(s3compat.URLParamMptPartNumber))
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	lom, err := t.initS3LOM(r, items)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	defer cluster.FreeLOM(lom)
+
+	var (
+		fh      *os.File
+		size    int64
+		buf     []byte
+		slab    *memsys.Slab
+		id      = query.Get(s3compat.URLParamMptUploadID)
+		workFQN = fs.CSM.GenContentFQN(lom, fs.WorkfileType, fs.WorkfileS3Mpt)
+		md5     = cos.NewCksumHash(cos.ChecksumMD5)
+	)
+	if r.ContentLength <= 0 {
+		buf, slab = t.gmm.Alloc()
+	} else {
+		buf, slab = t.gmm.AllocSize(r.ContentLength)
+	}
+	if fh, err = lom.CreateFile(workFQN); err == nil {
+		size, err = io.CopyBuffer(cos.NewWriterMulti(fh, md5.H), r.Body, buf)
+		cos.Close(fh)
+	}
+	slab.Free(buf)
+	cos.Close(r.Body)
+	if err != nil {
+		if errV := cos.RemoveFile(workFQN); errV != nil {
+			glog.Errorf("Nested error: %v => (remove %s => err: %v)", err, workFQN, errV)
+		}
+		t.fsErr(err, workFQN)
+		t.writeErr(w, r, err)
+		return
+	}
+	md5.Finalize()
+	part := &s3compat.MptPart{MD5: md5.Value(), FQN: workFQN, Size: size, Num: partNum}
+	prev, err := s3compat.AddPart(id, lom.Uname(), part)
+	if err != nil {
+		cos.RemoveFile(workFQN)
+		t.writeErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	if prev != nil { // re-uploaded
+		cos.RemoveFile(prev.FQN)
+	}
+	w.Header().Set(s3compat.HeaderETag, s3compat.QuoteETag(part.MD5))
+}
+
+// POST s3/bckName/objName?uploadId=ID
+func (t *targetrunner) completeMptS3(w http.ResponseWriter, r *http.Request, items []string, query url.Values) {
+	started := time.Now()
+	if cs := fs.GetCapStatus(); cs.OOS {
+		t.writeErr(w, r, cs.Err)
+		return
+	}
+	lom, err := t.initS3LOM(r, items)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	defer cluster.FreeLOM(lom)
+
+	var (
+		id    = query.Get(s3compat.URLParamMptUploadID)
+		uname = lom.Uname()
+		req   = &s3compat.CompleteMptUpload{}
+	)
+	err = xml.NewDecoder(r.Body).Decode(req)
+	cos.Close(r.Body)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	parts, custom, err := s3compat.CheckParts(id, uname, req.Parts)
+	if err != nil {
+		if cmn.IsObjNotExist(err) {
+			t.writeErr(w, r, err, http.StatusNotFound)
+		} else {
+			t.writeErr(w, r, err)
+		}
+		return
+	}
+	etag, err := s3compat.MptETag(parts)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+
+	// assemble the object
+	var (
+		size    int64
+		files   = make([]*os.File, 0, len(parts))
+		readers = make([]io.Reader, 0, len(parts))
+	)
+	for _, part := range parts {
+		fh, err := os.Open(part.FQN)
+		if err != nil {
+			for _, fh := range files {
+				cos.Close(fh)
+			}
+			t.writeErr(w, r, err)
+			return
+		}
+		files = append(files, fh)
+		readers = append(readers, fh)
+		size += part.Size
+	}
+	if lom.Bck().IsAIS() && lom.VersionConf().Enabled {
+		lom.Load(true /*cache it*/, false /*locked*/) // load it to see the current version
+	}
+	lom.SetAtimeUnix(started.UnixNano())
+	lom.SetCustom(custom)
+	poi := allocPutObjInfo()
+	{
+		poi.started = started
+		poi.t = t
+		poi.lom = lom
+		poi.r = io.NopCloser(io.MultiReader(readers...))
+		poi.size = size
+		poi.workFQN = fs.CSM.GenContentFQN(lom, fs.WorkfileType, fs.WorkfilePut)
+	}
+	errCode, err := poi.putObject()
+	freePutObjInfo(poi)
+	for _, fh := range files {
+		cos.Close(fh)
+	}
+	if err != nil {
+		t.fsErr(err, lom.FQN)
+		t.writeErr(w, r, err, errCode)
+		return
+	}
+
+	// cleanup
+	if all, err := s3compat.FinishUpload(id, uname); err == nil {
+		for _, part := range all {
+			if err := cos.RemoveFile(part.FQN); err != nil {
+				glog.Errorf("%s: failed to remove %s upload part %s: %v", t.si, lom, part.FQN, err)
+			}
+		}
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: %d-part upload %q => %s (%dB)", t.si, len(parts), id, lom, size)
+	}
+
+	result := s3compat.NewCompleteMptUploadResult(lom.Bucket().Name, lom.ObjName, etag)
+	sgl := memsys.DefaultPageMM().NewSGL(0)
+	result.MustMarshal(sgl)
+	w.Header().Set(cmn.HdrContentType, cmn.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// DELETE s3/bckName/objName?uploadId=ID
+func (t *targetrunner) abortMptS3(w http.ResponseWriter, r *http.Request, items []string, query url.Values) {
+	lom, err := t.initS3LOM(r, items)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	id := query.Get(s3compat.URLParamMptUploadID)
+	parts, err := s3compat.FinishUpload(id, lom.Uname())
+	cluster.FreeLOM(lom)
+	if err != nil {
+		t.writeErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	for _, part := range parts {
+		if err := cos.RemoveFile(part.FQN); err != nil {
+			glog.Errorf("%s: failed to remove upload %q part %s: %v", t.si, id, part.FQN, err)
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET s3/bckName/objName?uploadId=ID
+func (t *targetrunner) listPartsS3(w http.ResponseWriter, r *http.Request, items []string, query url.Values) {
+	lom, err := t.initS3LOM(r, items)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	id := query.Get(s3compat.URLParamMptUploadID)
+	parts, err := s3compat.ListParts(id, lom.Uname())
+	if err != nil {
+		cluster.FreeLOM(lom)
+		t.writeErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	result := s3compat.NewListPartsResult(lom.Bucket().Name, lom.ObjName, id, parts)
+	cluster.FreeLOM(lom)
+
+	sgl := memsys.DefaultPageMM().NewSGL(0)
+	result.MustMarshal(sgl)
+	w.Header().Set(cmn.HdrContentType, cmn.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
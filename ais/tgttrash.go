@@ -0,0 +1,111 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Soft delete (see cmn.TrashConf): listing and restoring deleted objects and
+// destroyed buckets. Note that the deleted content stays where it was at the
+// time of deletion - in particular, it does not get rebalanced.
+
+// POST /v1/objects/bucket-name/object-name { "action": "restoreobj" }
+func (t *targetrunner) restoreObj(w http.ResponseWriter, r *http.Request) {
+	request := &apiRequest{after: 2, prefix: cmn.URLPathObjects.L}
+	if err := t.parseAPIRequest(w, r, request); err != nil {
+		return
+	}
+	lom := cluster.AllocLOM(request.items[1])
+	defer cluster.FreeLOM(lom)
+	if err := lom.Init(request.bck.Bck); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	if !lom.Bck().IsAIS() {
+		t.writeErrf(w, r, "%s: cannot restore object %s from a remote bucket", t.si, lom)
+		return
+	}
+	lom.Lock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err == nil {
+		lom.Unlock(true)
+		t.writeErrf(w, r, "%s: cannot restore %s - object exists", t.si, lom)
+		return
+	}
+	err := lom.Undelete()
+	lom.Unlock(true)
+	if err != nil {
+		t.fsErr(err, lom.FQN)
+		t.writeErr(w, r, err)
+		return
+	}
+	t.putMirror(lom)
+	if ecErr := ec.ECM.EncodeObject(lom); ecErr != nil && ecErr != ec.ErrorECDisabled {
+		glog.Errorf("%s: failed to erasure-code restored %s: %v", t.si, lom, ecErr)
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: restored %s", t.si, lom)
+	}
+}
+
+// GET /v1/buckets[/bucket-name] { "action": "listtrash" }
+func (t *targetrunner) listTrash(w http.ResponseWriter, r *http.Request, queryBcks cmn.QueryBcks) {
+	var (
+		entries           cmn.TrashEntries
+		availablePaths, _ = fs.Get()
+	)
+	if queryBcks.Name == "" {
+		bids := make(map[uint64]*cmn.TrashEntry)
+		for _, mi := range availablePaths {
+			dbcks, err := mi.DeletedBcks()
+			if err != nil {
+				t.writeErr(w, r, err)
+				return
+			}
+			for _, entry := range dbcks {
+				if queryBcks.Contains(entry.Bck) {
+					bids[entry.Props.BID] = entry
+				}
+			}
+		}
+		for _, entry := range bids {
+			entries = append(entries, entry)
+		}
+		t.writeJSON(w, r, entries, "list_trash")
+		return
+	}
+
+	bck := cluster.NewBckEmbed(cmn.Bck(queryBcks))
+	if err := bck.Init(t.owner.bmd); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	names := make(map[string]*cmn.TrashEntry)
+	for _, mi := range availablePaths {
+		err := mi.WalkDeletedObjs(bck.Bck, func(_, objName string, finfo os.FileInfo) error {
+			deleted := finfo.ModTime().UnixNano()
+			if entry, ok := names[objName]; ok && entry.Deleted >= deleted {
+				return nil
+			}
+			names[objName] = &cmn.TrashEntry{Bck: bck.Bck, ObjName: objName, Size: finfo.Size(), Deleted: deleted}
+			return nil
+		})
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+	}
+	for _, entry := range names {
+		entries = append(entries, entry)
+	}
+	t.writeJSON(w, r, entries, "list_trash")
+}
@@ -0,0 +1,105 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/readers"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// soft delete must retain previous versions and EC data of the deleted object
+// so that the restored object gets them back
+func TestRestoreObjectECVersions(tst *testing.T) {
+	const (
+		objName = "trash/obj"
+		objSize = cos.KiB
+	)
+	bck := cmn.Bck{Name: testTrashBucket, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	tassert.CheckFatal(tst, lom.Init(bck))
+	defer func() {
+		lom.Lock(true)
+		lom.DelAllVersions()
+		os.Remove(lom.FQN)
+		lom.Unlock(true)
+	}()
+
+	// two PUTs - the first one becomes a previous version
+	for i := 0; i < 2; i++ {
+		r, err := readers.NewRandReader(objSize, cos.ChecksumNone)
+		tassert.CheckFatal(tst, err)
+		poi := &putObjInfo{
+			started: time.Now(),
+			t:       t,
+			lom:     lom,
+			r:       r,
+			workFQN: path.Join(testMountpath, "trash-obj.work"),
+			skipEC:  true, // (see below)
+		}
+		_, err = poi.putObject()
+		tassert.CheckFatal(tst, err)
+	}
+	ver := lom.Version()
+	checkVersions := func(tag string) {
+		lom.Lock(false)
+		vlist, err := lom.Versions()
+		lom.Unlock(false)
+		tassert.CheckFatal(tst, err)
+		tassert.Fatalf(tst, len(vlist) == 1, "%s: expected 1 previous version, got %d", tag, len(vlist))
+		for _, vlom := range vlist {
+			cluster.FreeLOM(vlom)
+		}
+	}
+	checkVersions("put")
+
+	// EC data, as if the object was encoded (local metafile and slice)
+	ecFQNs := []string{
+		cluster.NewCTFromLOM(lom, fs.ECMetaType).FQN(),
+		cluster.NewCTFromLOM(lom, fs.ECSliceType).FQN(),
+	}
+	for _, fqn := range ecFQNs {
+		fh, err := cos.CreateFile(fqn)
+		tassert.CheckFatal(tst, err)
+		fh.Close()
+		defer os.Remove(fqn)
+	}
+	checkEC := func(tag string) {
+		for _, fqn := range ecFQNs {
+			_, err := os.Stat(fqn)
+			tassert.Errorf(tst, err == nil, "%s: expected %q to exist: %v", tag, fqn, err)
+		}
+	}
+
+	_, err := t.DeleteObject(lom, false /*evict*/)
+	tassert.CheckFatal(tst, err)
+	lom.Lock(true)
+	err = lom.Load(false, true)
+	lom.Unlock(true)
+	tassert.Fatalf(tst, cmn.IsObjNotExist(err), "expected %s to be deleted, got %v", lom, err)
+	checkVersions("delete")
+	checkEC("delete")
+
+	lom.Lock(true)
+	err = lom.Undelete()
+	lom.Unlock(true)
+	tassert.CheckFatal(tst, err)
+	lom.Lock(false)
+	err = lom.Load(false, true)
+	lom.Unlock(false)
+	tassert.CheckFatal(tst, err)
+	tassert.Errorf(tst, lom.Version() == ver, "expected restored version %q, got %q", ver, lom.Version())
+	checkVersions("restore")
+	checkEC("restore")
+}
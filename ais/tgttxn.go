@@ -17,7 +17,6 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
-	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/etl"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/mirror"
@@ -83,7 +82,7 @@ func (t *targetrunner) txnHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	switch msg.Action {
-	case cmn.ActCreateBck, cmn.ActAddRemoteBck:
+	case cmn.ActCreateBck, cmn.ActAddRemoteBck, cmn.ActRestoreBck:
 		err = t.createBucket(c)
 	case cmn.ActMakeNCopies:
 		err = t.makeNCopies(c)
@@ -281,6 +280,19 @@ func (t *targetrunner) setBucketProps(c *txnServerCtx) error {
 		if err = t.transactions.wait(txn, c.timeout.netw, c.timeout.host); err != nil {
 			return fmt.Errorf("%s %s: %v", t.si, txn, err)
 		}
+		// re-encode takes care of mirroring and EC (see ec/bckreencodexact.go)
+		if reEncode(txnSetBprops.bprops, txnSetBprops.nprops) {
+			xreg.DoAbort(cmn.ActPutCopies, c.bck)
+			xreg.DoAbort(cmn.ActECEncode, c.bck)
+			rns := xreg.RenewReencode(t, c.bck, c.uuid)
+			if rns.Err != nil {
+				return fmt.Errorf("%s %s: %v", t.si, txn, rns.Err)
+			}
+			xact := rns.Entry.Get()
+			c.addNotif(xact) // notify upon completion
+			go xact.Run()
+			return nil
+		}
 		if reMirror(txnSetBprops.bprops, txnSetBprops.nprops) {
 			n := int(txnSetBprops.nprops.Mirror.Copies)
 			rns := xreg.RenewBckMakeNCopies(t, c.bck, c.uuid, "mnc-setprops", n)
@@ -436,10 +448,7 @@ func (t *targetrunner) validateBckRenTxn(bckFrom, bckTo *cluster.Bck, msg *aisMs
 // a reader based on a given ETL transformation.
 func (t *targetrunner) etlBucket(c *txnServerCtx, msg *cmn.TransCpyBckMsg) (err error) {
 	var dp cluster.LomReaderProvider
-	if err := k8s.Detect(); err != nil {
-		return err
-	}
-	if msg.ID == "" {
+	if msg.ETLID() == "" {
 		return cmn.ErrETLMissingUUID
 	}
 	if dp, err = etl.NewOfflineDataProvider(msg, t.si); err != nil {
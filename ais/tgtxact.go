@@ -81,6 +81,19 @@ func (t *targetrunner) xactHandler(w http.ResponseWriter, r *http.Request) {
 			}
 			xreg.DoAbort(xactMsg.Kind, bck)
 			return
+		case cmn.ActXactPause, cmn.ActXactResume:
+			var (
+				err error
+				flt = xreg.XactFilter{ID: xactMsg.ID, Kind: xactMsg.Kind, Bck: bck}
+			)
+			if msg.Action == cmn.ActXactPause {
+				err = xreg.DoPause(flt)
+			} else {
+				err = xreg.DoResume(flt)
+			}
+			if err != nil {
+				t.writeErr(w, r, err)
+			}
 		default:
 			t.writeErrAct(w, r, msg.Action)
 		}
@@ -152,6 +165,24 @@ func (t *targetrunner) cmdXactStart(xactMsg *xaction.XactReqMsg, bck *cluster.Bc
 			},
 		}
 		go t.runResilver(xactMsg.ID, false /*skipGlobMisplaced*/, notif)
+	case cmn.ActScrub: // bucket is optional
+		rns := xreg.RenewScrub(t, xactMsg.ID, bck, xactMsg.Restart)
+		if rns.Err != nil {
+			return rns.Err
+		}
+		if rns.UUID != "" {
+			return fmt.Errorf("%s: %s is already running", t.si, rns.Entry.Get())
+		}
+		xact := rns.Entry.Get()
+		xact.AddNotif(&xaction.NotifXact{
+			NotifBase: nl.NotifBase{
+				When: cluster.UponTerm,
+				Dsts: []string{equalIC},
+				F:    t.callerNotifyFin,
+			},
+			Xact: xact,
+		})
+		go xact.Run()
 	// 2. with bucket
 	case cmn.ActPrefetch:
 		args := &cmn.ListRangeMsg{}
@@ -168,6 +199,23 @@ func (t *targetrunner) cmdXactStart(xactMsg *xaction.XactReqMsg, bck *cluster.Bc
 		go xact.Run()
 	case cmn.ActLoadLomCache:
 		return xreg.RenewBckLoadLomCache(t, xactMsg.ID, bck)
+	case cmn.ActLifecycle:
+		return t.runLifecycle(bck, xactMsg.ID, xactMsg.DryRun)
+	case cmn.ActReencode:
+		rns := xreg.RenewReencode(t, bck, xactMsg.ID)
+		if rns.Err != nil {
+			return rns.Err
+		}
+		xact := rns.Entry.Get()
+		xact.AddNotif(&xaction.NotifXact{
+			NotifBase: nl.NotifBase{
+				When: cluster.UponTerm,
+				Dsts: []string{equalIC},
+				F:    t.callerNotifyFin,
+			},
+			Xact: xact,
+		})
+		go xact.Run()
 	// 3. cannot start
 	case cmn.ActPutCopies:
 		return fmt.Errorf("cannot start %q (is driven by PUTs into a mirrored bucket)", xactMsg)
@@ -257,11 +257,29 @@ func reEC(bprops, nprops *cmn.BucketProps, bck *cluster.Bck) bool {
 		}
 		return false
 	}
-	if !bprops.EC.Enabled {
+	// NOTE: changing EC geometry requires re-encode (see reEncode)
+	return !bprops.EC.Enabled
+}
+
+// changing EC geometry or storage classes requires re-encoding (and re-mirroring)
+// of the existing objects
+func reEncode(bprops, nprops *cmn.BucketProps) bool {
+	if bprops.EC.Enabled && nprops.EC.Enabled {
+		if bprops.EC.DataSlices != nprops.EC.DataSlices || bprops.EC.ParitySlices != nprops.EC.ParitySlices ||
+			bprops.EC.ObjSizeLimit != nprops.EC.ObjSizeLimit {
+			return true
+		}
+	}
+	oclasses, nclasses := bprops.StorageClass.Classes, nprops.StorageClass.Classes
+	if len(oclasses) != len(nclasses) {
 		return true
 	}
-	return bprops.EC.DataSlices != nprops.EC.DataSlices ||
-		bprops.EC.ParitySlices != nprops.EC.ParitySlices
+	for i := range oclasses {
+		if oclasses[i] != nclasses[i] {
+			return true
+		}
+	}
+	return false
 }
 
 func withRetry(cond func() bool) (ok bool) {
@@ -70,6 +70,25 @@ func LoginUser(baseParams BaseParams, userID, pass string, expire *time.Duration
 	return token, nil
 }
 
+// Issue S3 (SigV4) access and secret keys for a user. The keys expire in
+// `expire` time - same as the token returned by LoginUser
+func IssueS3Creds(baseParams BaseParams, userID, pass string, expire *time.Duration) (creds *authn.S3Creds, err error) {
+	baseParams.Method = http.MethodPost
+	rec := authn.LoginMsg{Password: pass, ExpiresIn: expire}
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathUsers.Join(userID, cmn.S3Creds),
+		Body:       cos.MustMarshal(rec),
+	}, &creds)
+	if err != nil {
+		return nil, err
+	}
+	if creds.AccessKey == "" {
+		return nil, errors.New("failed to issue S3 credentials: empty response from AuthN server")
+	}
+	return creds, nil
+}
+
 func RegisterClusterAuthN(baseParams BaseParams, cluSpec authn.Cluster) error {
 	msg := cos.MustMarshal(cluSpec)
 	baseParams.Method = http.MethodPost
@@ -172,6 +172,49 @@ func DestroyBucket(baseParams BaseParams, bck cmn.Bck) error {
 	})
 }
 
+// RestoreBucket restores the most recently destroyed AIS bucket with the given
+// name, provided the bucket is configured with delete retention (see cmn.TrashConf)
+// and the retention period hasn't expired yet.
+func RestoreBucket(baseParams BaseParams, bck cmn.Bck) error {
+	baseParams.Method = http.MethodPost
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathBuckets.Join(bck.Name),
+		Body:       cos.MustMarshal(cmn.ActionMsg{Action: cmn.ActRestoreBck}),
+		Query:      cmn.AddBckToQuery(nil, bck),
+	})
+}
+
+// ListTrash returns restorable destroyed buckets (when the bucket name is
+// empty) or deleted objects of the specified bucket.
+func ListTrash(baseParams BaseParams, queryBcks cmn.QueryBcks) (cmn.TrashEntries, error) {
+	var entries cmn.TrashEntries
+	baseParams.Method = http.MethodGet
+	err := DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathBuckets.Join(queryBcks.Name),
+		Body:       cos.MustMarshal(cmn.ActionMsg{Action: cmn.ActListTrash}),
+		Query:      cmn.AddBckToQuery(nil, cmn.Bck(queryBcks)),
+	}, &entries)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetQuotaUsage returns the cluster-wide usage of a bucket that has quota
+// (see cmn.QuotaConf).
+func GetQuotaUsage(baseParams BaseParams, bck cmn.Bck) (usage cmn.QuotaUsage, err error) {
+	baseParams.Method = http.MethodGet
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathBuckets.Join(bck.Name),
+		Body:       cos.MustMarshal(cmn.ActionMsg{Action: cmn.ActQuotaUsage}),
+		Query:      cmn.AddBckToQuery(nil, bck),
+	}, &usage)
+	return
+}
+
 // DoesBucketExist queries a proxy or target to get a list of all AIS buckets,
 // returns true if the bucket is present in the list.
 func DoesBucketExist(baseParams BaseParams, query cmn.QueryBcks) (bool, error) {
@@ -299,6 +342,9 @@ func waitForAsyncReqComplete(reqParams ReqParams, action string, msg *cmn.Bucket
 
 // ListObjects returns list of objects in a bucket. `numObjects` is the
 // maximum number of objects returned (0 - return all objects in a bucket).
+// With `cmn.SelectArchDir` set in `smsg.Flags`, archived objects are also listed
+// as directories: each archived file becomes a separate `cmn.EntryInArch` entry
+// named `objname/filename` (e.g., "shard.tar/a/b.jpg").
 func ListObjects(baseParams BaseParams, bck cmn.Bck, smsg *cmn.SelectMsg, numObjects uint,
 	args ...*ProgressContext) (bckList *cmn.BucketList, err error) {
 	baseParams.Method = http.MethodGet
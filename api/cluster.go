@@ -98,6 +98,19 @@ func GetRemoteAIS(baseParams BaseParams) (aisInfo cmn.BackendInfoAIS, err error)
 	return
 }
 
+// GetRebalancePlan estimates the data movement that the given (hypothetical)
+// change of the cluster membership would cause. Nothing gets moved.
+func GetRebalancePlan(baseParams BaseParams, msg *cmn.RebPlanMsg) (plan *cmn.RebPlan, err error) {
+	baseParams.Method = http.MethodGet
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathCluster.S,
+		Query:      url.Values{cmn.URLParamWhat: []string{cmn.GetWhatRebPlan}},
+		Body:       cos.MustMarshal(msg),
+	}, &plan)
+	return
+}
+
 // JoinCluster add a node to a cluster.
 func JoinCluster(baseParams BaseParams, nodeInfo *cluster.Snode) (rebID, daemonID string, err error) {
 	var info JoinNodeResult
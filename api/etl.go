@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -69,6 +70,12 @@ func ETLObject(baseParams BaseParams, id string, bck cmn.Bck, objName string, w
 	return
 }
 
+// ETLPipelineObject transforms the object by the ETLs with the given IDs applied in sequence
+// (see also: `cmn.TransCpyBckMsg.Pipeline`).
+func ETLPipelineObject(baseParams BaseParams, ids []string, bck cmn.Bck, objName string, w io.Writer) (err error) {
+	return ETLObject(baseParams, strings.Join(ids, cmn.ETLPipelineSepa), bck, objName, w)
+}
+
 func ETLBucket(baseParams BaseParams, fromBck, toBck cmn.Bck, bckMsg *cmn.TransCpyBckMsg) (xactID string, err error) {
 	if err = toBck.Validate(); err != nil {
 		return
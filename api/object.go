@@ -72,6 +72,16 @@ type AppendArgs struct {
 	Size       int64
 }
 
+type AppendToArchArgs struct {
+	BaseParams BaseParams
+	Bck        cmn.Bck
+	Object     string // existing archive (shard)
+	ArchPath   string // pathname of the file inside the archive
+	Mime       string // optional; by default, archive format is determined by the object's name
+	Reader     cos.ReadOpenCloser
+	Size       int64 // required
+}
+
 type FlushArgs struct {
 	BaseParams BaseParams
 	Bck        cmn.Bck
@@ -397,6 +407,54 @@ func FlushObject(args FlushArgs) (err error) {
 	})
 }
 
+// AppendToArch adds a new file to an existing archived object: tar archives get
+// appended in place, while compressed ones (tgz, zip) are rewritten. Either way,
+// the operation is atomic, and the resulting object gets new checksum and version.
+func AppendToArch(args AppendToArchArgs) (err error) {
+	query := make(url.Values)
+	query.Add(cmn.URLParamAppendType, cmn.AppendArchOp)
+	query.Add(cmn.URLParamArchpath, args.ArchPath)
+	if args.Mime != "" {
+		query.Add(cmn.URLParamArchmime, args.Mime)
+	}
+	query = cmn.AddBckToQuery(query, args.Bck)
+
+	reqArgs := cmn.ReqArgs{
+		Method: http.MethodPut,
+		Base:   args.BaseParams.URL,
+		Path:   cmn.URLPathObjects.Join(args.Bck.Name, args.Object),
+		Query:  query,
+		BodyR:  args.Reader,
+	}
+	newRequest := func(reqArgs cmn.ReqArgs) (*http.Request, error) {
+		req, err := reqArgs.Req()
+		if err != nil {
+			return nil, cmn.NewFailedToCreateHTTPRequest(err)
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return args.Reader.Open()
+		}
+		req.ContentLength = args.Size
+		setAuthToken(req, args.BaseParams)
+		return req, nil
+	}
+	_, err = DoReqWithRetry(args.BaseParams.Client, newRequest, reqArgs) // nolint:bodyclose // is closed inside
+	return
+}
+
+// DeleteFromArch removes a file from an existing archived object
+// (which, in turn, gets rewritten).
+func DeleteFromArch(baseParams BaseParams, bck cmn.Bck, object, archPath string) error {
+	query := make(url.Values)
+	query.Add(cmn.URLParamArchpath, archPath)
+	baseParams.Method = http.MethodDelete
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathObjects.Join(bck.Name, object),
+		Query:      cmn.AddBckToQuery(query, bck),
+	})
+}
+
 // RenameObject renames object name from `oldName` to `newName`. Works only
 // across single, specified bucket.
 func RenameObject(baseParams BaseParams, bck cmn.Bck, oldName, newName string) error {
@@ -409,6 +467,37 @@ func RenameObject(baseParams BaseParams, bck cmn.Bck, oldName, newName string) e
 	})
 }
 
+// RestoreObject restores the most recently deleted object with the given name,
+// provided the bucket is configured with delete retention (see cmn.TrashConf).
+func RestoreObject(baseParams BaseParams, bck cmn.Bck, object string) error {
+	baseParams.Method = http.MethodPost
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathObjects.Join(bck.Name, object),
+		Body:       cos.MustMarshal(cmn.ActionMsg{Action: cmn.ActRestoreObject}),
+		Query:      cmn.AddBckToQuery(nil, bck),
+	})
+}
+
+// PresignObject returns a URL that allows to GET or PUT (as per `method`) the
+// specified object without any other credentials until the URL expires.
+// Requires AuthN; the caller must have the corresponding access to the object.
+func PresignObject(baseParams BaseParams, bck cmn.Bck, object, method string, expires time.Duration) (string, error) {
+	var presigned string
+	baseParams.Method = http.MethodPost
+	actMsg := cmn.ActionMsg{
+		Action: cmn.ActPresignObject,
+		Value:  &cmn.ActValPresign{Method: method, Expires: cos.Duration(expires)},
+	}
+	err := DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathObjects.Join(bck.Name, object),
+		Body:       cos.MustMarshal(actMsg),
+		Query:      cmn.AddBckToQuery(nil, bck),
+	}, &presigned)
+	return presigned, err
+}
+
 // PromoteFileOrDir promotes AIS-colocated files and directories to objects.
 //
 // NOTE: Advanced usage only.
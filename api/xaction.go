@@ -40,6 +40,8 @@ type (
 		Buckets     []cmn.Bck // Optional: Xaction on list of buckets
 		Timeout     time.Duration
 		Force       bool // Optional: force LRU
+		DryRun      bool // Optional: only report what would be done (lifecycle)
+		Restart     bool // Optional: start over, discarding saved progress (scrub)
 		OnlyRunning bool // Read only active xactions
 	}
 )
@@ -64,6 +66,16 @@ func (xs NodesXactStat) Aborted() bool {
 	return false
 }
 
+// paused on (at least) one of the nodes
+func (xs NodesXactStat) Paused() bool {
+	for _, stat := range xs {
+		if stat.Running() && stat.Paused() {
+			return true
+		}
+	}
+	return false
+}
+
 func (xs NodesXactStat) ObjCount() (count int64) {
 	for _, stat := range xs {
 		count += stat.ObjCount()
@@ -154,9 +166,11 @@ func StartXaction(baseParams BaseParams, args XactReqArgs) (id string, err error
 	}
 
 	xactMsg := xaction.XactReqMsg{
-		Kind: args.Kind,
-		Bck:  args.Bck,
-		Node: args.Node,
+		Kind:    args.Kind,
+		Bck:     args.Bck,
+		Node:    args.Node,
+		DryRun:  args.DryRun,
+		Restart: args.Restart,
 	}
 
 	if args.Buckets != nil {
@@ -198,6 +212,36 @@ func AbortXaction(baseParams BaseParams, args XactReqArgs) error {
 	})
 }
 
+// PauseXaction pauses a given (pausable) xaction, see xaction.XactsDtor.
+// The xaction stays paused - with its progress preserved - until resumed
+// (see ResumeXaction) or aborted.
+func PauseXaction(baseParams BaseParams, args XactReqArgs) error {
+	return pauseResume(baseParams, args, cmn.ActXactPause)
+}
+
+// ResumeXaction resumes a given paused xaction.
+func ResumeXaction(baseParams BaseParams, args XactReqArgs) error {
+	return pauseResume(baseParams, args, cmn.ActXactResume)
+}
+
+func pauseResume(baseParams BaseParams, args XactReqArgs, action string) error {
+	msg := cmn.ActionMsg{
+		Action: action,
+		Value: xaction.XactReqMsg{
+			ID:   args.ID,
+			Kind: args.Kind,
+			Bck:  args.Bck,
+		},
+	}
+	baseParams.Method = http.MethodPut
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathCluster.S,
+		Body:       cos.MustMarshal(msg),
+		Query:      cmn.AddBckToQuery(nil, args.Bck),
+	})
+}
+
 // GetXactionStatsByID gets all xaction stats for given id.
 func GetXactionStatsByID(baseParams BaseParams, id string) (xactStat NodesXactStat, err error) {
 	xactStats, err := QueryXactionStats(baseParams, XactReqArgs{ID: id})
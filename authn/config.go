@@ -51,9 +51,11 @@ type (
 		Secret       *string `json:"secret"`
 		ExpirePeriod *string `json:"expiration_time"`
 	}
-	// TokenList is a list of tokens pushed by authn
+	// TokenList is a list of tokens pushed by authn: revoked tokens and/or
+	// S3 access keys (see S3Key)
 	TokenList struct {
 		Tokens  []string `json:"tokens"`
+		S3Keys  []*S3Key `json:"s3_keys,omitempty"`
 		Version int64    `json:"version,string"`
 	}
 )
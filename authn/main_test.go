@@ -168,3 +168,40 @@ func TestToken(t *testing.T) {
 		t.Errorf("Invalid error(must be 'token expired'): %v", err)
 	}
 }
+
+func TestS3Creds(t *testing.T) {
+	secret := Conf.Server.Secret
+
+	driver := dbdriver.NewDBMock()
+	mgr, err := NewUserManager(driver)
+	tassert.CheckError(t, err)
+	createUsers(mgr, t)
+	defer deleteUsers(mgr, false, t)
+
+	creds, err := mgr.issueS3Creds(users[1], passs[1], nil)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(creds.AccessKey) == 20 && len(creds.SecretKey) == 40, "Invalid S3 credentials %+v", creds)
+	_, err = DecryptToken(creds.AccessKey, secret)
+	tassert.Errorf(t, err != nil, "Access key must not be a token")
+
+	keys, err := mgr.generateS3KeyList()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(keys) == 1 && keys[0].AccessKey == creds.AccessKey, "Invalid S3 key list %+v", keys)
+	tassert.Errorf(t, keys[0].SecretKey == creds.SecretKey, "Secret key mismatch for %s", users[1])
+	token := keys[0].Token
+	info, err := DecryptToken(token, secret)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, info.UserID == users[1], "Invalid user %s returned for access key of %s", info.UserID, users[1])
+
+	// revoking the access key revokes the token it maps to
+	tassert.CheckFatal(t, mgr.revokeToken(creds.AccessKey))
+	keys, err = mgr.generateS3KeyList()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(keys) == 0, "Revoked S3 key is still listed: %+v", keys)
+	revoked, err := mgr.generateRevokedTokenList()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(revoked) == 1 && revoked[0] == token, "Token of revoked S3 key is not revoked")
+
+	_, err = mgr.issueS3Creds(users[1], passs[0], nil)
+	tassert.Errorf(t, err != nil, "S3 credentials generated for incorrect user creds")
+}
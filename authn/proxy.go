@@ -50,22 +50,36 @@ func (m *UserManager) broadcast(method, path string, body []byte) {
 	wg.Wait()
 }
 
-// Send valid and non-expired revoked token list to a cluster.
+// Send valid and non-expired revoked token list and S3 access keys to a cluster.
 func (m *UserManager) syncTokenList(cluster *Cluster) {
 	tokenList, err := m.generateRevokedTokenList()
 	if err != nil {
 		glog.Errorf("failed to sync token list with %q: %v", cluster.ID, err)
 		return
 	}
-	if len(tokenList) == 0 {
+	if len(tokenList) > 0 {
+		m.syncCluster(cluster, http.MethodDelete, TokenList{Tokens: tokenList})
+	}
+	s3Keys, err := m.generateS3KeyList()
+	if err != nil {
+		glog.Errorf("failed to sync S3 access keys with %q: %v", cluster.ID, err)
 		return
 	}
-	body := cos.MustMarshal(TokenList{Tokens: tokenList})
+	if len(s3Keys) > 0 {
+		m.syncCluster(cluster, http.MethodPut, TokenList{S3Keys: s3Keys})
+	}
+}
+
+func (m *UserManager) syncCluster(cluster *Cluster, method string, tokenList TokenList) {
+	var (
+		err  error
+		body = cos.MustMarshal(tokenList)
+	)
 	for _, u := range cluster.URLs {
-		if err = m.proxyRequest(http.MethodDelete, u, cmn.Tokens, body); err == nil {
+		if err = m.proxyRequest(method, u, cmn.Tokens, body); err == nil {
 			break
 		}
-		err = fmt.Errorf("failed to sync revoked tokens with %q: %v", cluster.ID, err)
+		err = fmt.Errorf("failed to sync tokens with %q: %v", cluster.ID, err)
 	}
 	if err != nil {
 		glog.Error(err)
@@ -173,6 +173,8 @@ func (a *Server) httpUserPost(w http.ResponseWriter, r *http.Request) {
 		return
 	} else if len(apiItems) == 0 {
 		a.userAdd(w, r)
+	} else if len(apiItems) == 2 && apiItems[1] == cmn.S3Creds {
+		a.userS3Creds(w, r, apiItems[0])
 	} else {
 		a.userLogin(w, r)
 	}
@@ -325,6 +327,25 @@ func (a *Server) userLogin(w http.ResponseWriter, r *http.Request) {
 	writeBytes(w, []byte(repl), "auth")
 }
 
+// Generate S3 access and secret keys for a user if provided credentials are valid
+func (a *Server) userS3Creds(w http.ResponseWriter, r *http.Request, userID string) {
+	msg := &LoginMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	if msg.Password == "" {
+		cmn.WriteErrMsg(w, r, "Not authorized", http.StatusUnauthorized)
+		return
+	}
+	creds, err := a.users.issueS3Creds(userID, msg.Password, msg.ExpiresIn)
+	if err != nil {
+		glog.Errorf("Failed to generate S3 credentials: %v\n", err)
+		cmn.WriteErrMsg(w, r, "Not authorized", http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, creds, "issue S3 credentials")
+}
+
 // Borrowed from ais (modified cmn.InvalidHandler calls)
 func writeJSON(w http.ResponseWriter, val interface{}, tag string) {
 	w.Header().Set(cmn.HdrContentType, cmn.ContentJSON)
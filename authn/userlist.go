@@ -26,6 +26,7 @@ const (
 	rolesCollection    = "role"
 	revokedCollection  = "revoked"
 	clustersCollection = "cluster"
+	s3KeysCollection   = "s3key"
 
 	adminID   = "admin"
 	adminPass = "admin"
@@ -406,9 +407,34 @@ func (m *UserManager) issueToken(userID, pwd string, ttl *time.Duration) (string
 	return tokenString, err
 }
 
+// Generate S3 credentials for a user: random access key ID and secret key
+// mapped to a newly issued token, so S3 requests get the same permissions
+// and expiration time. The token itself is never returned to the user -
+// the mapping gets registered with all the clusters instead.
+func (m *UserManager) issueS3Creds(userID, pwd string, ttl *time.Duration) (*S3Creds, error) {
+	token, err := m.issueToken(userID, pwd, ttl)
+	if err != nil {
+		return nil, err
+	}
+	creds := newS3Creds()
+	key := &S3Key{AccessKey: creds.AccessKey, SecretKey: creds.SecretKey, Token: token}
+	if err := m.db.Set(s3KeysCollection, key.AccessKey, key); err != nil {
+		return nil, err
+	}
+	// (synchronously, so that the keys can be used right away)
+	m.broadcast(http.MethodPut, cmn.Tokens, cos.MustMarshal(TokenList{S3Keys: []*S3Key{key}}))
+	return creds, nil
+}
+
 // Delete existing token, a.k.a log out
 // If the token was removed successfully then it sends the proxy a new valid token list
+// NOTE: S3 access key revokes the token it maps to (see issueS3Creds)
 func (m *UserManager) revokeToken(token string) error {
+	key := &S3Key{}
+	if err := m.db.Get(s3KeysCollection, token, key); err == nil {
+		m.db.Delete(s3KeysCollection, token)
+		token = key.Token
+	}
 	err := m.db.Set(revokedCollection, token, "!")
 	if err != nil {
 		return err
@@ -451,6 +477,36 @@ func (m *UserManager) generateRevokedTokenList() ([]string, error) {
 	return revokeList, nil
 }
 
+// Create a list of S3 keys that map to valid tokens (neither expired nor revoked).
+// Obsolete keys are removed from the database.
+func (m *UserManager) generateS3KeyList() ([]*S3Key, error) {
+	ids, err := m.db.List(s3KeysCollection, "")
+	if err != nil {
+		return nil, err
+	}
+	var (
+		now    = time.Now()
+		keys   = make([]*S3Key, 0, len(ids))
+		secret = Conf.Secret()
+	)
+	for _, id := range ids {
+		key := &S3Key{}
+		if err := m.db.Get(s3KeysCollection, id, key); err != nil {
+			continue
+		}
+		token, err := DecryptToken(key.Token, secret)
+		if err == nil && token.Expires.After(now) {
+			if err = m.db.Get(revokedCollection, key.Token, new(string)); err != nil {
+				keys = append(keys, key)
+				continue
+			}
+		}
+		glog.Infof("removing obsolete S3 access key %s", id)
+		m.db.Delete(s3KeysCollection, id)
+	}
+	return keys, nil
+}
+
 func (m *UserManager) lookupUser(userID string) (*User, error) {
 	uInfo := &User{}
 	err := m.db.Get(usersCollection, userID, uInfo)
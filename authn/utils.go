@@ -5,6 +5,7 @@
 package authn
 
 import (
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"time"
@@ -16,6 +17,11 @@ import (
 	"github.com/dgrijalva/jwt-go"
 )
 
+const (
+	s3AccessKeyChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"                                 // 32
+	s3SecretKeyChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/" // 64
+)
+
 const (
 	AdminRole        = "Admin"
 	ClusterOwnerRole = "ClusterOwner"
@@ -70,6 +76,18 @@ type (
 	TokenMsg struct {
 		Token string `json:"token"`
 	}
+	// S3 (SigV4) credentials: random access key ID and secret key
+	S3Creds struct {
+		AccessKey string `json:"access_key"`
+		SecretKey string `json:"secret_key"`
+	}
+	// S3 access key as registered with clusters: maps the access key ID to
+	// the secret key and the token that determines permissions and expiration
+	S3Key struct {
+		AccessKey string `json:"access_key"`
+		SecretKey string `json:"secret_key"`
+		Token     string `json:"token"`
+	}
 )
 
 /////////////////////
@@ -204,6 +222,23 @@ func MergeClusterACLs(oldACLs, newACLs []*Cluster) []*Cluster {
 	return oldACLs
 }
 
+// Generates random S3 access key ID (20 characters, as in AWS)
+// and secret key (40 characters)
+func newS3Creds() *S3Creds {
+	return &S3Creds{AccessKey: randString(20, s3AccessKeyChars), SecretKey: randString(40, s3SecretKeyChars)}
+}
+
+// NOTE: len(chars) must divide 256 (no modulo bias)
+func randString(n int, chars string) string {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	cos.AssertNoErr(err)
+	for i := range b {
+		b[i] = chars[int(b[i])%len(chars)]
+	}
+	return string(b)
+}
+
 func DecryptToken(tokenStr, secret string) (*Token, error) {
 	token, err := jwt.Parse(tokenStr, func(tk *jwt.Token) (interface{}, error) {
 		if _, ok := tk.Method.(*jwt.SigningMethodHMAC); !ok {
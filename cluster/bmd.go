@@ -155,7 +155,7 @@ func (m *BMD) Add(bck *Bck) {
 
 func (m *BMD) IsECUsed() (yes bool) {
 	m.Range(nil, nil, func(bck *Bck) (stop bool) {
-		if bck.Props.EC.Enabled {
+		if bck.Props.ECUsed() {
 			yes, stop = true, true
 		}
 		return
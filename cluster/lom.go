@@ -48,6 +48,9 @@ type (
 		atimefs uint64 // high bit is reserved for `dirty`
 		bckID   uint64 // see ais/bucketmeta
 		copies  fs.MPI // ditto
+		// number of times the object was accessed (GET) - maintained only
+		// if required by the bucket's LRU policy (see cmn.LRUConf.CountsAccess)
+		accessCnt uint64
 	}
 	LOM struct {
 		md          lmeta             // local persistent metadata
@@ -188,7 +191,14 @@ func (lom *LOM) Atime() time.Time           { return time.Unix(0, lom.md.Atime)
 func (lom *LOM) AtimeUnix() int64           { return lom.md.Atime }
 func (lom *LOM) SetAtimeUnix(tu int64)      { lom.md.Atime = tu }
 func (lom *LOM) SetCustom(md cos.SimpleKVs) { lom.md.AddMD = md }
-func (lom *LOM) Custom() cos.SimpleKVs      { return lom.md.AddMD }
+func (lom *LOM) AccessCnt() uint64          { return lom.md.accessCnt }
+
+// Count an access; the count is persisted lazily - along with atime (see lom_cache_hk.go).
+func (lom *LOM) IncAccessCnt() {
+	lom.md.accessCnt++
+	lom.md.makeDirty()
+}
+func (lom *LOM) Custom() cos.SimpleKVs { return lom.md.AddMD }
 
 func (lom *LOM) EqCksum(cksum *cos.Cksum) bool { return lom.md.Cksum.Equal(cksum) }
 
@@ -207,16 +217,36 @@ func AllocLomFromHdr(hdr *transport.ObjHdr) (lom *LOM, err error) {
 	return
 }
 
-func (lom *LOM) ECEnabled() bool { return lom.Bprops().EC.Enabled }
+func (lom *LOM) ECEnabled() bool { return lom.ECConf().Enabled }
 func (lom *LOM) IsHRW() bool     { return lom.HrwFQN == lom.FQN } // subj to resilvering
 
 func (lom *LOM) Bck() *Bck                { return lom.bck }
 func (lom *LOM) Bprops() *cmn.BucketProps { return lom.bck.Props }
 
-func (lom *LOM) MirrorConf() *cmn.MirrorConf  { return &lom.Bprops().Mirror }
 func (lom *LOM) CksumConf() *cmn.CksumConf    { return lom.bck.CksumConf() }
 func (lom *LOM) VersionConf() cmn.VersionConf { return lom.bck.VersionConf() }
 
+// Returns the storage class of the object, if any (see cmn.StorageClass);
+// size-based classes require the object to be loaded.
+func (lom *LOM) StorageClass() *cmn.StorageClass {
+	return lom.Bprops().StorageClass.Classes.Match(lom.ObjName, lom.SizeBytes(true))
+}
+
+// mirroring and erasure coding as per the object's storage class (if any) or the bucket
+func (lom *LOM) MirrorConf() *cmn.MirrorConf {
+	if sc := lom.StorageClass(); sc != nil {
+		return sc.MirrorConf(&lom.Bprops().Mirror)
+	}
+	return &lom.Bprops().Mirror
+}
+
+func (lom *LOM) ECConf() *cmn.ECConf {
+	if sc := lom.StorageClass(); sc != nil {
+		return sc.ECConf(&lom.Bprops().EC)
+	}
+	return &lom.Bprops().EC
+}
+
 // as fs.PartsFQN
 func (lom *LOM) ObjectName() string           { return lom.ObjName }
 func (lom *LOM) Bucket() cmn.Bck              { return lom.bck.Bucket() } // as fs.PartsFQN
@@ -1039,7 +1069,10 @@ func (lom *LOM) Remove() (err error) {
 		return exclusive || rc > 0
 	})
 	lom.Uncache(true /*delDirty*/)
-	err = cos.RemoveFile(lom.FQN)
+	size := lom.TrackedSize()
+	if err = cos.RemoveFile(lom.FQN); err == nil {
+		lom.trackRemoved(size)
+	}
 	for copyFQN := range lom.md.copies {
 		if err := cos.RemoveFile(copyFQN); err != nil {
 			glog.Error(err)
@@ -0,0 +1,182 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Bucket usage: for buckets with quota (see cmn.QuotaConf), targets keep track of
+// the local number and total size of objects. The tracking of a given bucket
+// starts upon the first BckUsage() call that walks the bucket to establish the
+// baseline (concurrent callers wait for the same walk); from there on, the usage
+// is updated incrementally as objects get stored and removed - until the bucket
+// gets destroyed or its quota removed (see ForgetBckUsage).
+//
+// Only main replicas of the objects that "belong" to this target (HRW-wise) are
+// counted - previous versions, deleted (soft-deleted) objects, copies, and EC
+// slices are not. Note that the numbers are approximate: objects stored while
+// the baseline is being established may be counted twice or not at all.
+
+type (
+	bckUsage struct {
+		size atomic.Int64
+		objs atomic.Int64
+	}
+	// baseline walk in progress
+	usageWalk struct {
+		wg  sync.WaitGroup
+		err error
+	}
+)
+
+var (
+	usages sync.Map // BID => *bckUsage
+	walks  struct {
+		mu sync.Mutex
+		m  map[uint64]*usageWalk // by BID
+	}
+)
+
+// Returns the local usage of a bucket with quota.
+func BckUsage(bck *Bck) (cmn.QuotaUsage, error) {
+	u, err := loadUsage(bck.Props.BID, func(u *bckUsage) error { return u.walk(bck) })
+	if err != nil {
+		return cmn.QuotaUsage{}, err
+	}
+	return cmn.QuotaUsage{Size: u.size.Load(), Objs: u.objs.Load()}, nil
+}
+
+// Stop tracking the usage of a destroyed bucket (or a bucket that no longer
+// has quota).
+func ForgetBckUsage(bid uint64) { usages.Delete(bid) }
+
+// returns the tracked usage or establishes the baseline - one walk at a time
+func loadUsage(bid uint64, walk func(*bckUsage) error) (*bckUsage, error) {
+	if v, ok := usages.Load(bid); ok {
+		return v.(*bckUsage), nil
+	}
+	walks.mu.Lock()
+	if v, ok := usages.Load(bid); ok {
+		walks.mu.Unlock()
+		return v.(*bckUsage), nil
+	}
+	if w, ok := walks.m[bid]; ok {
+		walks.mu.Unlock()
+		w.wg.Wait()
+		if w.err != nil {
+			return nil, w.err
+		}
+		return loadUsage(bid, walk)
+	}
+	if walks.m == nil {
+		walks.m = make(map[uint64]*usageWalk, 4)
+	}
+	w := &usageWalk{}
+	w.wg.Add(1)
+	walks.m[bid] = w
+	walks.mu.Unlock()
+
+	u := &bckUsage{}
+	if w.err = walk(u); w.err == nil {
+		usages.Store(bid, u)
+	}
+	walks.mu.Lock()
+	delete(walks.m, bid)
+	walks.mu.Unlock()
+	w.wg.Done()
+	if w.err != nil {
+		return nil, w.err
+	}
+	return u, nil
+}
+
+func (u *bckUsage) walk(bck *Bck) error {
+	var (
+		availablePaths, _ = fs.Get()
+		smap              = T.Sowner().Get()
+		wg                = &sync.WaitGroup{}
+		errCh             = make(chan error, len(availablePaths))
+	)
+	cb := func(fqn string, de fs.DirEntry) error {
+		if de.IsDir() {
+			return nil
+		}
+		lom := &LOM{FQN: fqn}
+		if err := lom.Init(bck.Bck); err != nil || !lom.IsHRW() {
+			return nil
+		}
+		if tsi, err := HrwTarget(lom.Uname(), smap); err != nil || tsi.ID() != T.SID() {
+			return nil
+		}
+		if finfo, err := os.Stat(fqn); err == nil {
+			u.size.Add(finfo.Size())
+			u.objs.Inc()
+		}
+		return nil
+	}
+	for _, mi := range availablePaths {
+		wg.Add(1)
+		go func(mi *fs.MountpathInfo) {
+			defer wg.Done()
+			opts := &fs.Options{Mpath: mi, Bck: bck.Bck, CTs: []string{fs.ObjectType}, Callback: cb}
+			if err := fs.Walk(opts); err != nil && !os.IsNotExist(err) {
+				errCh <- err
+			}
+		}(mi)
+	}
+	wg.Wait()
+	close(errCh)
+	return <-errCh
+}
+
+func (lom *LOM) usage() *bckUsage {
+	if !lom.Bprops().Quota.IsSet() {
+		return nil
+	}
+	if v, ok := usages.Load(lom.Bprops().BID); ok {
+		return v.(*bckUsage)
+	}
+	return nil
+}
+
+// Returns the size of the stored object if the bucket's usage is being tracked,
+// -1 otherwise (or if the object does not exist).
+func (lom *LOM) TrackedSize() int64 {
+	if lom.usage() == nil {
+		return -1
+	}
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		return -1
+	}
+	return finfo.Size()
+}
+
+// To be called upon storing the object that replaces the one of `prevSize`
+// (see TrackedSize).
+func (lom *LOM) TrackStored(prevSize int64) {
+	u := lom.usage()
+	if u == nil {
+		return
+	}
+	if prevSize < 0 {
+		u.objs.Inc()
+		prevSize = 0
+	}
+	u.size.Add(lom.SizeBytes(true) - prevSize)
+}
+
+func (lom *LOM) trackRemoved(size int64) {
+	if u := lom.usage(); u != nil && size >= 0 {
+		u.objs.Dec()
+		u.size.Sub(size)
+	}
+}
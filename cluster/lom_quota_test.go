@@ -0,0 +1,104 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestTrackStored(t *testing.T) {
+	var (
+		bck = NewBck("quota", cmn.ProviderAIS, cmn.NsGlobal,
+			&cmn.BucketProps{BID: 0xa1, Quota: cmn.QuotaConf{MaxObjs: 10}})
+		lom = &LOM{FQN: filepath.Join(t.TempDir(), "obj"), bck: bck}
+	)
+	// not tracked yet
+	tassert.Errorf(t, lom.TrackedSize() == -1, "expected untracked")
+	u := &bckUsage{}
+	usages.Store(bck.Props.BID, u)
+	defer ForgetBckUsage(bck.Props.BID)
+
+	put := func(size int) {
+		prevSize := lom.TrackedSize()
+		tassert.CheckFatal(t, os.WriteFile(lom.FQN, make([]byte, size), cos.PermRWR))
+		lom.SetSize(int64(size))
+		lom.TrackStored(prevSize)
+	}
+	check := func(size, objs int64) {
+		tassert.Errorf(t, u.size.Load() == size && u.objs.Load() == objs, "expected (%d, %d), got (%d, %d)",
+			size, objs, u.size.Load(), u.objs.Load())
+	}
+	put(100) // new
+	check(100, 1)
+	put(40) // overwrite
+	check(40, 1)
+
+	size := lom.TrackedSize()
+	tassert.CheckFatal(t, os.Remove(lom.FQN))
+	lom.trackRemoved(size)
+	check(0, 0)
+
+	// no longer tracked
+	ForgetBckUsage(bck.Props.BID)
+	tassert.Errorf(t, lom.usage() == nil && lom.TrackedSize() == -1, "expected untracked")
+}
+
+func TestLoadUsage(t *testing.T) {
+	const (
+		bid     = 0xa2
+		callers = 16
+	)
+	defer ForgetBckUsage(bid)
+
+	// failed walk is not cached
+	errWalk := errors.New("walk failed")
+	_, err := loadUsage(bid, func(*bckUsage) error { return errWalk })
+	tassert.Fatalf(t, err == errWalk, "expected %v, got %v", errWalk, err)
+
+	// concurrent callers share the same walk
+	var (
+		walks   atomic.Int32
+		release = make(chan struct{})
+		wg      = &sync.WaitGroup{}
+		results = make([]*bckUsage, callers)
+	)
+	walk := func(u *bckUsage) error {
+		walks.Inc()
+		<-release
+		u.objs.Store(7)
+		return nil
+	}
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			u, err := loadUsage(bid, walk)
+			tassert.CheckError(t, err)
+			results[i] = u
+		}(i)
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	tassert.Errorf(t, walks.Load() == 1, "expected a single walk, got %d", walks.Load())
+	for _, u := range results {
+		tassert.Fatalf(t, u != nil && u == results[0] && u.objs.Load() == 7, "expected the same usage")
+	}
+
+	// tracked from now on
+	u, err := loadUsage(bid, walk)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, u == results[0] && walks.Load() == 1, "expected tracked usage")
+}
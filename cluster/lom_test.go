@@ -30,6 +30,8 @@ var _ = Describe("LOM", func() {
 		bucketLocalA = "LOM_TEST_Local_A"
 		bucketLocalB = "LOM_TEST_Local_B"
 		bucketLocalC = "LOM_TEST_Local_C"
+		bucketLocalV = "LOM_TEST_Local_V"
+		bucketLocalT = "LOM_TEST_Local_T"
 
 		bucketCloudA = "LOM_TEST_Cloud_A"
 		bucketCloudB = "LOM_TEST_Cloud_B"
@@ -40,6 +42,8 @@ var _ = Describe("LOM", func() {
 	var (
 		localBckA = cmn.Bck{Name: bucketLocalA, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
 		localBckB = cmn.Bck{Name: bucketLocalB, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+		localBckV = cmn.Bck{Name: bucketLocalV, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+		localBckT = cmn.Bck{Name: bucketLocalT, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
 		cloudBckA = cmn.Bck{Name: bucketCloudA, Provider: cmn.ProviderAmazon, Ns: cmn.NsGlobal}
 	)
 
@@ -69,6 +73,7 @@ var _ = Describe("LOM", func() {
 
 	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
 	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.ObjVersionType, &fs.ObjVersionContentResolver{})
 
 	var (
 		bmd = cluster.NewBaseBownerMock(
@@ -93,6 +98,14 @@ var _ = Describe("LOM", func() {
 			cluster.NewBck(bucketCloudA, cmn.ProviderAmazon, cmn.NsGlobal, &cmn.BucketProps{BID: 5}),
 			cluster.NewBck(bucketCloudB, cmn.ProviderAmazon, cmn.NsGlobal, &cmn.BucketProps{BID: 6}),
 			cluster.NewBck(sameBucketName, cmn.ProviderAmazon, cmn.NsGlobal, &cmn.BucketProps{BID: 7}),
+			cluster.NewBck(
+				bucketLocalV, cmn.ProviderAIS, cmn.NsGlobal,
+				&cmn.BucketProps{Versioning: cmn.VersionConf{Enabled: true, KeepVersions: 2}, BID: 8},
+			),
+			cluster.NewBck(
+				bucketLocalT, cmn.ProviderAIS, cmn.NsGlobal,
+				&cmn.BucketProps{Trash: cmn.TrashConf{Retention: cos.Duration(time.Hour)}, BID: 9},
+			),
 		)
 		tMock cluster.Target
 	)
@@ -560,6 +573,95 @@ var _ = Describe("LOM", func() {
 			})
 		})
 
+		Describe("previous versions", func() {
+			testObject := "foldr/test-obj.ext"
+			localFQN := mis[0].MakePathFQN(localBckV, fs.ObjectType, testObject)
+
+			putVersion := func(ver int) *cluster.LOM {
+				lom := filePut(localFQN, 10)
+				lom.SetVersion(strconv.Itoa(ver))
+				Expect(lom.Persist()).NotTo(HaveOccurred())
+				return lom
+			}
+
+			It("should retain up to keep_versions previous versions", func() {
+				lom := putVersion(1)
+				Expect(lom.KeepsHistory()).To(BeTrue())
+				for ver := 2; ver <= 4; ver++ {
+					verFQN, err := lom.ArchiveCurrent()
+					Expect(err).NotTo(HaveOccurred())
+					lom = putVersion(ver)
+					lom.CommitArchived(verFQN)
+				}
+
+				vlist, err := lom.Versions()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vlist).To(HaveLen(2))
+				Expect(vlist[0].Version()).To(Equal("3"))
+				Expect(vlist[1].Version()).To(Equal("2"))
+				for _, vlom := range vlist {
+					Expect(vlom.SizeBytes()).To(BeEquivalentTo(10))
+					cluster.FreeLOM(vlom)
+				}
+
+				vlom, err := lom.LoadVersion("2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vlom.FQN).To(Equal(lom.VersionFQN("2")))
+				cluster.FreeLOM(vlom)
+				_, err = lom.LoadVersion("1")
+				Expect(cmn.IsObjNotExist(err)).To(BeTrue())
+
+				Expect(lom.DelAllVersions()).NotTo(HaveOccurred())
+				vlist, err = lom.Versions()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vlist).To(BeEmpty())
+			})
+
+			It("should keep the current object if not replaced", func() {
+				lom := putVersion(1)
+				verFQN, err := lom.ArchiveCurrent()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(verFQN).To(Equal(lom.VersionFQN("1")))
+				lom.AbortArchived(verFQN)
+
+				vlist, err := lom.Versions()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vlist).To(BeEmpty())
+				Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+				Expect(lom.Version()).To(Equal("1"))
+				Expect(lom.SizeBytes()).To(BeEquivalentTo(10))
+			})
+		})
+
+		Describe("soft delete", func() {
+			testObject := "foldr/test-obj.ext"
+			localFQN := mis[0].MakePathFQN(localBckT, fs.ObjectType, testObject)
+
+			It("should move deleted object to trash and restore it", func() {
+				lom := filePut(localFQN, 10)
+				Expect(lom.KeepsDeleted()).To(BeTrue())
+				Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+				ver := lom.Version()
+
+				Expect(lom.MoveToDeleted()).NotTo(HaveOccurred())
+				Expect(lom.FQN).NotTo(BeAnExistingFile())
+				Expect(mis[0].MakePathDeletedObj(localBckT, testObject)).To(BeAnExistingFile())
+
+				lom = NewBasicLom(localFQN)
+				Expect(cmn.IsObjNotExist(lom.Load(false, false))).To(BeTrue())
+				Expect(lom.Undelete()).NotTo(HaveOccurred())
+				Expect(mis[0].MakePathDeletedObj(localBckT, testObject)).NotTo(BeAnExistingFile())
+
+				lom = NewBasicLom(localFQN)
+				Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+				Expect(lom.SizeBytes()).To(BeEquivalentTo(10))
+				Expect(lom.Version()).To(Equal(ver))
+
+				// nothing else to restore
+				Expect(cmn.IsObjNotExist(lom.Undelete())).To(BeTrue())
+			})
+		})
+
 		Describe("CustomMD", func() {
 			testObject := "foldr/test-obj.ext"
 			localFQN := mis[0].MakePathFQN(localBckA, fs.ObjectType, testObject)
@@ -0,0 +1,94 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Soft delete: with trash.retention configured, deleting an object in ais
+// bucket moves the object (its main replica) to the $deleted namespace of its
+// mountpath (see fs/trash.go), from where it can be restored (Undelete) until
+// it expires.
+//
+// All the methods below require the caller to lock the object.
+
+func (lom *LOM) KeepsDeleted() bool {
+	return lom.Bck().IsAIS() && lom.Bprops().Trash.Retention > 0
+}
+
+// Move the (loaded) object to trash and remove its copies, if any.
+func (lom *LOM) MoveToDeleted() (err error) {
+	dfqn := lom.mpathInfo.MakePathDeletedObj(lom.Bucket(), lom.ObjName)
+	lom.Uncache(true /*delDirty*/)
+	size := lom.TrackedSize()
+	if err = cos.Rename(lom.FQN, dfqn); err != nil {
+		return
+	}
+	lom.trackRemoved(size)
+	now := time.Now()
+	if err := os.Chtimes(dfqn, now, now); err != nil {
+		glog.Errorf("%s: %v", lom, err)
+	}
+	for copyFQN := range lom.md.copies {
+		if copyFQN == lom.FQN {
+			continue
+		}
+		if err := cos.RemoveFile(copyFQN); err != nil {
+			glog.Error(err)
+		}
+	}
+	lom.md.bckID = 0 // (as in Remove: the object is gone - mark LOM as not loaded)
+	return
+}
+
+// Restore the most recently deleted object of this name; the object must not exist.
+func (lom *LOM) Undelete() (err error) {
+	var (
+		dfqn              string
+		dmi               *fs.MountpathInfo
+		deleted           time.Time
+		availablePaths, _ = fs.Get()
+	)
+	for _, mi := range availablePaths {
+		fqn := mi.MakePathDeletedObj(lom.Bucket(), lom.ObjName)
+		finfo, err := os.Stat(fqn)
+		if err != nil {
+			continue
+		}
+		if dfqn == "" || finfo.ModTime().After(deleted) {
+			dfqn, dmi, deleted = fqn, mi, finfo.ModTime()
+		}
+	}
+	if dfqn == "" {
+		return cmn.NewNotFoundError("deleted object %s", lom)
+	}
+	if dmi == lom.mpathInfo {
+		err = cos.Rename(dfqn, lom.FQN)
+	} else {
+		buf, slab := T.MMSA().Alloc()
+		err = MoveWithMD(dfqn, lom.FQN, buf)
+		slab.Free(buf)
+	}
+	if err != nil {
+		return fmt.Errorf(cmn.FmtErrFailed, T.Snode(), "restore", lom, err)
+	}
+	if _, err = lom.lmfs(true); err != nil {
+		return
+	}
+	lom.md.copies = nil
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	if err = lom.Persist(); err == nil {
+		lom.TrackStored(-1)
+	}
+	return
+}
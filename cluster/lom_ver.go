@@ -0,0 +1,261 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Previous (non-current) versions of ais objects are retained as fs.ObjVersionType
+// content next to the object itself: same mountpath, one directory per object
+// (that contains only the object's versions, named by version - see
+// fs.ObjVersionContentResolver). Each version file keeps its own lmeta (xattr)
+// while its mtime is the time the version became non-current. Retention is
+// configured via versioning.keep_versions and versioning.keep_ttl.
+//
+// All the methods below require the caller to lock the (current) object.
+
+func (lom *LOM) KeepsHistory() bool {
+	return lom.Bck().IsAIS() && lom.VersionConf().KeepsHistory()
+}
+
+func (lom *LOM) VersionFQN(ver string) string {
+	return fs.CSM.GenContentFQN(lom, fs.ObjVersionType, ver)
+}
+
+// the directory that contains all previous versions of the object
+func (lom *LOM) versionDir() string { return lom.VersionFQN("") }
+
+// Retain the current object (as stored on disk) as a previous version. The
+// object itself stays in place until the caller replaces it with the new
+// content and then either commits (CommitArchived) or, if the replacement
+// fails, rolls back (AbortArchived). Returns empty FQN if there's nothing
+// to retain.
+func (lom *LOM) ArchiveCurrent() (verFQN string, err error) {
+	md, err := lom.lmfs(false)
+	if err != nil {
+		if os.IsNotExist(err) || cos.IsErrXattrNotFound(err) {
+			err = nil
+		}
+		return
+	}
+	if md.Ver == "" {
+		return
+	}
+	verFQN = lom.VersionFQN(md.Ver)
+	if err = linkVersion(lom.FQN, verFQN); err != nil {
+		return "", fmt.Errorf(cmn.FmtErrFailed, T.Snode(), "archive", lom, err)
+	}
+	return
+}
+
+// hard link (or, failing that, copy) - the current object is then replaced
+// via rename (see cos.Rename) which leaves the linked content intact
+func linkVersion(fqn, verFQN string) error {
+	if err := cos.CreateDir(filepath.Dir(verFQN)); err != nil {
+		return err
+	}
+	if err := cos.RemoveFile(verFQN); err != nil {
+		return err
+	}
+	if err := os.Link(fqn, verFQN); err != nil {
+		return copyWithMD(fqn, verFQN, nil)
+	}
+	return nil
+}
+
+// The new content is in place: the archived version becomes non-current as of now.
+func (lom *LOM) CommitArchived(verFQN string) {
+	now := time.Now()
+	if err := os.Chtimes(verFQN, now, now); err != nil {
+		glog.Errorf("%s: %v", lom, err)
+	}
+	lom.pruneVersions()
+}
+
+// Failed to replace the object: the archived version is still current.
+func (lom *LOM) AbortArchived(verFQN string) {
+	if err := cos.RemoveFile(verFQN); err != nil {
+		glog.Errorf("%s: %v", lom, err)
+	}
+	lom.rmVersionDir()
+}
+
+// Returns previous versions of the object, newest first; the caller must free
+// all of them (see FreeLOM).
+func (lom *LOM) Versions() (vlist []*LOM, err error) {
+	dir := lom.versionDir()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	for _, entry := range entries {
+		ver := entry.Name()
+		if entry.IsDir() {
+			continue // versions of another object (that has this object's name as prefix)
+		}
+		if _, err := strconv.ParseUint(ver, 10, 64); err != nil {
+			continue
+		}
+		vlom, err := lom.loadVersion(filepath.Join(dir, ver))
+		if err != nil {
+			glog.Errorf("%s: failed to load version %s: %v", lom, ver, err)
+			continue
+		}
+		vlist = append(vlist, vlom)
+	}
+	sort.Slice(vlist, func(i, j int) bool {
+		vi, _ := strconv.ParseUint(vlist[i].md.Ver, 10, 64)
+		vj, _ := strconv.ParseUint(vlist[j].md.Ver, 10, 64)
+		return vi > vj
+	})
+	return
+}
+
+// Returns a given previous version of the object; the caller must free it.
+func (lom *LOM) LoadVersion(ver string) (*LOM, error) {
+	vlom, err := lom.loadVersion(lom.VersionFQN(ver))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, cmn.NewNotFoundError("%s version %q", lom, ver)
+		}
+		return nil, err
+	}
+	return vlom, nil
+}
+
+func (lom *LOM) loadVersion(fqn string) (*LOM, error) {
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		return nil, err
+	}
+	vlom := lom.Clone(fqn)
+	vlom.md = lmeta{uname: lom.md.uname, bckID: lom.Bprops().BID}
+	if _, err := vlom.lmfs(true); err != nil {
+		FreeLOM(vlom)
+		return nil, err
+	}
+	vlom.md.copies = nil
+	vlom.md.Atime = finfo.ModTime().UnixNano() // non-current since
+	return vlom, nil
+}
+
+// Remove versions that exceed the configured number or age.
+func (lom *LOM) pruneVersions() {
+	vlist, err := lom.Versions()
+	if err != nil {
+		glog.Errorf("%s: %v", lom, err)
+		return
+	}
+	var (
+		conf = lom.VersionConf()
+		now  = time.Now()
+	)
+	for i, vlom := range vlist {
+		expired := conf.KeepVersions > 0 && i >= conf.KeepVersions
+		if conf.KeepTTL > 0 && now.Sub(vlom.Atime()) > conf.KeepTTL.D() {
+			expired = true
+		}
+		if expired {
+			if err := cos.RemoveFile(vlom.FQN); err != nil {
+				glog.Errorf("%s: %v", lom, err)
+			}
+		}
+		FreeLOM(vlom)
+	}
+	lom.rmVersionDir()
+}
+
+func (lom *LOM) DelAllVersions() (err error) {
+	vlist, err := lom.Versions()
+	for _, vlom := range vlist {
+		if errRm := cos.RemoveFile(vlom.FQN); errRm != nil {
+			err = errRm
+		}
+		FreeLOM(vlom)
+	}
+	lom.rmVersionDir()
+	return
+}
+
+// remove the directory unless it still contains versions (or other objects' versions)
+func (lom *LOM) rmVersionDir() { os.Remove(lom.versionDir()) }
+
+// Store a previous version of the object received from another target;
+// the caller must fill in the version's attributes (lom.CopyAttrs and such).
+func (lom *LOM) SaveVersion(r io.Reader, mtime time.Time, buf []byte) (err error) {
+	var (
+		fh      *os.File
+		workFQN = fs.CSM.GenContentFQN(lom, fs.WorkfileType, fs.WorkfileRemote)
+		verFQN  = lom.VersionFQN(lom.md.Ver)
+	)
+	if fh, err = lom.CreateFile(workFQN); err != nil {
+		return
+	}
+	_, err = io.CopyBuffer(fh, r, buf)
+	cos.Close(fh)
+	if err == nil {
+		err = cos.Rename(workFQN, verFQN)
+	}
+	if err != nil {
+		if errRm := cos.RemoveFile(workFQN); errRm != nil {
+			glog.Errorf(fmtNestedErr, errRm)
+		}
+		return
+	}
+	lom.md.copies = nil
+	mdbuf, mm := lom.marshal()
+	err = fs.SetXattr(verFQN, XattrLOM, mdbuf)
+	mm.Free(mdbuf)
+	if err != nil {
+		cos.RemoveFile(verFQN)
+		return
+	}
+	return os.Chtimes(verFQN, mtime, mtime)
+}
+
+// Move a file (e.g., previous version) across mountpaths along with its metadata and mtime.
+func MoveWithMD(srcFQN, dstFQN string, buf []byte) error {
+	if err := copyWithMD(srcFQN, dstFQN, buf); err != nil {
+		return err
+	}
+	return cos.RemoveFile(srcFQN)
+}
+
+func copyWithMD(srcFQN, dstFQN string, buf []byte) error {
+	finfo, err := os.Stat(srcFQN)
+	if err != nil {
+		return err
+	}
+	md, err := fs.GetXattr(srcFQN, XattrLOM)
+	if err != nil {
+		return err
+	}
+	if _, _, err = cos.CopyFile(srcFQN, dstFQN, buf, cos.ChecksumNone); err != nil {
+		return err
+	}
+	if err = fs.SetXattr(dstFQN, XattrLOM, md); err == nil {
+		mtime := finfo.ModTime()
+		err = os.Chtimes(dstFQN, mtime, mtime)
+	}
+	if err != nil {
+		cos.RemoveFile(dstFQN)
+	}
+	return err
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -55,6 +56,7 @@ const (
 	lomObjSize
 	lomObjCopies
 	lomCustomMD
+	lomAccessCnt
 )
 
 // packing format separators
@@ -82,6 +84,10 @@ const (
 	MD5ObjMD     = cos.ChecksumMD5
 
 	OrigURLObjMD = "orig_url"
+
+	// web objects (see downloader): HTTP validators
+	ETagObjMD         = "etag"
+	LastModifiedObjMD = "last_modified"
 )
 
 // NOTE: used in tests, ignores `dirty`
@@ -325,6 +331,10 @@ func (md *lmeta) unmarshal(buf []byte) (err error) {
 			for i := 0; i < len(entries); i += 2 {
 				md.AddMD[entries[i]] = entries[i+1]
 			}
+		case lomAccessCnt:
+			if md.accessCnt, err = strconv.ParseUint(val, 10, 64); err != nil {
+				return errors.New(invalid + " #9")
+			}
 		default:
 			return errors.New(invalid + " #6")
 		}
@@ -365,6 +375,10 @@ func (md *lmeta) marshal(mm *memsys.MMSA, mdSize int64) (buf []byte) {
 		buf = _marshRecord(mm, buf, lomCustomMD, "", false)
 		buf = _marshCustomMD(mm, buf, md.AddMD)
 	}
+	if md.accessCnt > 0 {
+		buf = mm.Append(buf, recordSepa)
+		buf = _marshRecord(mm, buf, lomAccessCnt, strconv.FormatUint(md.accessCnt, 10), false)
+	}
 
 	// checksum, prepend, and return
 	buf[0] = cmn.MetaverLOM
@@ -36,6 +36,7 @@ type (
 		String() string
 		Finished() bool
 		Aborted() bool
+		Paused() bool
 		AbortedAfter(time.Duration) bool
 		Quiesce(time.Duration, QuiCB) QuiRes
 		ChanAbort() <-chan struct{}
@@ -46,6 +47,8 @@ type (
 		Renew()
 		Finish(err error)
 		Abort()
+		Pause() error
+		Resume()
 		AddNotif(n Notif)
 
 		BytesAdd(cnt int64) int64
@@ -62,6 +65,7 @@ type (
 		ObjCount() int64
 		BytesCount() int64
 		Aborted() bool
+		Paused() bool
 		Running() bool
 		Finished() bool
 	}
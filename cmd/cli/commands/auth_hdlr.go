@@ -33,6 +33,7 @@ const (
 	authnTokenPath = "AUTHN_TOKEN_FILE"
 
 	flagsAuthUserLogin   = "user_login"
+	flagsAuthS3Creds     = "s3_creds"
 	flagsAuthUserShow    = "user_show"
 	flagsAuthRoleAdd     = "role_add"
 	flagsAuthRevokeToken = "revoke_token"
@@ -45,6 +46,7 @@ const authnUnreachable = `AuthN unreachable at %s. You may need to update AIS CL
 var (
 	authFlags = map[string][]cli.Flag{
 		flagsAuthUserLogin:   {tokenFileFlag, passwordFlag, expireFlag},
+		flagsAuthS3Creds:     {passwordFlag, expireFlag},
 		subcmdAuthUser:       {passwordFlag},
 		flagsAuthRoleAdd:     {descriptionFlag},
 		flagsAuthRevokeToken: {tokenFileFlag},
@@ -182,6 +184,13 @@ var (
 				ArgsUsage: userLoginArgument,
 				Action:    wrapAuthN(loginUserHandler),
 			},
+			{
+				Name:      subcmdAuthS3Creds,
+				Usage:     "issue S3 access and secret keys for existing user",
+				Flags:     authFlags[flagsAuthS3Creds],
+				ArgsUsage: userLoginArgument,
+				Action:    wrapAuthN(s3CredsHandler),
+			},
 			{
 				Name:   subcmdAuthLogout,
 				Usage:  "log out",
@@ -319,6 +328,23 @@ func loginUserHandler(c *cli.Context) (err error) {
 	return nil
 }
 
+func s3CredsHandler(c *cli.Context) (err error) {
+	var (
+		expireIn *time.Duration
+		name     = cliAuthnUserName(c)
+		password = cliAuthnUserPassword(c, false)
+	)
+	if flagIsSet(c, expireFlag) {
+		expireIn = api.Duration(parseDurationFlag(c, expireFlag))
+	}
+	creds, err := api.IssueS3Creds(authParams, name, password, expireIn)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "Access key:\n%s\nSecret key:\n%s\n", creds.AccessKey, creds.SecretKey)
+	return nil
+}
+
 func logoutUserHandler(c *cli.Context) (err error) {
 	const logoutFailFmt = "logging out failed: %v"
 	tokenPath := filepath.Join(config.ConfigDirPath, tokenFile)
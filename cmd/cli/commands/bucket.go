@@ -184,6 +184,12 @@ func listObjects(c *cli.Context, bck cmn.Bck) error {
 	if flagIsSet(c, cachedFlag) {
 		msg.SetFlag(cmn.SelectCached)
 	}
+	if flagIsSet(c, listArchFlag) {
+		msg.SetFlag(cmn.SelectArchDir)
+	}
+	if flagIsSet(c, listVerFlag) {
+		msg.SetFlag(cmn.SelectVersions)
+	}
 	props := strings.Split(parseStrFlag(c, objPropsFlag), ",")
 	if cos.StringInSlice("all", props) {
 		msg.AddProps(cmn.GetPropsAll...)
@@ -367,10 +373,16 @@ func showBucketProps(c *cli.Context) (err error) {
 	if err != nil {
 		return err
 	}
-	return printBckHeadTable(c, p, defProps, section)
+	var usage *cmn.QuotaUsage
+	if p.Quota.IsSet() {
+		if u, err := api.GetQuotaUsage(defaultAPIParams, bck); err == nil {
+			usage = &u
+		}
+	}
+	return printBckHeadTable(c, p, defProps, section, usage)
 }
 
-func printBckHeadTable(c *cli.Context, props, defProps *cmn.BucketProps, section string) error {
+func printBckHeadTable(c *cli.Context, props, defProps *cmn.BucketProps, section string, usage *cmn.QuotaUsage) error {
 	var (
 		defList []prop
 		colored = !flagIsSet(c, noColorFlag)
@@ -379,6 +391,18 @@ func printBckHeadTable(c *cli.Context, props, defProps *cmn.BucketProps, section
 	// List instead of map to keep properties in the same order always.
 	// All names are one word ones - for easier parsing.
 	propList := bckPropList(props, !compact)
+	if usage != nil {
+		if compact {
+			for i := range propList {
+				if propList[i].Name == "quota" {
+					propList[i].Value = props.Quota.Describe(usage)
+				}
+			}
+		} else {
+			propList = append(propList, prop{"quota.usage", props.Quota.Describe(usage)})
+			sort.Slice(propList, func(i, j int) bool { return propList[i].Name < propList[j].Name })
+		}
+	}
 	if section != "" {
 		tmpPropList := propList[:0]
 		for _, v := range propList {
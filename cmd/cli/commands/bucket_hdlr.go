@@ -54,6 +54,12 @@ var (
 			maxPagesFlag,
 			startAfterFlag,
 			cachedFlag,
+			listArchFlag,
+			listVerFlag,
+		},
+		commandUndelete: {},
+		subcmdTrash: {
+			jsonFlag,
 		},
 		subcmdSummary: {
 			cachedFlag,
@@ -122,6 +128,21 @@ var (
 					multiple: true, provider: cmn.ProviderAIS,
 				}),
 			},
+			{
+				Name:      commandUndelete,
+				Usage:     "restore destroyed ais buckets (requires trash.retention)",
+				ArgsUsage: bucketsArgument,
+				Flags:     bucketCmdsFlags[commandUndelete],
+				Action:    undeleteBucketHandler,
+			},
+			{
+				Name:         subcmdTrash,
+				Usage:        "list destroyed buckets or deleted objects of a given bucket that can be restored",
+				ArgsUsage:    optionalBucketArgument,
+				Flags:        bucketCmdsFlags[subcmdTrash],
+				Action:       listTrashHandler,
+				BashComplete: bucketCompletions(bckCompletionsOpts{provider: cmn.ProviderAIS}),
+			},
 			{
 				Name:         commandEvict,
 				Usage:        "evict buckets or objects prefetched from remote buckets",
@@ -333,6 +354,36 @@ func removeBucketHandler(c *cli.Context) (err error) {
 	return destroyBuckets(c, buckets)
 }
 
+func undeleteBucketHandler(c *cli.Context) (err error) {
+	var buckets []cmn.Bck
+	if buckets, err = bucketsFromArgsOrEnv(c); err != nil {
+		return
+	}
+	for _, bck := range buckets {
+		if err = api.RestoreBucket(defaultAPIParams, bck); err != nil {
+			return
+		}
+		fmt.Fprintf(c.App.Writer, "%q bucket restored\n", bck)
+	}
+	return
+}
+
+func listTrashHandler(c *cli.Context) (err error) {
+	var queryBcks cmn.QueryBcks
+	if queryBcks, err = parseQueryBckURI(c, c.Args().First()); err != nil {
+		return
+	}
+	entries, err := api.ListTrash(defaultAPIParams, queryBcks)
+	if err != nil {
+		return
+	}
+	tmpl := templates.TrashObjectsTmpl
+	if queryBcks.Name == "" {
+		tmpl = templates.TrashBucketsTmpl
+	}
+	return templates.DisplayOutput(entries, c.App.Writer, tmpl, flagIsSet(c, jsonFlag))
+}
+
 func evictHandler(c *cli.Context) (err error) {
 	printDryRunHeader(c)
 
@@ -8,9 +8,11 @@ package commands
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmd/cli/templates"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/urfave/cli"
@@ -30,9 +32,14 @@ var (
 		subcmdCluConfig: {
 			transientFlag,
 		},
-		subcmdShutdown:  {},
-		subcmdRebalance: {},
-		subcmdPrimary:   {},
+		subcmdShutdown: {},
+		subcmdRebalance: {
+			dryRunFlag,
+			rebAddTargetsFlag,
+			rebRemoveTargetsFlag,
+			rebRateFlag,
+		},
+		subcmdPrimary: {},
 		subcmdJoin: {
 			roleFlag,
 		},
@@ -66,9 +73,9 @@ var (
 			},
 			{
 				Name:   subcmdRebalance,
-				Usage:  "rebalance data among storage targets in the cluster",
+				Usage:  "rebalance data among storage targets in the cluster (or estimate data movement with --dry-run)",
 				Flags:  clusterCmdsFlags[subcmdRebalance],
-				Action: startXactionHandler,
+				Action: rebalanceHandler,
 			},
 			{
 				Name:         subcmdPrimary,
@@ -237,6 +244,42 @@ func joinNodeHandler(c *cli.Context) (err error) {
 	return
 }
 
+func rebalanceHandler(c *cli.Context) (err error) {
+	if !flagIsSet(c, dryRunFlag) {
+		if flagIsSet(c, rebAddTargetsFlag) || flagIsSet(c, rebRemoveTargetsFlag) || flagIsSet(c, rebRateFlag) {
+			return fmt.Errorf("flags %s, %s, and %s require %s", rebAddTargetsFlag.Name,
+				rebRemoveTargetsFlag.Name, rebRateFlag.Name, dryRunFlag.Name)
+		}
+		return startXactionHandler(c)
+	}
+	msg := &cmn.RebPlanMsg{}
+	if flagIsSet(c, rebAddTargetsFlag) {
+		msg.Add = makeList(parseStrFlag(c, rebAddTargetsFlag))
+	}
+	if flagIsSet(c, rebRemoveTargetsFlag) {
+		msg.Remove = makeList(parseStrFlag(c, rebRemoveTargetsFlag))
+	}
+	if flagIsSet(c, rebRateFlag) {
+		if msg.Rate, err = parseByteFlagToInt(c, rebRateFlag); err != nil {
+			return
+		}
+	}
+	plan, err := api.GetRebalancePlan(defaultAPIParams, msg)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(c.App.Writer, dryRunHeader+" "+dryRunExplanation)
+	if err = templates.DisplayOutput(plan, c.App.Writer, templates.RebPlanTmpl); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "\n%d objects (%s) would move", plan.Moved.Objs, cos.B2S(plan.Moved.Size, 2))
+	if plan.Duration > 0 {
+		fmt.Fprintf(c.App.Writer, ", estimated duration: %v", plan.Duration.D().Round(time.Second))
+	}
+	fmt.Fprintln(c.App.Writer)
+	return
+}
+
 func nodeMaintenanceHandler(c *cli.Context) (err error) {
 	if c.NArg() < 1 {
 		return missingArgumentsError(c, "daemon ID")
@@ -7,6 +7,7 @@ package commands
 
 import (
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -29,28 +30,33 @@ const (
 	commandSearch    = "search"
 	commandETL       = cmn.ETL
 
-	commandCat       = "cat"
-	commandConcat    = "concat"
-	commandCopy      = "cp"
-	commandCreate    = "create"
-	commandECEncode  = "ec-encode"
-	commandEvict     = "evict"
-	commandGenShards = "gen-shards"
-	commandGet       = "get"
-	commandList      = "ls"
-	commandPrefetch  = cmn.ActPrefetch
-	commandPromote   = "promote"
-	commandPut       = "put"
-	commandSetCustom = "set-custom"
-	commandRemove    = "rm"
-	commandMv        = "mv"
-	commandSet       = "set"
-	commandMirror    = "mirror"
-	commandStart     = cmn.ActXactStart
-	commandStop      = cmn.ActXactStop
-	commandWait      = "wait"
-	commandAlias     = "alias"
-	commandStorage   = "storage"
+	commandAppendArch = "append-arch"
+	commandCat        = "cat"
+	commandConcat     = "concat"
+	commandCopy       = "cp"
+	commandCreate     = "create"
+	commandECEncode   = "ec-encode"
+	commandEvict      = "evict"
+	commandGenShards  = "gen-shards"
+	commandGet        = "get"
+	commandList       = "ls"
+	commandPrefetch   = cmn.ActPrefetch
+	commandPresign    = "presign"
+	commandPromote    = "promote"
+	commandPut        = "put"
+	commandSetCustom  = "set-custom"
+	commandUndelete   = "undelete"
+	commandRemove     = "rm"
+	commandMv         = "mv"
+	commandSet        = "set"
+	commandMirror     = "mirror"
+	commandStart      = cmn.ActXactStart
+	commandStop       = cmn.ActXactStop
+	commandPause      = cmn.ActXactPause
+	commandResume     = cmn.ActXactResume
+	commandWait       = "wait"
+	commandAlias      = "alias"
+	commandStorage    = "storage"
 
 	// Common Subcommands
 	// NOTE: second level subcommands are preferably verbs
@@ -76,6 +82,8 @@ const (
 	subcmdLogs       = "logs"
 	subcmdStop       = "stop"
 	subcmdLRU        = cmn.ActLRU
+	subcmdLifecycle  = cmn.ActLifecycle
+	subcmdScrub      = cmn.ActScrub
 	subcmdMembership = "membership"
 	subcmdShutdown   = "shutdown"
 	subcmdAttach     = "attach"
@@ -126,8 +134,13 @@ const (
 	subcmdStopDsort    = subcmdDsort
 	subcmdStopDownload = subcmdDownload
 
+	// Pause and resume subcommands
+	subcmdPauseXaction  = subcmdXaction
+	subcmdResumeXaction = subcmdXaction
+
 	// Bucket subcommands
 	subcmdSummary = "summary"
+	subcmdTrash   = "trash"
 
 	// Bucket properties subcommands
 	subcmdSetProps   = "set"
@@ -151,6 +164,7 @@ const (
 	subcmdAuthCluster = "cluster"
 	subcmdAuthToken   = "token"
 	subcmdAuthConfig  = subcmdConfig
+	subcmdAuthS3Creds = "s3creds"
 
 	// Warm up subcommands
 	subcmdPreload = "preload"
@@ -198,6 +212,7 @@ const (
 	getObjectArgument        = "BUCKET/OBJECT_NAME [OUT_FILE|-]"
 	putPromoteObjectArgument = "FILE|DIRECTORY BUCKET/[OBJECT_NAME]"
 	concatObjectArgument     = "FILE|DIRECTORY [FILE|DIRECTORY...] BUCKET/OBJECT_NAME"
+	appendArchArgument       = "FILE BUCKET/OBJECT_NAME"
 	objectArgument           = "BUCKET/OBJECT_NAME"
 	optionalObjectsArgument  = "BUCKET/[OBJECT_NAME]..."
 
@@ -274,6 +289,7 @@ var (
 	}
 	bucketPropsFlag = cli.StringFlag{Name: "bucket-props", Usage: "bucket properties"}
 	forceFlag       = cli.BoolFlag{Name: "force,f", Usage: "force an action"}
+	restartFlag     = cli.BoolFlag{Name: "restart", Usage: "start over, discarding the progress saved by the previous run"}
 
 	allXactionsFlag = cli.BoolTFlag{Name: "all", Usage: "show all xactions, including finished"}
 	allItemsFlag    = cli.BoolTFlag{Name: "all", Usage: "list all items"} // TODO: differentiate bucket names vs objects
@@ -336,6 +352,23 @@ var (
 		Value: downloader.DownloadProgressInterval.String(),
 		Usage: "interval(in secs) at which progress will be monitored, e.g. '10s'",
 	}
+	mirrorFlag = cli.StringFlag{
+		Name: "mirror",
+		Usage: "incrementally sync the destination with the source (remote bucket or range of links) every given interval, " +
+			"e.g. '1h': download only new and modified objects",
+	}
+	deleteVanishedFlag = cli.BoolFlag{
+		Name:  "delete-vanished",
+		Usage: "(with --mirror) delete objects that no longer exist at the source",
+	}
+	resumableSizeFlag = cli.StringFlag{
+		Name:  "resumable-size",
+		Usage: "download objects of at least this size (can end with suffix (k, MB, GiB, ...)) resumably, in ranges (default: 256MiB)",
+	}
+	parallelRangesFlag = cli.IntFlag{
+		Name:  "parallel-ranges",
+		Usage: "number of ranges of a (resumably downloaded) object to fetch in parallel",
+	}
 	// dSort
 	fileSizeFlag = cli.StringFlag{Name: "fsize", Value: "1024", Usage: "size of file in a shard"}
 	logFlag      = cli.StringFlag{Name: "log", Usage: "path to file where the metrics will be saved"}
@@ -358,6 +391,9 @@ var (
 	offsetFlag   = cli.StringFlag{Name: "offset", Usage: "object read offset " + sizeUnits}
 	lengthFlag   = cli.StringFlag{Name: "length", Usage: "object read length " + sizeUnits}
 	archpathFlag = cli.StringFlag{Name: "archpath", Usage: "filename in archive"}
+	listArchFlag = cli.BoolFlag{Name: "archive", Usage: "list archived content"}
+	objVerFlag   = cli.StringFlag{Name: "version", Usage: "previous version of the object (ais buckets only)"}
+	listVerFlag  = cli.BoolFlag{Name: "versions", Usage: "list previous versions of objects (ais buckets only)"}
 	isCachedFlag = cli.BoolFlag{Name: "is-cached", Usage: "check if object from a remote bucket is present (cached)"}
 	cachedFlag   = cli.BoolFlag{
 		Name:  "cached",
@@ -389,6 +425,18 @@ var (
 		Usage: "token expiration time, '0' - for never-expiring token. Default expiration time is 24 hours",
 	}
 
+	// Presigned object URL
+	presignMethodFlag = cli.StringFlag{
+		Name:  "method",
+		Usage: "HTTP method the URL grants access to: GET or PUT",
+		Value: http.MethodGet,
+	}
+	presignExpireFlag = cli.DurationFlag{
+		Name:  "expire,e",
+		Usage: "URL expiration time (max 7 days), valid time units: 's', 'm', and 'h'",
+		Value: time.Hour,
+	}
+
 	// Copy Bucket
 	cpBckDryRunFlag = cli.BoolFlag{
 		Name:  "dry-run",
@@ -400,14 +448,22 @@ var (
 	etlExtFlag              = cli.StringFlag{Name: "ext", Usage: "mapping from old to new extensions of transformed objects' names"}
 	etlUUID                 = cli.StringFlag{Name: "name", Usage: "unique ETL name (leaving this field empty will have unique ID auto-generated)"}
 	etlBucketRequestTimeout = cli.DurationFlag{Name: "request-timeout", Usage: "timeout for a transformation of a single object"}
-	fromFileFlag            = cli.StringFlag{Name: "from-file", Usage: "absolute path to the file with the code for ETL", Required: true}
+	fromFileFlag            = cli.StringFlag{Name: "from-file", Usage: "absolute path to the file with the code for ETL"}
 	depsFileFlag            = cli.StringFlag{
 		Name:  "deps-file",
 		Usage: "absolute path to the file with dependencies that must be installed before running the code",
 	}
 	runtimeFlag = cli.StringFlag{
 		Name:  "runtime",
-		Usage: "runtime which should be used when running the provided code", Required: true,
+		Usage: "runtime which should be used when running the provided code",
+	}
+	etlBuiltinFlag = cli.StringFlag{
+		Name:  "builtin",
+		Usage: "name of the built-in in-process transform to start (instead of the code and runtime)",
+	}
+	etlArgsFlag = cli.StringFlag{
+		Name:  "args",
+		Usage: "JSON-formatted arguments of the in-process transform, e.g.: '{\"fields\": \"id,label\"}'",
 	}
 	waitTimeoutFlag = cli.DurationFlag{
 		Name:  "wait-timeout",
@@ -427,6 +483,18 @@ var (
 		Name:  "no-rebalance",
 		Usage: "do not run rebalance after putting a node under maintenance",
 	}
+	rebAddTargetsFlag = cli.StringFlag{
+		Name:  "add",
+		Usage: "comma-separated list of IDs of the targets to join (dry-run)",
+	}
+	rebRemoveTargetsFlag = cli.StringFlag{
+		Name:  "remove",
+		Usage: "comma-separated list of IDs of the targets to leave (dry-run)",
+	}
+	rebRateFlag = cli.StringFlag{
+		Name:  "rate",
+		Usage: "per-target rebalance rate (bytes/s) to estimate the duration (dry-run), e.g.: 200MiB (default: rebalance.bwidth.target, if configured)",
+	}
 
 	longRunFlags = []cli.Flag{refreshFlag, countFlag}
 
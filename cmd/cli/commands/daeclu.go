@@ -450,6 +450,8 @@ func displayRebStats(tw *tabwriter.Writer, st *targetRebStats) {
 	endTime := "-"
 	if !st.stats.EndTime().IsZero() {
 		endTime = st.stats.EndTime().Format("01-02 15:04:05")
+	} else if st.stats.Paused() {
+		endTime = "paused"
 	}
 	startTime := st.stats.StartTime().Format("01-02 15:04:05")
 
@@ -340,6 +340,7 @@ func downloadJobStatus(c *cli.Context, id string) error {
 }
 
 func printDownloadStatus(w io.Writer, d downloader.DlStatusResp, verbose bool) {
+	printMirrorRuns(w, &d.DlJobInfo)
 	if d.Aborted {
 		fmt.Fprintln(w, "Download aborted")
 		return
@@ -407,3 +408,19 @@ func printDownloadStatus(w io.Writer, d downloader.DlStatusResp, verbose bool) {
 		fmt.Fprintf(w, "Errors (%d) occurred during the download. To see detailed info run `ais show job download %s -v`\n", d.ErrorCnt, d.ID)
 	}
 }
+
+// mirror job only: finished runs followed by the current one
+func printMirrorRuns(w io.Writer, d *downloader.DlJobInfo) {
+	const timeFmt = "01-02 15:04:05"
+	if d.Run == 0 {
+		return
+	}
+	for _, r := range d.Runs {
+		fmt.Fprintf(w, "Run #%d (%s - %s): %d downloaded, %d skipped, %d deleted, %d error%s\n",
+			r.Run, r.StartedTime.Format(timeFmt), r.FinishedTime.Format(timeFmt),
+			r.FinishedCnt-r.SkippedCnt-r.DeletedCnt, r.SkippedCnt, r.DeletedCnt, r.ErrorCnt, cos.NounEnding(r.ErrorCnt))
+	}
+	if d.JobRunning() {
+		fmt.Fprintf(w, "Run #%d:\n", d.Run)
+	}
+}
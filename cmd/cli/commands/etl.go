@@ -41,6 +41,8 @@ var etlCmd = cli.Command{
 				runtimeFlag,
 				waitTimeoutFlag,
 				etlUUID,
+				etlBuiltinFlag,
+				etlArgsFlag,
 			},
 			Action: etlBuildHandler,
 		},
@@ -148,8 +150,12 @@ func etlBuildHandler(c *cli.Context) (err error) {
 	var msg etl.BuildMsg
 
 	fromFile := parseStrFlag(c, fromFileFlag)
-	if fromFile == "" {
-		return fmt.Errorf("%s flag cannot be empty", fromFileFlag.Name)
+	builtin := parseStrFlag(c, etlBuiltinFlag)
+	switch {
+	case builtin != "" && fromFile != "":
+		return fmt.Errorf("flags %s and %s are mutually exclusive", etlBuiltinFlag.Name, fromFileFlag.Name)
+	case builtin == "" && fromFile == "":
+		return fmt.Errorf("either %s or %s flag must be specified", fromFileFlag.Name, etlBuiltinFlag.Name)
 	}
 
 	msg.ID = parseStrFlag(c, etlUUID)
@@ -162,8 +168,18 @@ func etlBuildHandler(c *cli.Context) (err error) {
 		}
 	}
 
-	if msg.Code, err = os.ReadFile(fromFile); err != nil {
-		return fmt.Errorf("failed to read file: %q, err: %v", fromFile, err)
+	if builtin != "" {
+		msg.Code, msg.Runtime = []byte(builtin), etl.RuntimeBuiltin
+	} else {
+		if msg.Code, err = os.ReadFile(fromFile); err != nil {
+			return fmt.Errorf("failed to read file: %q, err: %v", fromFile, err)
+		}
+		msg.Runtime = parseStrFlag(c, runtimeFlag)
+	}
+	if flagIsSet(c, etlArgsFlag) {
+		if err = jsoniter.UnmarshalFromString(parseStrFlag(c, etlArgsFlag), &msg.Args); err != nil {
+			return fmt.Errorf("couldn't parse %s flag: %v", etlArgsFlag.Name, err)
+		}
 	}
 
 	depsFile := parseStrFlag(c, depsFileFlag)
@@ -173,7 +189,6 @@ func etlBuildHandler(c *cli.Context) (err error) {
 		}
 	}
 
-	msg.Runtime = parseStrFlag(c, runtimeFlag)
 	msg.WaitTimeout = cos.Duration(parseDurationFlag(c, waitTimeoutFlag))
 
 	if err := msg.Validate(); err != nil {
@@ -321,12 +336,16 @@ func etlBucketHandler(c *cli.Context) (err error) {
 	}
 
 	msg := &cmn.TransCpyBckMsg{
-		ID: id,
 		CopyBckMsg: cmn.CopyBckMsg{
 			Prefix: parseStrFlag(c, cpBckPrefixFlag),
 			DryRun: flagIsSet(c, cpBckDryRunFlag),
 		},
 	}
+	if strings.Contains(id, cmn.ETLPipelineSepa) {
+		msg.Pipeline = strings.Split(id, cmn.ETLPipelineSepa)
+	} else {
+		msg.ID = id
+	}
 
 	if flagIsSet(c, etlExtFlag) {
 		mapStr := parseStrFlag(c, etlExtFlag)
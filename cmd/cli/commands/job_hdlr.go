@@ -24,6 +24,8 @@ var (
 	jobSubcmds = []cli.Command{
 		jobStartSubcmds,
 		jobStopSubcmds,
+		jobPauseSubcmds,
+		jobResumeSubcmds,
 		jobWaitSubcmds,
 		jobRemoveSubcmds,
 		makeAlias(showCmdJob, "", true, commandShow), // alias for `ais show`
@@ -47,6 +49,8 @@ func xactionCmds() cli.Commands {
 		cmn.ActMakeNCopies,
 		cmn.ActLoadLomCache,
 		cmn.ActLRU,
+		cmn.ActLifecycle,
+		cmn.ActScrub,
 		cmn.ActResilver,
 	)
 
@@ -0,0 +1,100 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+// This file handles commands that pause and resume running jobs.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/urfave/cli"
+)
+
+var (
+	jobPauseSubcmds = cli.Command{
+		Name:  commandPause,
+		Usage: "pause jobs running in the cluster",
+		Subcommands: []cli.Command{
+			{
+				Name:         subcmdPauseXaction,
+				Usage:        "pause an xaction",
+				ArgsUsage:    "XACTION_ID|XACTION_NAME [BUCKET]",
+				Description:  pausableXactDesc(),
+				Action:       pauseXactionHandler,
+				BashComplete: xactionCompletions(cmn.ActXactPause),
+			},
+		},
+	}
+	jobResumeSubcmds = cli.Command{
+		Name:  commandResume,
+		Usage: "resume paused jobs",
+		Subcommands: []cli.Command{
+			{
+				Name:         subcmdResumeXaction,
+				Usage:        "resume a paused xaction",
+				ArgsUsage:    "XACTION_ID|XACTION_NAME [BUCKET]",
+				Description:  pausableXactDesc(),
+				Action:       resumeXactionHandler,
+				BashComplete: xactionCompletions(cmn.ActXactResume),
+			},
+		},
+	}
+)
+
+func pausableXactDesc() string {
+	kinds := make([]string, 0, 4)
+	for _, kind := range listXactions(false) {
+		if xaction.IsPausable(kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+	return fmt.Sprintf("%s can be one of: %q", xactionArgument, strings.Join(kinds, ", "))
+}
+
+func pauseXactionHandler(c *cli.Context) error  { return pauseResumeXaction(c, true /*pause*/) }
+func resumeXactionHandler(c *cli.Context) error { return pauseResumeXaction(c, false /*pause*/) }
+
+func pauseResumeXaction(c *cli.Context, pause bool) (err error) {
+	var sid string
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "xaction name or id")
+	}
+	xactID, xactKind, bck, err := parseXactionFromArgs(c)
+	if err != nil {
+		return err
+	}
+	if xactKind != "" && !xaction.IsPausable(xactKind) {
+		return fmt.Errorf("xaction %q cannot be paused", xactKind)
+	}
+
+	xactArgs := api.XactReqArgs{ID: xactID, Kind: xactKind, Bck: bck}
+	verb := "Paused"
+	if pause {
+		err = api.PauseXaction(defaultAPIParams, xactArgs)
+	} else {
+		verb = "Resumed"
+		err = api.ResumeXaction(defaultAPIParams, xactArgs)
+	}
+	if err != nil {
+		return
+	}
+
+	if xactKind != "" && xactID != "" {
+		sid = fmt.Sprintf("%s, ID=%q", xactKind, xactID)
+	} else if xactKind != "" {
+		sid = xactKind
+	} else {
+		sid = fmt.Sprintf("xaction ID=%q", xactID)
+	}
+	if bck.IsEmpty() {
+		fmt.Fprintf(c.App.Writer, "%s %s\n", verb, sid)
+	} else {
+		fmt.Fprintf(c.App.Writer, "%s %s, bucket=%s\n", verb, sid, bck)
+	}
+	return
+}
@@ -35,6 +35,10 @@ var (
 			limitConnectionsFlag,
 			objectsListFlag,
 			progressIntervalFlag,
+			mirrorFlag,
+			deleteVanishedFlag,
+			resumableSizeFlag,
+			parallelRangesFlag,
 		},
 		subcmdStartDsort: {
 			specFileFlag,
@@ -47,6 +51,12 @@ var (
 			listBucketsFlag,
 			forceFlag,
 		},
+		subcmdLifecycle: {
+			dryRunFlag,
+		},
+		subcmdScrub: {
+			restartFlag,
+		},
 	}
 
 	jobStartSubcmds = cli.Command{
@@ -81,6 +91,22 @@ var (
 				Flags:  startCmdsFlags[subcmdLRU],
 				Action: startLRUHandler,
 			},
+			{
+				Name:         subcmdLifecycle,
+				Usage:        fmt.Sprintf("start %q xaction (apply bucket lifecycle rules)", cmn.ActLifecycle),
+				ArgsUsage:    bucketArgument,
+				Flags:        startCmdsFlags[subcmdLifecycle],
+				Action:       startLifecycleHandler,
+				BashComplete: bucketCompletions(),
+			},
+			{
+				Name:         subcmdScrub,
+				Usage:        fmt.Sprintf("start %q xaction (verify and repair objects, copies, and EC slices)", cmn.ActScrub),
+				ArgsUsage:    optionalBucketArgument,
+				Flags:        startCmdsFlags[subcmdScrub],
+				Action:       startScrubHandler,
+				BashComplete: bucketCompletions(),
+			},
 		},
 	}
 )
@@ -187,6 +213,10 @@ func startDownloadHandler(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	resumableSize, err := parseByteFlagToInt(c, resumableSizeFlag)
+	if err != nil {
+		return err
+	}
 
 	if _, err := time.ParseDuration(progressInterval); err != nil {
 		return err
@@ -201,6 +231,10 @@ func startDownloadHandler(c *cli.Context) error {
 			Connections:  parseIntFlag(c, limitConnectionsFlag),
 			BytesPerHour: int(limitBPH),
 		},
+		Resumable: downloader.DlResumable{
+			MinSize:  resumableSize,
+			Parallel: parseIntFlag(c, parallelRangesFlag),
+		},
 	}
 
 	if basePayload.Bck.Props, err = api.HeadBucket(defaultAPIParams, basePayload.Bck); err != nil {
@@ -256,6 +290,26 @@ func startDownloadHandler(c *cli.Context) error {
 		}
 	}
 
+	var mirrorPayload downloader.DlMirrorBody
+	if flagIsSet(c, mirrorFlag) {
+		mirrorPayload = downloader.DlMirrorBody{
+			DlBase:   basePayload,
+			Interval: parseStrFlag(c, mirrorFlag),
+			Delete:   flagIsSet(c, deleteVanishedFlag),
+		}
+		switch dlType {
+		case downloader.DlTypeRange:
+			mirrorPayload.Template, mirrorPayload.Subdir = source.link, pathSuffix
+		case downloader.DlTypeBackend:
+			mirrorPayload.Prefix = source.backend.prefix
+		default:
+			return fmt.Errorf("flag %q requires either range of links or remote bucket as the source", mirrorFlag.Name)
+		}
+		dlType = downloader.DlTypeMirror
+	} else if flagIsSet(c, deleteVanishedFlag) {
+		return fmt.Errorf("flag %q requires %q", deleteVanishedFlag.Name, mirrorFlag.Name)
+	}
+
 	switch dlType {
 	case downloader.DlTypeSingle:
 		payload := downloader.DlSingleBody{
@@ -299,6 +353,8 @@ func startDownloadHandler(c *cli.Context) error {
 			Prefix: source.backend.prefix,
 		}
 		id, err = api.DownloadWithParam(defaultAPIParams, dlType, payload)
+	case downloader.DlTypeMirror:
+		id, err = api.DownloadWithParam(defaultAPIParams, dlType, mirrorPayload)
 	default:
 		cos.Assert(false)
 	}
@@ -308,6 +364,11 @@ func startDownloadHandler(c *cli.Context) error {
 	}
 
 	fmt.Fprintln(c.App.Writer, id)
+	if dlType == downloader.DlTypeMirror {
+		fmt.Fprintf(c.App.Writer, "Mirroring every %s until stopped. Run `ais show job download %s` to see the runs.\n",
+			mirrorPayload.Interval, id)
+		return nil
+	}
 	fmt.Fprintf(c.App.Writer, "Run `ais show job download %s --progress` to monitor the progress.\n", id)
 
 	if flagIsSet(c, progressBarFlag) {
@@ -451,6 +512,64 @@ func startLRUHandler(c *cli.Context) (err error) {
 	return
 }
 
+func startLifecycleHandler(c *cli.Context) (err error) {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "bucket name")
+	}
+	bck, err := parseBckURI(c, c.Args().First())
+	if err != nil {
+		return err
+	}
+	if _, err = headBucket(bck); err != nil {
+		return err
+	}
+	var (
+		id       string
+		dryRun   = flagIsSet(c, dryRunFlag)
+		xactArgs = api.XactReqArgs{Kind: cmn.ActLifecycle, Bck: bck, DryRun: dryRun}
+	)
+	if id, err = api.StartXaction(defaultAPIParams, xactArgs); err != nil {
+		return
+	}
+	if !dryRun {
+		fmt.Fprintf(c.App.Writer, "Started %s %q, %s\n", cmn.ActLifecycle, id, xactProgressMsg(id))
+		return
+	}
+
+	if _, err = api.WaitForXaction(defaultAPIParams, api.XactReqArgs{ID: id}); err != nil {
+		return
+	}
+	stat, err := api.GetXactionStatsByID(defaultAPIParams, id)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(c.App.Writer, dryRunHeader+" "+dryRunExplanation)
+	fmt.Fprintf(c.App.Writer, "%d objects (%s) in bucket %s would have been affected\n",
+		stat.ObjCount(), cos.B2S(stat.BytesCount(), 2), bck)
+	return
+}
+
+func startScrubHandler(c *cli.Context) (err error) {
+	var (
+		bck cmn.Bck
+		id  string
+	)
+	if c.NArg() > 0 {
+		if bck, err = parseBckURI(c, c.Args().First()); err != nil {
+			return
+		}
+		if _, err = headBucket(bck); err != nil {
+			return
+		}
+	}
+	xactArgs := api.XactReqArgs{Kind: cmn.ActScrub, Bck: bck, Restart: flagIsSet(c, restartFlag)}
+	if id, err = api.StartXaction(defaultAPIParams, xactArgs); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "Started %s %q, %s\n", cmn.ActScrub, id, xactProgressMsg(id))
+	return
+}
+
 func startPrefetchHandler(c *cli.Context) (err error) {
 	printDryRunHeader(c)
 
@@ -116,6 +116,12 @@ func getObject(c *cli.Context, outFile string, silent bool) (err error) {
 		}
 		objArgs.Query.Set(cmn.URLParamArchpath, archpath)
 	}
+	if version := parseStrFlag(c, objVerFlag); version != "" {
+		if objArgs.Query == nil {
+			objArgs.Query = make(url.Values, 1)
+		}
+		objArgs.Query.Set(cmn.URLParamObjVersion, version)
+	}
 
 	if flagIsSet(c, checksumFlag) {
 		objLen, err = api.GetObjectWithValidation(defaultAPIParams, bck, objName, objArgs)
@@ -466,6 +472,37 @@ func putObject(c *cli.Context, bck cmn.Bck, objName, fileName, cksumType string)
 	return putMultipleObjects(c, files, bck)
 }
 
+// append a single file to an existing archive; by default, the file's base name
+// becomes its pathname inside the archive
+func appendToArch(c *cli.Context, bck cmn.Bck, objName, fileName string) error {
+	archPath := parseStrFlag(c, archpathFlag)
+	if archPath == "" {
+		archPath = filepath.Base(fileName)
+	}
+	fh, err := cos.NewFileHandle(fileName)
+	if err != nil {
+		return err
+	}
+	finfo, err := fh.Stat()
+	if err != nil {
+		fh.Close()
+		return err
+	}
+	args := api.AppendToArchArgs{
+		BaseParams: defaultAPIParams,
+		Bck:        bck,
+		Object:     objName,
+		ArchPath:   archPath,
+		Reader:     fh,
+		Size:       finfo.Size(),
+	}
+	if err := api.AppendToArch(args); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "%q appended to archive %q as %q\n", fileName, bck.String()+"/"+objName, archPath)
+	return nil
+}
+
 func concatObject(c *cli.Context, bck cmn.Bck, objName string, fileNames []string) (err error) {
 	var (
 		bar        *mpb.Bar
@@ -859,6 +896,14 @@ func multiObjOp(c *cli.Context, command string) error {
 
 		switch command {
 		case commandRemove:
+			if flagIsSet(c, archpathFlag) {
+				archPath := parseStrFlag(c, archpathFlag)
+				if err := api.DeleteFromArch(defaultAPIParams, bck, objectName, archPath); err != nil {
+					return err
+				}
+				fmt.Fprintf(c.App.Writer, "%q deleted from archive %q\n", archPath, bck.String()+"/"+objectName)
+				continue
+			}
 			if err := api.DeleteObject(defaultAPIParams, bck, objectName); err != nil {
 				return err
 			}
@@ -20,12 +20,14 @@ import (
 
 var (
 	objectCmdsFlags = map[string][]cli.Flag{
-		commandRemove: baseLstRngFlags,
-		commandMv:     {},
+		commandRemove:   append(baseLstRngFlags, archpathFlag),
+		commandUndelete: {},
+		commandMv:       {},
 		commandGet: {
 			offsetFlag,
 			lengthFlag,
 			archpathFlag,
+			objVerFlag,
 			checksumFlag,
 			isCachedFlag,
 			forceFlag,
@@ -62,6 +64,13 @@ var (
 			recursiveFlag,
 			progressBarFlag,
 		},
+		commandAppendArch: {
+			archpathFlag,
+		},
+		commandPresign: {
+			presignMethodFlag,
+			presignExpireFlag,
+		},
 		commandCat: {
 			offsetFlag,
 			lengthFlag,
@@ -140,6 +149,14 @@ var (
 				Flags:     objectCmdsFlags[commandConcat],
 				Action:    concatHandler,
 			},
+			{
+				Name:         commandAppendArch,
+				Usage:        "append file to an existing archive (tar, tgz, zip) in the specified bucket",
+				ArgsUsage:    appendArchArgument,
+				Flags:        objectCmdsFlags[commandAppendArch],
+				Action:       appendArchHandler,
+				BashComplete: putPromoteObjectCompletions,
+			},
 			{
 				Name:         commandCat,
 				Usage:        "print an object from the specified bucket to STDOUT",
@@ -148,6 +165,22 @@ var (
 				Action:       catHandler,
 				BashComplete: bucketCompletions(bckCompletionsOpts{separator: true}),
 			},
+			{
+				Name:         commandUndelete,
+				Usage:        "restore deleted object in an ais bucket (requires trash.retention)",
+				ArgsUsage:    objectArgument,
+				Flags:        objectCmdsFlags[commandUndelete],
+				Action:       undeleteObjectHandler,
+				BashComplete: bucketCompletions(bckCompletionsOpts{separator: true, provider: cmn.ProviderAIS}),
+			},
+			{
+				Name:         commandPresign,
+				Usage:        "generate time-limited URL to GET or PUT the object without credentials",
+				ArgsUsage:    objectArgument,
+				Flags:        objectCmdsFlags[commandPresign],
+				Action:       presignHandler,
+				BashComplete: bucketCompletions(bckCompletionsOpts{separator: true}),
+			},
 		},
 	}
 )
@@ -418,6 +451,31 @@ func promoteHandler(c *cli.Context) (err error) {
 	return promoteFileOrDir(c, bck, objName, fqn)
 }
 
+func appendArchHandler(c *cli.Context) (err error) {
+	var (
+		bck         cmn.Bck
+		objName     string
+		fileName    = c.Args().Get(0)
+		fullObjName = c.Args().Get(1)
+	)
+	if c.NArg() < 1 {
+		return missingArgumentsError(c, "file to append", "archive name in the form bucket/object")
+	}
+	if c.NArg() < 2 {
+		return missingArgumentsError(c, "archive name in the form bucket/object")
+	}
+	if bck, objName, err = parseBckObjectURI(c, fullObjName); err != nil {
+		return
+	}
+	if objName == "" {
+		return incorrectUsageMsg(c, "no archive specified in %q", fullObjName)
+	}
+	if _, err = headBucket(bck); err != nil {
+		return
+	}
+	return appendToArch(c, bck, objName, fileName)
+}
+
 func setCustomPropsHandler(c *cli.Context) (err error) {
 	if c.NArg() == 0 {
 		return incorrectUsageMsg(c, "missing bucket")
@@ -433,3 +491,43 @@ func setCustomPropsHandler(c *cli.Context) (err error) {
 func catHandler(c *cli.Context) (err error) {
 	return getObject(c, fileStdIO, true /*silent*/)
 }
+
+func presignHandler(c *cli.Context) (err error) {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "object name in the form bucket/object")
+	}
+	uri := c.Args().First()
+	bck, objName, err := parseBckObjectURI(c, uri)
+	if err != nil {
+		return
+	}
+	if objName == "" {
+		return incorrectUsageMsg(c, "no object specified in %q", uri)
+	}
+	method := strings.ToUpper(parseStrFlag(c, presignMethodFlag))
+	presigned, err := api.PresignObject(defaultAPIParams, bck, objName, method, parseDurationFlag(c, presignExpireFlag))
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(c.App.Writer, presigned)
+	return
+}
+
+func undeleteObjectHandler(c *cli.Context) (err error) {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "object name in the form bucket/object")
+	}
+	uri := c.Args().First()
+	bck, objName, err := parseBckObjectURI(c, uri)
+	if err != nil {
+		return
+	}
+	if objName == "" {
+		return incorrectUsageMsg(c, "no object specified in %q", uri)
+	}
+	if err = api.RestoreObject(defaultAPIParams, bck, objName); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "%q restored\n", bck.String()+"/"+objName)
+	return
+}
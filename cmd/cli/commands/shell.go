@@ -405,9 +405,17 @@ func xactionCompletions(cmd string) func(ctx *cli.Context) {
 	return func(c *cli.Context) {
 		if c.NArg() == 0 {
 			for kind, meta := range xaction.XactsDtor {
-				if (cmd != cmn.ActXactStart) || (cmd == cmn.ActXactStart && meta.Startable) {
-					fmt.Println(kind)
+				switch cmd {
+				case cmn.ActXactStart:
+					if !meta.Startable {
+						continue
+					}
+				case cmn.ActXactPause, cmn.ActXactResume:
+					if !meta.Pausable {
+						continue
+					}
 				}
+				fmt.Println(kind)
 			}
 			return
 		}
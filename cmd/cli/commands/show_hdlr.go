@@ -395,6 +395,8 @@ func showXactionHandler(c *cli.Context) (err error) {
 		return templates.DisplayOutput(ctx, c.App.Writer, templates.XactionECGetBodyTmpl, useJSON)
 	case cmn.ActECPut:
 		return templates.DisplayOutput(ctx, c.App.Writer, templates.XactionECPutBodyTmpl, useJSON)
+	case cmn.ActScrub:
+		return templates.DisplayOutput(ctx, c.App.Writer, templates.XactionScrubBodyTmpl, useJSON)
 	default:
 		return templates.DisplayOutput(ctx, c.App.Writer, templates.XactionsBodyTmpl, useJSON)
 	}
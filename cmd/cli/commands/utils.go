@@ -1098,8 +1098,12 @@ func bckPropList(props *cmn.BucketProps, verbose bool) (propList []prop) {
 			{"checksum", props.Cksum.String()},
 			{"mirror", props.Mirror.String()},
 			{"ec", props.EC.String()},
+			{"storage_class", props.StorageClass.String()},
 			{"lru", props.LRU.String()},
+			{"lifecycle", props.Lifecycle.String()},
 			{"versioning", props.Versioning.String()},
+			{"trash", props.Trash.String()},
+			{"quota", props.Quota.String()},
 		}
 		if props.Provider == cmn.ProviderHTTP {
 			origURL := props.Extra.HTTP.OrigURLBck
@@ -20,6 +20,7 @@ import (
 	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xs"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/urfave/cli"
 	"k8s.io/apimachinery/pkg/util/duration"
@@ -117,7 +118,7 @@ const (
 		"{{if (eq $xact.ObjCountX 0) }}-{{else}}{{$xact.ObjCountX}}{{end}}\t " +
 		"{{if (eq $xact.BytesCountX 0) }}-{{else}}{{FormatBytesSigned $xact.BytesCountX 2}}{{end}}\t " +
 		"{{FormatTime $xact.StartTimeX}}\t " +
-		"{{if (IsUnsetTime $xact.EndTimeX)}}-{{else}}{{FormatTime $xact.EndTimeX}}{{end}}\t " +
+		"{{if (IsUnsetTime $xact.EndTimeX)}}{{if $xact.PausedX}}paused{{else}}-{{end}}{{else}}{{FormatTime $xact.EndTimeX}}{{end}}\t " +
 		"{{$xact.AbortedX}}\n"
 	XactionsExtBodyTmpl = "{{if $.Verbose }}" + // if not nil
 		"\n{{range $daemon := $.Stats }}" +
@@ -165,6 +166,35 @@ const (
 		"{{if (IsUnsetTime $xact.EndTimeX)}}-{{else}}{{FormatTime $xact.EndTimeX}}{{end}}\t " +
 		"{{$xact.AbortedX}}\n"
 
+	XactionScrubStatsHeader = "NODE\t BUCKET\t OBJECTS\t BYTES\t CORRUPTED\t REPAIRED\t UNRECOVERABLE\t START\t END\t ABORTED\n"
+	XactionScrubBodyTmpl    = XactionScrubStatsHeader +
+		"{{range $daemon := $.Stats }}" + XactionScrubBody + "{{end}}" +
+		"{{range $daemon := $.Stats }}{{range $key, $xact := $daemon.Stats}}" +
+		"{{ $ext := ExtScrubStats $xact }}{{range $name := $ext.UnrecoverableObjs}}" +
+		"{{ $daemon.DaemonID }}: unrecoverable {{ $name }}\n{{end}}{{end}}{{end}}"
+	XactionScrubBody      = "{{range $key, $xact := $daemon.Stats}}" + XactionScrubStatsBody + "{{end}}"
+	XactionScrubStatsBody = "{{ $daemon.DaemonID }}\t " +
+		"{{if $xact.BckX.Name}}{{$xact.BckX.Name}}{{else}}-{{end}}\t " +
+		"{{if (eq $xact.ObjCountX 0) }}-{{else}}{{$xact.ObjCountX}}{{end}}\t " +
+		"{{if (eq $xact.BytesCountX 0) }}-{{else}}{{FormatBytesSigned $xact.BytesCountX 2}}{{end}}\t " +
+
+		"{{ $ext := ExtScrubStats $xact }}" +
+		"{{ $ext.Corrupted }}\t " +
+		"{{ $ext.Repaired }}\t " +
+		"{{ $ext.Unrecoverable }}\t " +
+
+		"{{FormatTime $xact.StartTimeX}}\t " +
+		"{{if (IsUnsetTime $xact.EndTimeX)}}-{{else}}{{FormatTime $xact.EndTimeX}}{{end}}\t " +
+		"{{$xact.AbortedX}}\n"
+
+	// Command `cluster rebalance --dry-run`
+	RebPlanTmpl = "TARGET\t STORED OBJECTS\t STORED SIZE\t IN OBJECTS\t IN SIZE\t OUT OBJECTS\t OUT SIZE\n" +
+		"{{range $sid, $t := .Targets}}" +
+		"{{$sid}}\t {{$t.Stored.Objs}}\t {{FormatBytesSigned $t.Stored.Size 2}}\t " +
+		"{{$t.In.Objs}}\t {{FormatBytesSigned $t.In.Size 2}}\t " +
+		"{{$t.Out.Objs}}\t {{FormatBytesSigned $t.Out.Size 2}}\n" +
+		"{{end}}"
+
 	// Buckets templates
 	BucketsSummariesFastTmpl = "NAME\t EST. OBJECTS\t EST. SIZE\t EST. USED %\n" + bucketsSummariesBody
 	BucketsSummariesTmpl     = "NAME\t OBJECTS\t SIZE \t USED %\n" + bucketsSummariesBody
@@ -172,6 +202,16 @@ const (
 		"{{$v.Bck}}\t {{$v.ObjCount}}\t {{FormatBytesUnsigned $v.Size 2}}\t {{FormatFloat $v.UsedPct}}%\n" +
 		"{{end}}"
 
+	// Command `bucket trash`
+	TrashBucketsTmpl = "BUCKET\t DELETED\n" +
+		"{{range $v := . }}" +
+		"{{$v.Bck}}\t {{FormatUnixNano $v.Deleted}}\n" +
+		"{{end}}"
+	TrashObjectsTmpl = "NAME\t SIZE\t DELETED\n" +
+		"{{range $v := . }}" +
+		"{{$v.ObjName}}\t {{FormatBytesSigned $v.Size 2}}\t {{FormatUnixNano $v.Deleted}}\n" +
+		"{{end}}"
+
 	// Bucket summary validate templates
 	BucketSummaryValidateTmpl = "BUCKET\t OBJECTS\t MISPLACED\t MISSING COPIES\n" + bucketSummaryValidateBody
 	bucketSummaryValidateBody = "{{range $v := . }}" +
@@ -320,6 +360,7 @@ var (
 		"FormatACL":           fmtACL,
 		"ExtECGetStats":       extECGetStats,
 		"ExtECPutStats":       extECPutStats,
+		"ExtScrubStats":       extScrubStats,
 	}
 
 	AliasTemplate = "ALIAS\tCOMMAND\n{{range $alias, $command := .}}" +
@@ -417,6 +458,9 @@ func fmtXactStatus(tStatus *stats.TargetStatus) string {
 		return "aborted"
 	}
 	if tStats.EndTime().IsZero() {
+		if tStats.Paused() {
+			return "paused"
+		}
 		return "running"
 	}
 	return "finished"
@@ -431,7 +475,7 @@ func fmtObjStatus(obj *cmn.BucketEntry) string {
 
 var ConfigSectionTmpl = []string{
 	"global", "mirror", "log", "client", "periodic", "timeout", "proxy",
-	"lru", "disk", "rebalance", "checksum", "versioning", "fspath",
+	"lru", "disk", "rebalance", "checksum", "versioning", "trash", "fspath",
 	"testfs", "network", "fshc", "auth", "keepalive", "downloader",
 	cmn.DSortNameLowercase, "compression", "ec", "replication",
 }
@@ -595,6 +639,14 @@ func extECPutStats(base *xaction.BaseXactStatsExt) *ec.ExtECPutStats {
 	return ecPut
 }
 
+func extScrubStats(base *xaction.BaseXactStatsExt) *xs.ExtScrubStats {
+	scrub := &xs.ExtScrubStats{}
+	if err := cos.MorphMarshal(base.Ext, scrub); err != nil {
+		return &xs.ExtScrubStats{}
+	}
+	return scrub
+}
+
 func fmtMilli(val cos.Duration) string {
 	return cos.FormatMilli(time.Duration(val))
 }
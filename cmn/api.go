@@ -6,10 +6,12 @@
 package cmn
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -20,6 +22,8 @@ const (
 	SelectCached    = 1 << iota // list only cached (Cloud buckets only)
 	SelectMisplaced             // Include misplaced
 	SelectDeleted               // Include marked for deletion
+	SelectArchDir               // Expand archives (cos.ArchExtensions) as directories
+	SelectVersions              // Include previous versions of objects (ais buckets only)
 )
 
 // ActionMsg is a JSON-formatted control structures for the REST API
@@ -36,6 +40,10 @@ type (
 		Overwrite bool   `json:"overwrite"`
 		KeepOrig  bool   `json:"keep_original"`
 	}
+	ActValPresign struct {
+		Method  string       `json:"method"`  // http.MethodGet or http.MethodPut
+		Expires cos.Duration `json:"expires"` // URL validity period
+	}
 	ActValRmNode struct {
 		DaemonID      string `json:"sid"`
 		SkipRebalance bool   `json:"skip_rebalance"`
@@ -71,6 +79,17 @@ type (
 	}
 	BucketsSummaries []BucketSummary
 
+	// TrashEntry describes a deleted (restorable) object or a destroyed bucket -
+	// see TrashConf
+	TrashEntry struct {
+		Bck     Bck          `json:"bck"`
+		ObjName string       `json:"name,omitempty"`  // empty for buckets
+		Size    int64        `json:"size,string"`     // objects only
+		Deleted int64        `json:"deleted,string"`  // time of deletion (nanoseconds since epoch)
+		Props   *BucketProps `json:"props,omitempty"` // buckets only
+	}
+	TrashEntries []*TrashEntry
+
 	CopyBckMsg struct {
 		Prefix string `json:"prefix"`  // Prefix added to each resulting object.
 		DryRun bool   `json:"dry_run"` // Don't perform any PUT
@@ -84,6 +103,7 @@ type (
 		Ext cos.SimpleKVs `json:"ext"`
 
 		ID             string       `json:"id,omitempty"`              // optional, ETL only
+		Pipeline       []string     `json:"pipeline,omitempty"`        // optional, ETL only: IDs of ETLs to apply in sequence
 		RequestTimeout cos.Duration `json:"request_timeout,omitempty"` // optional, ETL only
 
 		CopyBckMsg
@@ -120,6 +140,15 @@ type (
 		// Versioning can be enabled or disabled on a per-bucket basis
 		Versioning VersionConf `json:"versioning"`
 
+		// Delete retention (soft delete) policy for ais buckets
+		Trash TrashConf `json:"trash"`
+
+		// Storage quota (zero - unlimited)
+		Quota QuotaConf `json:"quota"`
+
+		// Time-based expiration (lifecycle) rules
+		Lifecycle LifecycleConf `json:"lifecycle"`
+
 		// Cksum is the embedded struct of the same name
 		Cksum CksumConf `json:"checksum"`
 
@@ -135,6 +164,9 @@ type (
 		// EC defines erasure coding setting for the bucket
 		EC ECConf `json:"ec"`
 
+		// Storage classes override mirror and EC settings for subsets of objects
+		StorageClass StorageClassConf `json:"storage_class"`
+
 		// Bucket access attributes - see Allow* above
 		Access AccessAttrs `json:"access,string"`
 
@@ -152,6 +184,55 @@ type (
 		Renamed string `list:"omit"`
 	}
 
+	QuotaConf struct {
+		MaxSize int64 `json:"max_size"`    // max total size of the bucket's objects (bytes)
+		MaxObjs int64 `json:"max_objects"` // max number of objects in the bucket
+	}
+	QuotaConfToUpdate struct {
+		MaxSize *int64 `json:"max_size"`
+		MaxObjs *int64 `json:"max_objects"`
+	}
+	// cluster-wide (as aggregated by proxy) or local (target) usage of a bucket with quota
+	QuotaUsage struct {
+		Size int64 `json:"size,string"`
+		Objs int64 `json:"objects,string"`
+	}
+
+	LifecycleConf struct {
+		Rules LifecycleRules `json:"rules"`
+	}
+	LifecycleConfToUpdate struct {
+		Rules *LifecycleRules `json:"rules"`
+	}
+	// The rule applies to objects whose names start with the prefix (empty - all objects)
+	// and that are older than `age` and/or have not been accessed for `atime`.
+	LifecycleRule struct {
+		Prefix string       `json:"prefix"`
+		Age    cos.Duration `json:"age"`    // since creation (last modification)
+		Atime  cos.Duration `json:"atime"`  // since last access
+		Action string       `json:"action"` // one of the SupportedLifecycleActions
+	}
+	LifecycleRules []LifecycleRule
+
+	StorageClassConf struct {
+		Classes StorageClasses `json:"classes"`
+	}
+	StorageClassConfToUpdate struct {
+		Classes *StorageClasses `json:"classes"`
+	}
+	// Storage class applies to objects whose names start with the prefix (empty - all objects)
+	// and whose sizes are within [min_size, max_size) range; the objects get erasure coded
+	// if parity_slices is non-zero, mirrored otherwise.
+	StorageClass struct {
+		Prefix       string `json:"prefix"`
+		MinSize      int64  `json:"min_size"`      // bytes, inclusive
+		MaxSize      int64  `json:"max_size"`      // bytes, exclusive (0 - unlimited)
+		Copies       int64  `json:"copies"`        // n-way mirror (1 - single copy)
+		DataSlices   int    `json:"data_slices"`   // erasure coding
+		ParitySlices int    `json:"parity_slices"` // ditto
+	}
+	StorageClasses []StorageClass
+
 	ExtraProps struct {
 		AWS  ExtraPropsAWS  `json:"aws,omitempty" list:"omitempty"`
 		HTTP ExtraPropsHTTP `json:"http,omitempty" list:"omitempty"`
@@ -183,16 +264,20 @@ type (
 	// The struct may have extra fields that do not exist in BucketProps.
 	// Add tag 'copy:"skip"' to ignore those fields when copying values.
 	BucketPropsToUpdate struct {
-		BackendBck *BckToUpdate         `json:"backend_bck"`
-		Versioning *VersionConfToUpdate `json:"versioning"`
-		Cksum      *CksumConfToUpdate   `json:"checksum"`
-		LRU        *LRUConfToUpdate     `json:"lru"`
-		Mirror     *MirrorConfToUpdate  `json:"mirror"`
-		EC         *ECConfToUpdate      `json:"ec"`
-		Access     *AccessAttrs         `json:"access,string"`
-		MDWrite    *MDWritePolicy       `json:"md_write"`
-		Extra      *ExtraToUpdate       `json:"extra"`
-		Force      bool                 `json:"force" copy:"skip" list:"omit"`
+		BackendBck   *BckToUpdate              `json:"backend_bck"`
+		Versioning   *VersionConfToUpdate      `json:"versioning"`
+		Trash        *TrashConfToUpdate        `json:"trash"`
+		Quota        *QuotaConfToUpdate        `json:"quota"`
+		Lifecycle    *LifecycleConfToUpdate    `json:"lifecycle"`
+		Cksum        *CksumConfToUpdate        `json:"checksum"`
+		LRU          *LRUConfToUpdate          `json:"lru"`
+		Mirror       *MirrorConfToUpdate       `json:"mirror"`
+		EC           *ECConfToUpdate           `json:"ec"`
+		StorageClass *StorageClassConfToUpdate `json:"storage_class"`
+		Access       *AccessAttrs              `json:"access,string"`
+		MDWrite      *MDWritePolicy            `json:"md_write"`
+		Extra        *ExtraToUpdate            `json:"extra"`
+		Force        bool                      `json:"force" copy:"skip" list:"omit"`
 	}
 
 	BckToUpdate struct {
@@ -246,6 +331,57 @@ type (
 	}
 )
 
+// rebalance plan (dry run)
+type (
+	// hypothetical change of the cluster membership
+	RebPlanMsg struct {
+		Add    []string `json:"add,omitempty"`    // IDs of the targets to join
+		Remove []string `json:"remove,omitempty"` // IDs of the targets to leave (decommission, maintenance)
+		Rate   int64    `json:"rate,omitempty"`   // bytes/s per target - to estimate the duration
+	}
+	RebPlanCount struct {
+		Objs int64 `json:"objs,string"`
+		Size int64 `json:"size,string"`
+	}
+	// as reported by each target: the objects it stores and the ones it'd send
+	TRebPlan struct {
+		Stored RebPlanCount            `json:"stored"`
+		Out    map[string]RebPlanCount `json:"out"` // by destination target ID
+	}
+	RebPlanTarget struct {
+		Stored RebPlanCount `json:"stored"`
+		In     RebPlanCount `json:"in"`
+		Out    RebPlanCount `json:"out"`
+	}
+	RebPlan struct {
+		Targets  map[string]*RebPlanTarget `json:"targets"`  // by target ID (including the ones to join)
+		Moved    RebPlanCount              `json:"moved"`    // total
+		Duration cos.Duration              `json:"duration"` // estimated (zero if unknown)
+	}
+)
+
+func (c *RebPlanCount) Add(o RebPlanCount) { c.Objs += o.Objs; c.Size += o.Size }
+
+func (plan *RebPlan) Target(sid string) (tplan *RebPlanTarget) {
+	if tplan = plan.Targets[sid]; tplan == nil {
+		tplan = &RebPlanTarget{}
+		plan.Targets[sid] = tplan
+	}
+	return
+}
+
+// Targets send and receive in parallel - the slowest one determines the duration.
+func (plan *RebPlan) Estimate(rate int64) {
+	if rate <= 0 {
+		return
+	}
+	var size int64
+	for _, tplan := range plan.Targets {
+		size = cos.MaxI64(size, cos.MaxI64(tplan.In.Size, tplan.Out.Size))
+	}
+	plan.Duration = cos.Duration(float64(size) / float64(rate) * float64(time.Second))
+}
+
 // GetPropsDefault is a list of default (most relevant) `GetProps*` options.
 // NOTE: do **NOT** forget update this array when a prop is added/removed.
 var GetPropsDefault = []string{
@@ -376,6 +512,13 @@ func (c *VersionConf) String() string {
 	return text
 }
 
+func (c *TrashConf) String() string {
+	if c.Retention == 0 {
+		return "Disabled"
+	}
+	return "Retention: " + c.Retention.String()
+}
+
 func (c *CksumConf) String() string {
 	if c.Type == cos.ChecksumNone {
 		return "Disabled"
@@ -404,8 +547,215 @@ func (c *LRUConf) String() string {
 	if !c.Enabled {
 		return "Disabled"
 	}
-	return fmt.Sprintf("Watermarks: %d%%/%d%% | Do not evict time: %v | OOS: %v%%",
+	s := fmt.Sprintf("Watermarks: %d%%/%d%% | Do not evict time: %v | OOS: %v%%",
 		c.LowWM, c.HighWM, c.DontEvictTime, c.OOS)
+	if policy := c.EvictPolicy(); policy != LRUPolicyLRU {
+		s += " | Policy: " + policy
+	}
+	if c.Priority != 0 {
+		s += fmt.Sprintf(" | Priority: %d", c.Priority)
+	}
+	return s
+}
+
+func (c *QuotaConf) String() string {
+	if !c.IsSet() {
+		return "Disabled"
+	}
+	return c.Describe(nil)
+}
+
+func (c *QuotaConf) IsSet() bool { return c.MaxSize > 0 || c.MaxObjs > 0 }
+
+func (c *QuotaConf) Exceeded(usage *QuotaUsage) bool {
+	return (c.MaxSize > 0 && usage.Size >= c.MaxSize) || (c.MaxObjs > 0 && usage.Objs >= c.MaxObjs)
+}
+
+// Describes the quota and, optionally, its utilization.
+func (c *QuotaConf) Describe(usage *QuotaUsage) string {
+	var parts []string
+	if c.MaxSize > 0 {
+		if usage == nil {
+			parts = append(parts, "Max size: "+cos.B2S(c.MaxSize, 2))
+		} else {
+			parts = append(parts, fmt.Sprintf("Size: %s/%s (%d%%)", cos.B2S(usage.Size, 2), cos.B2S(c.MaxSize, 2),
+				usage.Size*100/c.MaxSize))
+		}
+	}
+	if c.MaxObjs > 0 {
+		if usage == nil {
+			parts = append(parts, fmt.Sprintf("Max objects: %d", c.MaxObjs))
+		} else {
+			parts = append(parts, fmt.Sprintf("Objects: %d/%d (%d%%)", usage.Objs, c.MaxObjs,
+				usage.Objs*100/c.MaxObjs))
+		}
+	}
+	return strings.Join(parts, " | ")
+}
+
+func (c *QuotaConf) ValidateAsProps(_ *ValidationArgs) error {
+	if c.MaxSize < 0 || c.MaxObjs < 0 {
+		return fmt.Errorf("invalid quota (max_size, max_objects) configuration (%d, %d)", c.MaxSize, c.MaxObjs)
+	}
+	return nil
+}
+
+func (c *LifecycleConf) String() string {
+	if len(c.Rules) == 0 {
+		return "Disabled"
+	}
+	return c.Rules.String()
+}
+
+func (c *LifecycleConf) ValidateAsProps(args *ValidationArgs) error {
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if !cos.StringInSlice(rule.Action, SupportedLifecycleActions) {
+			return fmt.Errorf("invalid lifecycle rule %q: action must be one of %v", rule, SupportedLifecycleActions)
+		}
+		if rule.Age < 0 || rule.Atime < 0 || (rule.Age == 0 && rule.Atime == 0) {
+			return fmt.Errorf("invalid lifecycle rule %q: age and/or atime must be positive", rule)
+		}
+		if rule.Action == LifecycleEvict && args.Provider == ProviderAIS {
+			return fmt.Errorf("invalid lifecycle rule %q: only remote buckets can be evicted", rule)
+		}
+	}
+	return nil
+}
+
+func (r LifecycleRule) String() string {
+	var cond []string
+	if r.Age > 0 {
+		cond = append(cond, "age>"+r.Age.String())
+	}
+	if r.Atime > 0 {
+		cond = append(cond, "atime>"+r.Atime.String())
+	}
+	return fmt.Sprintf("%s*(%s): %s", r.Prefix, strings.Join(cond, ","), r.Action)
+}
+
+func (rules LifecycleRules) String() string {
+	s := make([]string, 0, len(rules))
+	for _, r := range rules {
+		s = append(s, r.String())
+	}
+	return strings.Join(s, " | ")
+}
+
+// Returns the index of the first rule that applies to a given object
+// (see LifecycleRule), or -1 if none does.
+func (rules LifecycleRules) Match(objName string, age, atime time.Duration) int {
+	for i, r := range rules {
+		if !strings.HasPrefix(objName, r.Prefix) {
+			continue
+		}
+		if (r.Age == 0 || age > r.Age.D()) && (r.Atime == 0 || atime > r.Atime.D()) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *StorageClassConf) String() string {
+	if len(c.Classes) == 0 {
+		return "Disabled"
+	}
+	return c.Classes.String()
+}
+
+func (c *StorageClassConf) HasEC() bool {
+	for i := range c.Classes {
+		if c.Classes[i].IsEC() {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *StorageClassConf) ValidateAsProps(args *ValidationArgs) (err error) {
+	for i := range c.Classes {
+		sc := &c.Classes[i]
+		if sc.MinSize < 0 || sc.MaxSize < 0 || (sc.MaxSize > 0 && sc.MaxSize <= sc.MinSize) {
+			return fmt.Errorf("invalid storage class %q: invalid size range", sc)
+		}
+		if !sc.IsEC() {
+			if sc.DataSlices != 0 || sc.Copies < 1 || sc.Copies > 32 {
+				return fmt.Errorf("invalid storage class %q: expecting copies in range [1, 32] or ec slices", sc)
+			}
+			continue
+		}
+		if sc.Copies > 1 {
+			return fmt.Errorf("invalid storage class %q: cannot mirror and erasure code at the same time", sc)
+		}
+		ecConf := ECConf{Enabled: true, DataSlices: sc.DataSlices, ParitySlices: sc.ParitySlices}
+		if errV := ecConf.ValidateAsProps(args); errV != nil {
+			if !IsErrSoft(errV) {
+				return fmt.Errorf("invalid storage class %q: %v", sc, errV)
+			}
+			err = errV
+		}
+	}
+	return
+}
+
+func (sc StorageClass) String() string {
+	var (
+		sizes = "any size"
+		what  = fmt.Sprintf("%d copies", sc.Copies)
+	)
+	if sc.MaxSize > 0 {
+		sizes = fmt.Sprintf("[%s, %s)", cos.B2S(sc.MinSize, 0), cos.B2S(sc.MaxSize, 0))
+	} else if sc.MinSize > 0 {
+		sizes = ">= " + cos.B2S(sc.MinSize, 0)
+	}
+	if sc.IsEC() {
+		what = fmt.Sprintf("EC %d:%d", sc.DataSlices, sc.ParitySlices)
+	}
+	return fmt.Sprintf("%s*(%s): %s", sc.Prefix, sizes, what)
+}
+
+func (sc *StorageClass) IsEC() bool { return sc.ParitySlices > 0 }
+
+// Returns mirroring configuration for the objects of this class.
+func (sc *StorageClass) MirrorConf(base *MirrorConf) *MirrorConf {
+	conf := *base
+	conf.Enabled = !sc.IsEC() && sc.Copies > 1
+	if conf.Enabled {
+		conf.Copies = sc.Copies
+	}
+	return &conf
+}
+
+// Returns erasure coding configuration for the objects of this class.
+func (sc *StorageClass) ECConf(base *ECConf) *ECConf {
+	conf := *base
+	conf.Enabled = sc.IsEC()
+	if conf.Enabled {
+		conf.DataSlices, conf.ParitySlices = sc.DataSlices, sc.ParitySlices
+	}
+	return &conf
+}
+
+func (classes StorageClasses) String() string {
+	s := make([]string, 0, len(classes))
+	for _, sc := range classes {
+		s = append(s, sc.String())
+	}
+	return strings.Join(s, " | ")
+}
+
+// Returns the first class that a given object belongs to, or nil if none.
+func (classes StorageClasses) Match(objName string, size int64) *StorageClass {
+	for i := range classes {
+		sc := &classes[i]
+		if !strings.HasPrefix(objName, sc.Prefix) || size < sc.MinSize {
+			continue
+		}
+		if sc.MaxSize == 0 || size < sc.MaxSize {
+			return sc
+		}
+	}
+	return nil
 }
 
 func (c *MirrorConf) String() string {
@@ -479,6 +829,7 @@ func DefaultBckProps(cs ...*Config) *BucketProps {
 		LRU:        c.LRU,
 		Mirror:     c.Mirror,
 		Versioning: c.Versioning,
+		Trash:      c.Trash,
 		Access:     AccessAll,
 		EC:         c.EC,
 		MDWrite:    c.MDWrite,
@@ -490,6 +841,27 @@ func (bp *BucketProps) SetProvider(provider string) {
 	bp.Provider = provider
 }
 
+// Returns true if any of the bucket's objects may be erasure coded.
+func (bp *BucketProps) ECUsed() bool { return bp.EC.Enabled || bp.StorageClass.HasEC() }
+
+// Returns the numbers of targets required to encode and restore objects - the maximum
+// across the bucket's own EC configuration and its EC storage classes.
+func (bp *BucketProps) ECRequiredTargets() (encode, restore int) {
+	if bp.EC.Enabled {
+		encode, restore = bp.EC.RequiredEncodeTargets(), bp.EC.RequiredRestoreTargets()
+	}
+	for i := range bp.StorageClass.Classes {
+		sc := &bp.StorageClass.Classes[i]
+		if !sc.IsEC() {
+			continue
+		}
+		ecConf := sc.ECConf(&bp.EC)
+		encode = cos.Max(encode, ecConf.RequiredEncodeTargets())
+		restore = cos.Max(restore, ecConf.RequiredRestoreTargets())
+	}
+	return
+}
+
 func (bp *BucketProps) Clone() *BucketProps {
 	to := *bp
 	debug.Assert(bp.Equal(&to))
@@ -525,7 +897,8 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 	var (
 		softErr        error
 		validationArgs = &ValidationArgs{Provider: bp.Provider, TargetCnt: targetCnt}
-		validators     = []PropsValidator{&bp.Cksum, &bp.LRU, &bp.Mirror, &bp.EC, &bp.Extra, bp.MDWrite}
+		validators     = []PropsValidator{&bp.Cksum, &bp.LRU, &bp.Mirror, &bp.EC, &bp.Extra, bp.MDWrite,
+			&bp.Versioning, &bp.Trash, &bp.Quota, &bp.Lifecycle, &bp.StorageClass}
 	)
 	for _, validator := range validators {
 		if err := validator.ValidateAsProps(validationArgs); err != nil {
@@ -567,12 +940,23 @@ func NewBucketPropsToUpdate(nvs cos.SimpleKVs) (props *BucketPropsToUpdate, err
 }
 
 func (msg *TransCpyBckMsg) Validate() error {
-	if msg.ID == "" {
+	if msg.ID == "" && len(msg.Pipeline) == 0 {
 		return ErrETLMissingUUID
 	}
+	if msg.ID != "" && len(msg.Pipeline) > 0 {
+		return errors.New("ETL ID and ETL pipeline are mutually exclusive")
+	}
 	return nil
 }
 
+// ETLID returns the ETL ID or, in case of ETL pipeline, its (separated) ETL IDs.
+func (msg *TransCpyBckMsg) ETLID() string {
+	if len(msg.Pipeline) > 0 {
+		return strings.Join(msg.Pipeline, ETLPipelineSepa)
+	}
+	return msg.ID
+}
+
 // Replace extension and add suffix if provided.
 func ObjNameFromBck2BckMsg(name string, msg *TransCpyBckMsg) string {
 	if msg == nil {
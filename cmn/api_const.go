@@ -23,8 +23,10 @@ const (
 	ActRebalance      = "rebalance"
 	ActResilver       = "resilver"
 	ActLRU            = "lru"
+	ActScrub          = "scrub"
 	ActCreateBck      = "create_bck"
 	ActDestroyBck     = "destroy_bck"     // destroy bucket data and metadata
+	ActRestoreBck     = "restore_bck"     // restore destroyed bucket (see TrashConf)
 	ActAddRemoteBck   = "add_remotebck"   // register (existing) remote bucket into AIS
 	ActEvictRemoteBck = "evict_remotebck" // evict remote bucket's data
 	ActMoveBck        = "move_bck"
@@ -39,8 +41,12 @@ const (
 	ActQueryObjects   = "queryobj"
 	ActInvalListCache = "invallistobjcache"
 	ActSummary        = "summary"
+	ActListTrash      = "listtrash"
+	ActQuotaUsage     = "quotausage"
 	ActRenameObject   = "renameobj"
 	ActPromote        = "promote"
+	ActPresignObject  = "presignobj"
+	ActRestoreObject  = "restoreobj"
 	ActEvictObjects   = "evictobj"
 	ActDelete         = "delete"
 	ActArchive        = "archive"
@@ -52,11 +58,13 @@ const (
 	ActElection       = "election"
 	ActPutCopies      = "putcopies"
 	ActMakeNCopies    = "makencopies"
+	ActLifecycle      = "lifecycle"
 	ActLoadLomCache   = "loadlomcache"
 	ActECGet          = "ecget"    // erasure decode objects
 	ActECPut          = "ecput"    // erasure encode objects
 	ActECRespond      = "ecresp"   // respond to other targets' EC requests
 	ActECEncode       = "ecencode" // erasure code a bucket
+	ActReencode       = "reencode" // re-mirror and re-erasure code a bucket as per its storage classes
 	ActStartGFN       = "metasync_start_gfn"
 	ActAttach         = "attach"
 	ActDetach         = "detach"
@@ -80,8 +88,10 @@ const (
 	ActMountpathRemove  = "remove"
 
 	// Actions on xactions
-	ActXactStop  = Stop
-	ActXactStart = Start
+	ActXactStop   = Stop
+	ActXactStart  = Start
+	ActXactPause  = "pause"
+	ActXactResume = "resume"
 
 	// auxiliary
 	ActTransient = "transient" // transient - in-memory only
@@ -189,6 +199,11 @@ const (
 	// Object related query params.
 	URLParamAppendType   = "append_type"
 	URLParamAppendHandle = "append_handle"
+	URLParamObjVersion   = "version" // previous (non-current) version of an object (GET, HEAD)
+
+	// Presigned object URL: expiration time (Unix seconds) and signature.
+	URLParamPresignExpires = "presign_expires"
+	URLParamPresignSig     = "presign_sig"
 
 	// HTTP bucket support.
 	URLParamOrigURL = "original_url"
@@ -235,8 +250,9 @@ const (
 
 // URLParamAppendType enum
 const (
-	AppendOp = "append"
-	FlushOp  = "flush"
+	AppendOp     = "append"
+	FlushOp      = "flush"
+	AppendArchOp = "arch" // append file to existing archive (see URLParamArchpath)
 )
 
 // URLParamTaskAction enum
@@ -265,6 +281,7 @@ const (
 	GetWhatSysInfo       = "sysinfo"
 	GetWhatTargetIPs     = "target_ips"
 	GetWhatLog           = "log"
+	GetWhatRebPlan       = "rebplan"
 )
 
 // Internal "what" values.
@@ -311,6 +328,8 @@ const (
 
 	// Flags
 	EntryIsCached = 1 << (EntryStatusBits + 1)
+	EntryInArch   = 1 << (EntryStatusBits + 2)
+	EntryIsVer    = 1 << (EntryStatusBits + 3) // previous (non-current) version of an object
 )
 
 // List objects default page size
@@ -344,6 +363,7 @@ const (
 	Users     = "users"    // AuthN
 	Clusters  = "clusters" // AuthN
 	Roles     = "roles"    // AuthN
+	S3Creds   = "s3creds"  // AuthN
 	Query     = "query"
 	IC        = "ic" // information center
 
@@ -387,6 +407,9 @@ const (
 	ETLObject = "object"
 	ETLStop   = Stop
 	ETLHealth = "health"
+
+	// separates ETL IDs of an ETL pipeline (e.g., in the URLParamUUID query)
+	ETLPipelineSepa = ","
 )
 
 const (
@@ -400,6 +423,21 @@ const (
 	CompressNever  = "never"
 )
 
+// LRU eviction policy enum (see LRUConf.Policy)
+const (
+	LRUPolicyLRU = "lru" // least recently used first (default)
+	LRUPolicyLFU = "lfu" // least frequently used first; ties are broken by access time
+	LRUPolicyGDS = "gds" // size-weighted GreedyDual: larger and colder objects first
+)
+
+// Lifecycle rule actions (see LifecycleRule)
+const (
+	LifecycleDelete  = "delete"  // delete the object (for remote buckets - from the backend as well)
+	LifecycleEvict   = "evict"   // evict the object from the cluster (remote buckets only)
+	LifecycleMirror1 = "mirror1" // remove all mirrored copies except the object itself
+	LifecycleEC      = "ec"      // erasure code the object (if not yet encoded) and remove mirrored copies
+)
+
 // timeouts for intra-cluster requests
 const (
 	DefaultTimeout = time.Duration(-1)
@@ -422,4 +460,7 @@ const (
 var (
 	SupportedWritePolicy = []string{string(WriteImmediate), string(WriteDelayed), string(WriteNever)}
 	SupportedCompression = []string{CompressNever, CompressAlways}
+	SupportedLRUPolicies = []string{LRUPolicyLRU, LRUPolicyLFU, LRUPolicyGDS}
+
+	SupportedLifecycleActions = []string{LifecycleDelete, LifecycleEvict, LifecycleMirror1, LifecycleEC}
 )
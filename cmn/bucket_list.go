@@ -17,15 +17,17 @@ import "github.com/NVIDIA/aistore/cmn/cos"
 // 0-2: objects status, all statuses are mutually exclusive, so it can hold up
 //      to 8 different statuses. Now only OK=0, Moved=1, Deleted=2 are supported
 // 3:   CheckExists (for remote bucket it shows if the object in present in AIS)
+// 4:   InArch (the entry is a file inside an archived object, see SelectArchDir)
 type BucketEntry struct {
-	Name      string `json:"name" msg:"n"`                            // name of the object - NOTE: Does not include the bucket name.
-	Size      int64  `json:"size,string,omitempty" msg:"s,omitempty"` // size in bytes
-	Checksum  string `json:"checksum,omitempty" msg:"cs,omitempty"`   // checksum
-	Atime     string `json:"atime,omitempty" msg:"a,omitempty"`       // formatted as per SelectMsg.TimeFormat
-	Version   string `json:"version,omitempty" msg:"v,omitempty"`     // version/generation ID. In GCP it is int64, in AWS it is a string
-	TargetURL string `json:"target_url,omitempty" msg:"t,omitempty"`  // URL of target which has the entry
-	Copies    int16  `json:"copies,omitempty" msg:"c,omitempty"`      // ## copies (non-replicated = 1)
-	Flags     uint16 `json:"flags,omitempty" msg:"f,omitempty"`       // object flags, like CheckExists, IsMoved etc
+	Name      string `json:"name" msg:"n"`                              // name of the object - NOTE: Does not include the bucket name.
+	Size      int64  `json:"size,string,omitempty" msg:"s,omitempty"`   // size in bytes
+	Checksum  string `json:"checksum,omitempty" msg:"cs,omitempty"`     // checksum
+	Atime     string `json:"atime,omitempty" msg:"a,omitempty"`         // formatted as per SelectMsg.TimeFormat
+	Version   string `json:"version,omitempty" msg:"v,omitempty"`       // version/generation ID. In GCP it is int64, in AWS it is a string
+	TargetURL string `json:"target_url,omitempty" msg:"t,omitempty"`    // URL of target which has the entry
+	Copies    int16  `json:"copies,omitempty" msg:"c,omitempty"`        // ## copies (non-replicated = 1)
+	Flags     uint16 `json:"flags,omitempty" msg:"f,omitempty"`         // object flags, like CheckExists, IsMoved etc
+	Offset    int64  `json:"offset,string,omitempty" msg:"o,omitempty"` // archived file: offset of its data in the archive
 }
 
 func (be *BucketEntry) CheckExists() bool {
@@ -36,6 +38,19 @@ func (be *BucketEntry) SetExists() {
 	be.Flags |= EntryIsCached
 }
 
+func (be *BucketEntry) IsInsideArch() bool {
+	return be.Flags&EntryInArch != 0
+}
+
+func (be *BucketEntry) IsVersion() bool {
+	return be.Flags&EntryIsVer != 0
+}
+
+// Name of the list entry that represents a previous version of the object
+func VersionEntryName(objName, ver string) string {
+	return objName + "?" + URLParamObjVersion + "=" + ver
+}
+
 func (be *BucketEntry) IsStatusOK() bool {
 	return be.Flags&EntryStatusMask == 0
 }
@@ -43,7 +58,7 @@ func (be *BucketEntry) IsStatusOK() bool {
 func (be *BucketEntry) String() string { return "{" + be.Name + "}" }
 
 func (be *BucketEntry) CopyWithProps(propsSet cos.StringSet) (ne *BucketEntry) {
-	ne = &BucketEntry{Name: be.Name}
+	ne = &BucketEntry{Name: be.Name, Offset: be.Offset}
 	if propsSet.Contains(GetPropsSize) {
 		ne.Size = be.Size
 	}
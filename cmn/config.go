@@ -120,6 +120,7 @@ type (
 		Resilver    ResilverConf    `json:"resilver"`
 		Cksum       CksumConf       `json:"checksum"`
 		Versioning  VersionConf     `json:"versioning" allow:"cluster"`
+		Trash       TrashConf       `json:"trash" allow:"cluster"`
 		Net         NetConf         `json:"net"`
 		FSHC        FSHCConf        `json:"fshc"`
 		Auth        AuthConf        `json:"auth"`
@@ -127,6 +128,7 @@ type (
 		Downloader  DownloaderConf  `json:"downloader"`
 		DSort       DSortConf       `json:"distributed_sort"`
 		Compression CompressionConf `json:"compression"`
+		ETL         ETLConf         `json:"etl" allow:"cluster"`
 		MDWrite     MDWritePolicy   `json:"md_write"`
 		LastUpdated string          `json:"lastupdate_time"`
 		UUID        string          `json:"uuid"`                  // immutable
@@ -171,6 +173,7 @@ type (
 		Resilver    *ResilverConfToUpdate    `json:"resilver,omitempty"`
 		Cksum       *CksumConfToUpdate       `json:"checksum,omitempty"`
 		Versioning  *VersionConfToUpdate     `json:"versioning,omitempty"`
+		Trash       *TrashConfToUpdate       `json:"trash,omitempty"`
 		Net         *NetConfToUpdate         `json:"net,omitempty"`
 		FSHC        *FSHCConfToUpdate        `json:"fshc,omitempty"`
 		Auth        *AuthConfToUpdate        `json:"auth,omitempty"`
@@ -178,6 +181,7 @@ type (
 		Downloader  *DownloaderConfToUpdate  `json:"downloader,omitempty"`
 		DSort       *DSortConfToUpdate       `json:"distributed_sort,omitempty"`
 		Compression *CompressionConfToUpdate `json:"compression,omitempty"`
+		ETL         *ETLConfToUpdate         `json:"etl,omitempty"`
 		MDWrite     *MDWritePolicy           `json:"md_write,omitempty"`
 		Proxy       *ProxyConfToUpdate       `json:"proxy,omitempty"`
 
@@ -330,6 +334,13 @@ type (
 		// CapacityUpdTimeStr denotes the frequency at which AIStore updates local capacity utilization
 		CapacityUpdTime cos.Duration `json:"capacity_upd_time"`
 
+		// Policy determines the order in which objects get evicted:
+		// enum { LRUPolicyLRU (default), LRUPolicyLFU, LRUPolicyGDS }
+		Policy string `json:"policy"`
+
+		// Priority: buckets with lower priority get evicted first
+		Priority int `json:"priority"`
+
 		// Enabled: LRU will only run when set to true
 		Enabled bool `json:"enabled"`
 	}
@@ -339,6 +350,8 @@ type (
 		OOS             *int64        `json:"out_of_space,omitempty"`
 		DontEvictTime   *cos.Duration `json:"dont_evict_time,omitempty"`
 		CapacityUpdTime *cos.Duration `json:"capacity_upd_time,omitempty"`
+		Policy          *string       `json:"policy,omitempty"`
+		Priority        *int          `json:"priority,omitempty"`
 		Enabled         *bool         `json:"enabled,omitempty"`
 	}
 
@@ -362,21 +375,37 @@ type (
 		Quiesce       cos.Duration `json:"quiescent"`       // max wait for no-obj before next stage/batch
 		Compression   string       `json:"compression"`     // see CompressAlways, etc. enum
 		Multiplier    uint8        `json:"multiplier"`      // stream-bundle-and-jogger multiplier
+		Bwidth        BwidthConf   `json:"bwidth"`          // bandwidth limits
 		Enabled       bool         `json:"enabled"`         // true=auto-rebalance | manual rebalancing
 	}
 	RebalanceConfToUpdate struct {
-		DestRetryTime *cos.Duration `json:"dest_retry_time,omitempty"`
-		Quiesce       *cos.Duration `json:"quiescent,omitempty"`
-		Compression   *string       `json:"compression,omitempty"`
-		Multiplier    *uint8        `json:"multiplier,omitempty"`
-		Enabled       *bool         `json:"enabled,omitempty"`
+		DestRetryTime *cos.Duration       `json:"dest_retry_time,omitempty"`
+		Quiesce       *cos.Duration       `json:"quiescent,omitempty"`
+		Compression   *string             `json:"compression,omitempty"`
+		Multiplier    *uint8              `json:"multiplier,omitempty"`
+		Bwidth        *BwidthConfToUpdate `json:"bwidth,omitempty"`
+		Enabled       *bool               `json:"enabled,omitempty"`
 	}
 
 	ResilverConf struct {
-		Enabled bool `json:"enabled"` // true=auto-resilver | manual resilvering
+		Bwidth  BwidthConf `json:"bwidth"`  // bandwidth limits
+		Enabled bool       `json:"enabled"` // true=auto-resilver | manual resilvering
 	}
 	ResilverConfToUpdate struct {
-		Enabled *bool `json:"enabled,omitempty"` // true=auto-resilver | manual resilvering
+		Bwidth  *BwidthConfToUpdate `json:"bwidth,omitempty"`
+		Enabled *bool               `json:"enabled,omitempty"` // true=auto-resilver | manual resilvering
+	}
+
+	// rebalance and resilver bandwidth limits, in bytes per second (zero = unlimited)
+	BwidthConf struct {
+		Target   int64 `json:"target"`    // per target
+		Mpath    int64 `json:"mountpath"` // per mountpath (that is being read)
+		Adaptive bool  `json:"adaptive"`  // back off when drives are busy or GET latency goes up
+	}
+	BwidthConfToUpdate struct {
+		Target   *int64 `json:"target,omitempty"`
+		Mpath    *int64 `json:"mountpath,omitempty"`
+		Adaptive *bool  `json:"adaptive,omitempty"`
 	}
 
 	CksumConf struct {
@@ -413,10 +442,30 @@ type (
 
 		// Validate object version upon warm GET.
 		ValidateWarmGet bool `json:"validate_warm_get"`
+
+		// Version history (ais buckets only): the number of previous versions
+		// to retain when objects get overwritten (0 - unlimited if keep_ttl is set)
+		KeepVersions int `json:"keep_versions"`
+
+		// Version history: retain previous versions for this long after they
+		// get overwritten (0 - indefinitely if keep_versions is set)
+		KeepTTL cos.Duration `json:"keep_ttl"`
 	}
 	VersionConfToUpdate struct {
-		Enabled         *bool `json:"enabled,omitempty"`
-		ValidateWarmGet *bool `json:"validate_warm_get,omitempty"`
+		Enabled         *bool         `json:"enabled,omitempty"`
+		ValidateWarmGet *bool         `json:"validate_warm_get,omitempty"`
+		KeepVersions    *int          `json:"keep_versions,omitempty"`
+		KeepTTL         *cos.Duration `json:"keep_ttl,omitempty"`
+	}
+
+	TrashConf struct {
+		// Delete retention (ais buckets only): deleted objects and destroyed
+		// buckets are kept in the trash for this long and can be restored
+		// (0 - delete immediately)
+		Retention cos.Duration `json:"retention"`
+	}
+	TrashConfToUpdate struct {
+		Retention *cos.Duration `json:"retention,omitempty"`
 	}
 
 	TestfspathConf struct {
@@ -546,6 +595,20 @@ type (
 		Checksum     *bool `json:"checksum,omitempty"`
 	}
 
+	ETLConf struct {
+		// Run ETL transformers as local processes when the cluster is deployed
+		// without Kubernetes (NOTE: lets anyone allowed to initialize ETL run
+		// arbitrary commands on every target)
+		LocalRuntime bool `json:"local_runtime"`
+
+		// Local runtime: executables allowed to run (empty - any)
+		LocalAllowlist []string `json:"local_allowlist"`
+	}
+	ETLConfToUpdate struct {
+		LocalRuntime   *bool     `json:"local_runtime,omitempty"`
+		LocalAllowlist *[]string `json:"local_allowlist,omitempty"`
+	}
+
 	// obsolete; TODO: remove with the next meta-version update
 	ReplicationConf struct {
 		OnColdGet     bool `json:"on_cold_get"`
@@ -690,6 +753,7 @@ var (
 	_ Validator = (*MirrorConf)(nil)
 	_ Validator = (*ECConf)(nil)
 	_ Validator = (*VersionConf)(nil)
+	_ Validator = (*TrashConf)(nil)
 	_ Validator = (*KeepaliveConf)(nil)
 	_ Validator = (*PeriodConf)(nil)
 	_ Validator = (*TimeoutConf)(nil)
@@ -703,8 +767,13 @@ var (
 
 	_ PropsValidator = (*CksumConf)(nil)
 	_ PropsValidator = (*LRUConf)(nil)
+	_ PropsValidator = (*QuotaConf)(nil)
+	_ PropsValidator = (*LifecycleConf)(nil)
+	_ PropsValidator = (*StorageClassConf)(nil)
 	_ PropsValidator = (*MirrorConf)(nil)
 	_ PropsValidator = (*ECConf)(nil)
+	_ PropsValidator = (*VersionConf)(nil)
+	_ PropsValidator = (*TrashConf)(nil)
 
 	_ json.Marshaler   = (*BackendConf)(nil)
 	_ json.Unmarshaler = (*BackendConf)(nil)
@@ -930,11 +999,25 @@ func (c *DiskConf) Validate() (err error) {
 func (c *LRUConf) Validate() (err error) {
 	lwm, hwm, oos := c.LowWM, c.HighWM, c.OOS
 	if lwm <= 0 || hwm < lwm || oos < hwm || oos > 100 {
-		err = fmt.Errorf("invalid lru (lwm, hwm, oos) configuration (%d, %d, %d)", lwm, hwm, oos)
+		return fmt.Errorf("invalid lru (lwm, hwm, oos) configuration (%d, %d, %d)", lwm, hwm, oos)
+	}
+	if c.Policy != "" && !cos.StringInSlice(c.Policy, SupportedLRUPolicies) {
+		err = fmt.Errorf("invalid lru.policy %q (expecting one of: %v)", c.Policy, SupportedLRUPolicies)
 	}
 	return
 }
 
+// Returns the configured eviction policy (LRUPolicyLRU if not specified).
+func (c *LRUConf) EvictPolicy() string {
+	if c.Policy == "" {
+		return LRUPolicyLRU
+	}
+	return c.Policy
+}
+
+// Whether the policy requires counting object accesses (see cluster.LOM.IncAccessCnt).
+func (c *LRUConf) CountsAccess() bool { return c.Policy == LRUPolicyLFU }
+
 func (c *LRUConf) ValidateAsProps(_ *ValidationArgs) (err error) {
 	if !c.Enabled {
 		return nil
@@ -958,9 +1041,31 @@ func (c *VersionConf) Validate() error {
 	if !c.Enabled && c.ValidateWarmGet {
 		return errors.New("versioning.validate_warm_get requires versioning to be enabled")
 	}
+	if c.KeepVersions < 0 || c.KeepTTL < 0 {
+		return fmt.Errorf("invalid versioning.keep_versions=%d or versioning.keep_ttl=%v (expected non-negative)",
+			c.KeepVersions, c.KeepTTL)
+	}
+	if !c.Enabled && c.KeepsHistory() {
+		return errors.New("versioning.keep_versions and versioning.keep_ttl require versioning to be enabled")
+	}
 	return nil
 }
 
+func (c *VersionConf) ValidateAsProps(_ *ValidationArgs) error { return c.Validate() }
+
+// KeepsHistory returns true if previous versions of objects are retained
+// (NOTE: applies only to ais buckets)
+func (c VersionConf) KeepsHistory() bool { return c.KeepVersions > 0 || c.KeepTTL > 0 }
+
+func (c *TrashConf) Validate() error {
+	if c.Retention < 0 {
+		return fmt.Errorf("invalid trash.retention=%v (expected non-negative)", c.Retention)
+	}
+	return nil
+}
+
+func (c *TrashConf) ValidateAsProps(_ *ValidationArgs) error { return c.Validate() }
+
 func (c *MirrorConf) Validate() error {
 	if c.UtilThresh < 0 || c.UtilThresh > 100 {
 		return fmt.Errorf("invalid mirror.util_thresh: %v (expected value in range [0, 100])",
@@ -1031,12 +1136,22 @@ func (c *ECConf) ValidateAsProps(args *ValidationArgs) error {
 	return nil
 }
 
-func (*TimeoutConf) Validate() error    { return nil }
-func (*ClientConf) Validate() error     { return nil }
-func (*RebalanceConf) Validate() error  { return nil }
-func (*ResilverConf) Validate() error   { return nil }
-func (*PeriodConf) Validate() error     { return nil }
-func (*DownloaderConf) Validate() error { return nil }
+func (*TimeoutConf) Validate() error     { return nil }
+func (*ClientConf) Validate() error      { return nil }
+func (c *RebalanceConf) Validate() error { return c.Bwidth.Validate() }
+func (c *ResilverConf) Validate() error  { return c.Bwidth.Validate() }
+func (*PeriodConf) Validate() error      { return nil }
+func (*DownloaderConf) Validate() error  { return nil }
+
+func (c *BwidthConf) Validate() error {
+	if c.Target < 0 || c.Mpath < 0 {
+		return fmt.Errorf("invalid bandwidth limit (target %d, mountpath %d): expecting non-negative number of bytes/s",
+			c.Target, c.Mpath)
+	}
+	return nil
+}
+
+func (c *BwidthConf) IsSet() bool { return c.Target > 0 || c.Mpath > 0 || c.Adaptive }
 
 func (c *KeepaliveConf) Validate() (err error) {
 	if !validKeepaliveType(c.Proxy.Name) {
@@ -4,7 +4,14 @@
  */
 package cos
 
-import "strings"
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+)
 
 // supported archive types (file extensions)
 const (
@@ -24,6 +31,18 @@ type (
 	ErrUnknownMime struct {
 		detail string
 	}
+
+	// ArchEntry describes a file inside an archive
+	ArchEntry struct {
+		Name   string
+		Size   int64
+		Offset int64 // start of the file's data (compressed tar: in the uncompressed stream)
+	}
+
+	rcounter struct {
+		r io.Reader
+		n int64
+	}
 )
 
 func (e *ErrUnknownMime) Error() string            { return "unknown mime type \"" + e.detail + "\"" }
@@ -53,3 +72,72 @@ func Mime(mime, filename string) (ext string, err error) {
 	err = NewUnknownMimeError(filename)
 	return
 }
+
+// List regular files in an archive - one of the ArchExtensions (`mime`) - in their
+// respective archive order
+func ListArch(fh *os.File, mime string, size int64) (entries []ArchEntry, err error) {
+	switch mime {
+	case ExtTar:
+		return listTar(fh, func() (int64, error) { return fh.Seek(0, io.SeekCurrent) })
+	case ExtTgz, ExtTarTgz:
+		var gzr *gzip.Reader
+		if gzr, err = gzip.NewReader(fh); err != nil {
+			return
+		}
+		rc := &rcounter{r: gzr}
+		entries, err = listTar(rc, func() (int64, error) { return rc.n, nil })
+		gzr.Close()
+		return
+	case ExtZip:
+		return listZip(fh, size)
+	default:
+		return nil, NewUnknownMimeError(mime)
+	}
+}
+
+func listTar(reader io.Reader, offset func() (int64, error)) (entries []ArchEntry, err error) {
+	tr := tar.NewReader(reader)
+	for {
+		var hdr *tar.Header
+		if hdr, err = tr.Next(); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		entry := ArchEntry{Name: hdr.Name, Size: hdr.Size}
+		if entry.Offset, err = offset(); err != nil {
+			return
+		}
+		entries = append(entries, entry)
+	}
+}
+
+func listZip(readerAt io.ReaderAt, size int64) (entries []ArchEntry, err error) {
+	var zr *zip.Reader
+	if zr, err = zip.NewReader(readerAt, size); err != nil {
+		return
+	}
+	entries = make([]ArchEntry, 0, len(zr.File))
+	for _, f := range zr.File {
+		finfo := f.FileInfo()
+		if finfo.IsDir() {
+			continue
+		}
+		entry := ArchEntry{Name: f.FileHeader.Name, Size: finfo.Size()}
+		if entry.Offset, err = f.DataOffset(); err != nil {
+			return
+		}
+		entries = append(entries, entry)
+	}
+	return
+}
+
+func (rc *rcounter) Read(b []byte) (n int, err error) {
+	n, err = rc.r.Read(b)
+	rc.n += int64(n)
+	return
+}
@@ -119,6 +119,9 @@ type (
 	// can use some heuristics to improve performance but can result in not
 	// using `buffer` provided in `io.CopyBuffer`. See: https://golang.org/doc/go1.15#os.
 	WriterOnly struct{ io.Writer }
+
+	// WriterCounter discards all writes while counting the bytes written.
+	WriterCounter struct{ N int64 }
 )
 
 // interface guard
@@ -396,6 +399,11 @@ func (mw *WriterMulti) Write(b []byte) (n int, err error) {
 	return
 }
 
+func (wc *WriterCounter) Write(b []byte) (int, error) {
+	wc.N += int64(len(b))
+	return len(b), nil
+}
+
 ///////////////////////
 // misc file and dir //
 ///////////////////////
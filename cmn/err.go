@@ -53,6 +53,11 @@ type (
 		usedPct        int32
 		oos            bool
 	}
+	ErrBucketQuotaExceeded struct {
+		bck   Bck
+		quota QuotaConf
+		usage QuotaUsage
+	}
 	ErrBucketAccessDenied struct{ errAccessDenied }
 	ErrObjectAccessDenied struct{ errAccessDenied }
 	errAccessDenied       struct {
@@ -132,8 +137,7 @@ var (
 	ErrQuiesceTimeout = errors.New("timed-out waiting for quiescence")
 
 	ErrETLMissingUUID = errors.New("ETL UUID can't be empty")
-	ErrETLOnlyOne     = errors.New(
-		"cannot run more than one etl, please stop the current ETL before starting another")
+	ErrETLStarting    = errors.New("another ETL is being started, please retry later")
 )
 
 func IsErrAborted(err error) bool {
@@ -307,6 +311,14 @@ func (e *ErrCapacityExceeded) Error() string {
 		e.usedPct, e.highWM, suffix)
 }
 
+func NewErrBucketQuotaExceeded(bck Bck, quota *QuotaConf, usage *QuotaUsage) *ErrBucketQuotaExceeded {
+	return &ErrBucketQuotaExceeded{bck: bck, quota: *quota, usage: *usage}
+}
+
+func (e *ErrBucketQuotaExceeded) Error() string {
+	return fmt.Sprintf("bucket %s: quota exceeded (%s)", e.bck, e.quota.Describe(&e.usage))
+}
+
 func (e *ErrInvalidCksum) Error() string {
 	return fmt.Sprintf("checksum: expected [%s], actual [%s]", e.expectedHash, e.actualHash)
 }
@@ -16,10 +16,12 @@ const (
 	BmdFname         = ".ais.bmd"         // bmd persistent file basename
 	BmdPreviousFname = BmdFname + ".prev" // bmd previous version
 	VmdFname         = ".ais.vmd"         // vmd persistent file basename
+	ScrubFname       = ".ais.scrub"       // scrub progress (per mountpath)
 
 	ShutdownMarker      = ".ais.shutdown"
 	MarkersDirName      = ".ais.markers"
 	ResilverMarker      = "resilver"
 	RebalanceMarker     = "rebalance"
+	RebalanceCkpt       = "rebalance.ckpt" // rebalance progress (see reb/ckpt.go)
 	NodeRestartedMarker = "node_restarted"
 )
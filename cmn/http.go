@@ -37,6 +37,7 @@ const (
 	HdrAccept                = "Accept"
 	HdrLocation              = "Location"
 	HdrETag                  = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Hdrs/ETag
+	HdrLastModified          = "Last-Modified"
 	HdrError                 = "Hdr-Error"
 )
 
@@ -46,6 +47,8 @@ const (
 	ContentMsgPack = "application/msgpack"
 	ContentXML     = "application/xml"
 	ContentBinary  = "application/octet-stream"
+
+	ContentMultiRange = "multipart/byteranges" // Ref: https://datatracker.ietf.org/doc/html/rfc7233#appendix-A
 )
 
 type (
@@ -0,0 +1,76 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/archive"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestListArch(t *testing.T) {
+	const (
+		numFiles = 10
+		fileSize = 1000
+	)
+	names := make([]string, numFiles)
+	for i := range names {
+		names[i] = fmt.Sprintf("dir%d/%d.txt", i%3, i)
+	}
+	for _, ext := range []string{cos.ExtTar, cos.ExtTarTgz, cos.ExtZip} {
+		t.Run(ext, func(t *testing.T) {
+			var (
+				err      error
+				archName = filepath.Join(t.TempDir(), "arch"+ext)
+			)
+			if ext == cos.ExtZip {
+				err = archive.CreateZipWithRandomFiles(archName, numFiles, fileSize, names)
+			} else {
+				err = archive.CreateTarWithRandomFiles(archName, numFiles, fileSize, false, nil, names)
+			}
+			tassert.CheckFatal(t, err)
+
+			fh, err := os.Open(archName)
+			tassert.CheckFatal(t, err)
+			defer fh.Close()
+			finfo, err := fh.Stat()
+			tassert.CheckFatal(t, err)
+
+			entries, err := cos.ListArch(fh, ext, finfo.Size())
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, len(entries) == numFiles, "expected %d entries, got %d", numFiles, len(entries))
+			for i, entry := range entries {
+				tassert.Errorf(t, entry.Name == names[i], "expected %q, got %q", names[i], entry.Name)
+				tassert.Errorf(t, entry.Size == fileSize, "%s: expected size %d, got %d", entry.Name, fileSize, entry.Size)
+				tassert.Errorf(t, entry.Offset > 0, "%s: invalid offset %d", entry.Name, entry.Offset)
+			}
+			if ext != cos.ExtTar {
+				return
+			}
+			// uncompressed tar: offsets point at the files' data
+			var (
+				tr        = tar.NewReader(io.NewSectionReader(fh, 0, finfo.Size()))
+				got, want = make([]byte, fileSize), make([]byte, fileSize)
+			)
+			for _, entry := range entries {
+				_, err := tr.Next()
+				tassert.CheckFatal(t, err)
+				_, err = io.ReadFull(tr, want)
+				tassert.CheckFatal(t, err)
+				_, err = fh.ReadAt(got, entry.Offset)
+				tassert.CheckFatal(t, err)
+				tassert.Errorf(t, bytes.Equal(got, want), "%s: content at offset %d differs", entry.Name, entry.Offset)
+			}
+		})
+	}
+}
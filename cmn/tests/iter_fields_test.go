@@ -5,6 +5,8 @@
 package tests
 
 import (
+	"time"
+
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -82,6 +84,17 @@ var _ = Describe("IterFields", func() {
 
 					"versioning.enabled":           false,
 					"versioning.validate_warm_get": false,
+					"versioning.keep_versions":     0,
+					"versioning.keep_ttl":          cos.Duration(0),
+
+					"trash.retention": cos.Duration(0),
+
+					"quota.max_size":    int64(0),
+					"quota.max_objects": int64(0),
+
+					"lifecycle.rules": cmn.LifecycleRules(nil),
+
+					"storage_class.classes": cmn.StorageClasses(nil),
 
 					"checksum.type":              cos.ChecksumXXHash,
 					"checksum.validate_warm_get": false,
@@ -95,6 +108,8 @@ var _ = Describe("IterFields", func() {
 					"lru.out_of_space":      int64(0),
 					"lru.dont_evict_time":   cos.Duration(0),
 					"lru.capacity_upd_time": cos.Duration(0),
+					"lru.policy":            "",
+					"lru.priority":          0,
 
 					"extra.aws.cloud_region": "us-central",
 
@@ -136,6 +151,17 @@ var _ = Describe("IterFields", func() {
 
 					"versioning.enabled":           (*bool)(nil),
 					"versioning.validate_warm_get": (*bool)(nil),
+					"versioning.keep_versions":     (*int)(nil),
+					"versioning.keep_ttl":          (*cos.Duration)(nil),
+
+					"trash.retention": (*cos.Duration)(nil),
+
+					"quota.max_size":    (*int64)(nil),
+					"quota.max_objects": (*int64)(nil),
+
+					"lifecycle.rules": (*cmn.LifecycleRules)(nil),
+
+					"storage_class.classes": (*cmn.StorageClasses)(nil),
 
 					"checksum.type":              api.String(cos.ChecksumXXHash),
 					"checksum.validate_warm_get": (*bool)(nil),
@@ -149,6 +175,8 @@ var _ = Describe("IterFields", func() {
 					"lru.dont_evict_time":   (*cos.Duration)(nil),
 					"lru.capacity_upd_time": (*cos.Duration)(nil),
 					"lru.out_of_space":      (*int64)(nil),
+					"lru.policy":            (*string)(nil),
+					"lru.priority":          (*int)(nil),
 
 					"access":   api.AccessAttrs(1024),
 					"md_write": api.MDWritePolicy("never"),
@@ -275,6 +303,8 @@ var _ = Describe("IterFields", func() {
 
 					"checksum.type": cos.ChecksumXXHash,
 
+					"lifecycle.rules": `[{"prefix": "tmp/", "age": "24h", "action": "delete"}]`, // type == JSON list
+
 					"access":   "12", // type == uint64
 					"md_write": "never",
 				},
@@ -282,6 +312,11 @@ var _ = Describe("IterFields", func() {
 					Versioning: &cmn.VersionConfToUpdate{
 						Enabled: api.Bool(false),
 					},
+					Lifecycle: &cmn.LifecycleConfToUpdate{
+						Rules: &cmn.LifecycleRules{
+							{Prefix: "tmp/", Age: cos.Duration(24 * time.Hour), Action: cmn.LifecycleDelete},
+						},
+					},
 					Mirror: &cmn.MirrorConfToUpdate{
 						Enabled: api.Bool(true),
 						Copies:  api.Int64(120),
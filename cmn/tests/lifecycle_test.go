@@ -0,0 +1,64 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestLifecycleRulesMatch(t *testing.T) {
+	rules := cmn.LifecycleRules{
+		{Prefix: "tmp/", Age: cos.Duration(time.Hour), Action: cmn.LifecycleDelete},
+		{Prefix: "logs/", Age: cos.Duration(time.Hour), Atime: cos.Duration(time.Minute), Action: cmn.LifecycleEC},
+		{Atime: cos.Duration(24 * time.Hour), Action: cmn.LifecycleMirror1},
+	}
+	tests := []struct {
+		objName    string
+		age, atime time.Duration
+		expected   int
+	}{
+		{"tmp/a", 2 * time.Hour, 0, 0},
+		{"tmp/a", time.Hour, 0, -1}, // must be strictly older
+		{"tmp/a", 0, 48 * time.Hour, 2},
+		{"logs/a", 2 * time.Hour, 2 * time.Minute, 1},
+		{"logs/a", 2 * time.Hour, time.Second, -1}, // both conditions must hold
+		{"logs/a", time.Minute, 2 * time.Minute, -1},
+		{"logs/a", 2 * time.Hour, 48 * time.Hour, 1}, // first matching rule wins
+		{"data/a", 48 * time.Hour, time.Hour, -1},
+		{"data/a", 0, 48 * time.Hour, 2},
+	}
+	for _, test := range tests {
+		i := rules.Match(test.objName, test.age, test.atime)
+		tassert.Errorf(t, i == test.expected, "%s (age %v, atime %v): expected rule %d, got %d",
+			test.objName, test.age, test.atime, test.expected, i)
+	}
+	tassert.Errorf(t, cmn.LifecycleRules(nil).Match("a", time.Hour, time.Hour) == -1, "expected no match")
+}
+
+func TestLifecycleValidate(t *testing.T) {
+	tests := []struct {
+		rule     cmn.LifecycleRule
+		provider string
+		valid    bool
+	}{
+		{cmn.LifecycleRule{Age: cos.Duration(time.Hour), Action: cmn.LifecycleDelete}, cmn.ProviderAIS, true},
+		{cmn.LifecycleRule{Atime: cos.Duration(time.Hour), Action: cmn.LifecycleEvict}, cmn.ProviderAmazon, true},
+		{cmn.LifecycleRule{Atime: cos.Duration(time.Hour), Action: cmn.LifecycleEvict}, cmn.ProviderAIS, false},
+		{cmn.LifecycleRule{Age: cos.Duration(time.Hour), Action: "archive"}, cmn.ProviderAIS, false},
+		{cmn.LifecycleRule{Prefix: "tmp/", Action: cmn.LifecycleDelete}, cmn.ProviderAIS, false},
+		{cmn.LifecycleRule{Age: -1, Action: cmn.LifecycleMirror1}, cmn.ProviderAIS, false},
+	}
+	for _, test := range tests {
+		conf := cmn.LifecycleConf{Rules: cmn.LifecycleRules{test.rule}}
+		err := conf.ValidateAsProps(&cmn.ValidationArgs{Provider: test.provider})
+		tassert.Errorf(t, (err == nil) == test.valid, "%s (%s): expected valid=%t, got %v",
+			test.rule, test.provider, test.valid, err)
+	}
+}
@@ -0,0 +1,91 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestStorageClassesMatch(t *testing.T) {
+	classes := cmn.StorageClasses{
+		{Prefix: "hot/", Copies: 3},
+		{MaxSize: cos.MiB, Copies: 2},
+		{MinSize: cos.MiB, MaxSize: cos.GiB, DataSlices: 4, ParitySlices: 2},
+	}
+	tests := []struct {
+		objName  string
+		size     int64
+		expected int
+	}{
+		{"hot/a", cos.TiB, 0}, // first matching class wins
+		{"a", 0, 1},
+		{"a", cos.MiB - 1, 1},
+		{"a", cos.MiB, 2}, // [min_size, max_size)
+		{"a", cos.GiB - 1, 2},
+		{"a", cos.GiB, -1},
+	}
+	for _, test := range tests {
+		sc := classes.Match(test.objName, test.size)
+		if test.expected < 0 {
+			tassert.Errorf(t, sc == nil, "%s (%d): expected no class, got %q", test.objName, test.size, sc)
+			continue
+		}
+		tassert.Errorf(t, sc == &classes[test.expected], "%s (%d): expected class %q, got %v", test.objName,
+			test.size, classes[test.expected], sc)
+	}
+	tassert.Errorf(t, cmn.StorageClasses(nil).Match("a", 0) == nil, "expected no class")
+}
+
+func TestStorageClassesValidate(t *testing.T) {
+	tests := []struct {
+		sc    cmn.StorageClass
+		valid bool
+		soft  bool
+	}{
+		{cmn.StorageClass{Copies: 2}, true, false},
+		{cmn.StorageClass{MinSize: cos.MiB, DataSlices: 2, ParitySlices: 1}, true, false},
+		{cmn.StorageClass{DataSlices: 4, ParitySlices: 2}, false, true}, // not enough targets
+		{cmn.StorageClass{DataSlices: 1, ParitySlices: 6}, false, false},
+		{cmn.StorageClass{Copies: 0}, false, false},
+		{cmn.StorageClass{Copies: 33}, false, false},
+		{cmn.StorageClass{Copies: 2, DataSlices: 2}, false, false},
+		{cmn.StorageClass{Copies: 2, DataSlices: 2, ParitySlices: 1}, false, false},
+		{cmn.StorageClass{MinSize: cos.MiB, MaxSize: cos.MiB, Copies: 1}, false, false},
+		{cmn.StorageClass{MinSize: -1, Copies: 1}, false, false},
+	}
+	for _, test := range tests {
+		conf := cmn.StorageClassConf{Classes: cmn.StorageClasses{test.sc}}
+		err := conf.ValidateAsProps(&cmn.ValidationArgs{TargetCnt: 5})
+		if test.valid {
+			tassert.Errorf(t, err == nil, "%q: expected valid, got %v", test.sc, err)
+			continue
+		}
+		tassert.Errorf(t, err != nil && cmn.IsErrSoft(err) == test.soft, "%q: expected (soft=%t) error, got %v",
+			test.sc, test.soft, err)
+	}
+}
+
+func TestECRequiredTargets(t *testing.T) {
+	props := cmn.BucketProps{
+		EC: cmn.ECConf{Enabled: true, DataSlices: 2, ParitySlices: 1},
+		StorageClass: cmn.StorageClassConf{Classes: cmn.StorageClasses{
+			{Prefix: "a/", Copies: 2},
+			{Prefix: "b/", DataSlices: 4, ParitySlices: 2},
+		}},
+	}
+	encode, restore := props.ECRequiredTargets()
+	tassert.Errorf(t, encode == 7 && restore == 4, "expected (7, 4) targets, got (%d, %d)", encode, restore)
+
+	props.StorageClass.Classes = props.StorageClass.Classes[:1]
+	encode, restore = props.ECRequiredTargets()
+	tassert.Errorf(t, encode == 4 && restore == 2, "expected (4, 2) targets, got (%d, %d)", encode, restore)
+
+	props.EC.Enabled = false
+	tassert.Errorf(t, !props.ECUsed(), "expected EC not used")
+}
@@ -81,7 +81,7 @@ func (bd *DBMock) List(collection, pattern string) ([]string, error) {
 		if strings.HasPrefix(k, filter) {
 			_, key := parsePath(k)
 			if key != "" {
-				keys = append(keys, k)
+				keys = append(keys, key)
 			}
 		}
 	}
@@ -97,7 +97,7 @@ func (bd *DBMock) DeleteCollection(collection string) error {
 		return err
 	}
 	for _, k := range keys {
-		delete(bd.values, k)
+		delete(bd.values, bd.makePath(collection, k))
 	}
 	return nil
 }
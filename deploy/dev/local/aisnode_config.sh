@@ -61,6 +61,8 @@ cat > $AIS_CONF_FILE <<EOL
 		"out_of_space":      95,
 		"dont_evict_time":   "120m",
 		"capacity_upd_time": "10m",
+		"policy":            "lru",
+		"priority":          0,
 		"enabled":           true
 	},
 	"disk":{
@@ -75,10 +77,20 @@ cat > $AIS_CONF_FILE <<EOL
 		"dest_retry_time": "2m",
 		"quiescent":       "15s",
 		"compression":     "${COMPRESSION:-never}",
-		"multiplier":      ${REBALANCE_MULTIPLIER:-2}
+		"multiplier":      ${REBALANCE_MULTIPLIER:-2},
+		"bwidth": {
+			"target":    0,
+			"mountpath": 0,
+			"adaptive":  false
+		}
 	},
 	"resilver": {
-		"enabled": true
+		"enabled": true,
+		"bwidth": {
+			"target":    0,
+			"mountpath": 0,
+			"adaptive":  false
+		}
 	},
 	"checksum": {
 		"type":			"xxhash",
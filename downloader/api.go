@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -24,8 +25,13 @@ const (
 	DlTypeRange   DlType = "range"
 	DlTypeMulti   DlType = "multi"
 	DlTypeBackend DlType = "backend"
+	DlTypeMirror  DlType = "mirror"
 
 	DownloadProgressInterval = 10 * time.Second
+
+	// mirror job: minimum interval between the runs and the number of (most recent) runs to keep
+	MinMirrorInterval = 10 * time.Second
+	mirrorRunsHistory = 100
 )
 
 type (
@@ -49,12 +55,29 @@ type (
 		FinishedCnt   int       `json:"finished_cnt"`
 		ScheduledCnt  int       `json:"scheduled_cnt"` // tasks being processed or already processed by dispatched
 		SkippedCnt    int       `json:"skipped_cnt"`   // number of tasks skipped
+		DeletedCnt    int       `json:"deleted_cnt"`   // number of objects deleted because they vanished from the source
 		ErrorCnt      int       `json:"error_cnt"`
 		Total         int       `json:"total"`          // total number of tasks, negative if unknown
 		AllDispatched bool      `json:"all_dispatched"` // if true, dispatcher has already scheduled all tasks for given job
 		Aborted       bool      `json:"aborted"`
 		StartedTime   time.Time `json:"started_time"`
 		FinishedTime  time.Time `json:"finished_time"`
+
+		// Mirror job only: the current run (counters above pertain to it)
+		// and the most recent finished runs.
+		Run  int         `json:"run,omitempty"`
+		Runs []DlRunInfo `json:"runs,omitempty"`
+	}
+
+	// Summary info of a single run (pass) of the mirror job
+	DlRunInfo struct {
+		Run          int       `json:"run"`
+		FinishedCnt  int       `json:"finished_cnt"` // includes skipped and deleted
+		SkippedCnt   int       `json:"skipped_cnt"`
+		DeletedCnt   int       `json:"deleted_cnt"`
+		ErrorCnt     int       `json:"error_cnt"`
+		StartedTime  time.Time `json:"started_time"`
+		FinishedTime time.Time `json:"finished_time"`
 	}
 
 	DlJobInfos []*DlJobInfo
@@ -69,13 +92,14 @@ type (
 
 func IsType(a string) bool {
 	b := DlType(a)
-	return b == DlTypeMulti || b == DlTypeBackend || b == DlTypeSingle || b == DlTypeRange
+	return b == DlTypeMulti || b == DlTypeBackend || b == DlTypeSingle || b == DlTypeRange || b == DlTypeMirror
 }
 
 func (j *DlJobInfo) Aggregate(rhs *DlJobInfo) {
 	j.FinishedCnt += rhs.FinishedCnt
 	j.ScheduledCnt += rhs.ScheduledCnt
 	j.SkippedCnt += rhs.SkippedCnt
+	j.DeletedCnt += rhs.DeletedCnt
 	j.ErrorCnt += rhs.ErrorCnt
 	j.Total += rhs.Total
 	j.AllDispatched = j.AllDispatched && rhs.AllDispatched
@@ -91,6 +115,39 @@ func (j *DlJobInfo) Aggregate(rhs *DlJobInfo) {
 			j.FinishedTime = rhs.FinishedTime
 		}
 	}
+	j.aggregateRuns(rhs)
+}
+
+// Targets run mirror jobs independently of each other - aggregate the runs by their numbers.
+func (j *DlJobInfo) aggregateRuns(rhs *DlJobInfo) {
+	if rhs.Run > j.Run {
+		j.Run = rhs.Run
+	}
+	for _, rr := range rhs.Runs {
+		var found bool
+		for i := range j.Runs {
+			r := &j.Runs[i]
+			if r.Run != rr.Run {
+				continue
+			}
+			r.FinishedCnt += rr.FinishedCnt
+			r.SkippedCnt += rr.SkippedCnt
+			r.DeletedCnt += rr.DeletedCnt
+			r.ErrorCnt += rr.ErrorCnt
+			if r.StartedTime.After(rr.StartedTime) {
+				r.StartedTime = rr.StartedTime
+			}
+			if r.FinishedTime.Before(rr.FinishedTime) {
+				r.FinishedTime = rr.FinishedTime
+			}
+			found = true
+			break
+		}
+		if !found {
+			j.Runs = append(j.Runs, rr)
+		}
+	}
+	sort.Slice(j.Runs, func(i, k int) bool { return j.Runs[i].Run < j.Runs[k].Run })
 }
 
 func (db DlBody) MarshalJSON() ([]byte, error) {
@@ -199,12 +256,20 @@ type DlLimits struct {
 	BytesPerHour int `json:"bytes_per_hour"`
 }
 
+// DlResumable configures resumable (ranged) download of large objects
+// from HTTP(S) sources - see ranged.go.
+type DlResumable struct {
+	MinSize  int64 `json:"min_size"` // objects of at least this size are downloaded resumably (0 - default, negative - never)
+	Parallel int   `json:"parallel"` // number of ranges (of the object) to fetch in parallel (0 or 1 - one at a time)
+}
+
 type DlBase struct {
-	Description      string   `json:"description"`
-	Bck              cmn.Bck  `json:"bucket"`
-	Timeout          string   `json:"timeout"`
-	ProgressInterval string   `json:"progress_interval"`
-	Limits           DlLimits `json:"limits"`
+	Description      string      `json:"description"`
+	Bck              cmn.Bck     `json:"bucket"`
+	Timeout          string      `json:"timeout"`
+	ProgressInterval string      `json:"progress_interval"`
+	Limits           DlLimits    `json:"limits"`
+	Resumable        DlResumable `json:"resumable"`
 }
 
 func (b *DlBase) Validate() error {
@@ -222,6 +287,10 @@ func (b *DlBase) Validate() error {
 	if b.Limits.BytesPerHour < 0 {
 		return fmt.Errorf("'limit.bytes_per_hour' must be non-negative (got: %d)", b.Limits.BytesPerHour)
 	}
+	if b.Resumable.Parallel < 0 || b.Resumable.Parallel > MaxParallelRanges {
+		return fmt.Errorf("'resumable.parallel' must be in the range [0, %d] (got: %d)",
+			MaxParallelRanges, b.Resumable.Parallel)
+	}
 	return nil
 }
 
@@ -427,3 +496,48 @@ func (b *DlBackendBody) Describe() string {
 	}
 	return fmt.Sprintf("remote bucket prefetch -> %s", b.Bck)
 }
+
+// Mirror (incremental sync) request: periodically fetches new and modified objects
+// from either HTTP(S) source (range of links) or remote bucket.
+type DlMirrorBody struct {
+	DlBase
+	Template string `json:"template"` // HTTP(S) source, e.g. "https://example.com/shard-{0..99}.tar"
+	Subdir   string `json:"subdir"`   // (HTTP(S) source) destination virtual directory
+	Prefix   string `json:"prefix"`   // (remote bucket source)
+	Suffix   string `json:"suffix"`   // (remote bucket source)
+	Interval string `json:"interval"` // time between the runs; empty - run once
+	Delete   bool   `json:"delete"`   // delete objects that vanished from the source
+}
+
+func (b *DlMirrorBody) Validate() error {
+	if err := b.DlBase.Validate(); err != nil {
+		return err
+	}
+	if b.Template != "" && (b.Prefix != "" || b.Suffix != "") {
+		return errors.New("'template' and 'prefix' (or 'suffix') cannot be defined together")
+	}
+	if b.Template == "" && b.Subdir != "" {
+		return errors.New("'subdir' requires 'template'")
+	}
+	if b.Interval != "" {
+		interval, err := time.ParseDuration(b.Interval)
+		if err != nil {
+			return fmt.Errorf("failed to parse interval field: %v", err)
+		}
+		if interval < MinMirrorInterval {
+			return fmt.Errorf("interval must be at least %v (got: %v)", MinMirrorInterval, interval)
+		}
+	}
+	return nil
+}
+
+func (b *DlMirrorBody) Describe() string {
+	if b.Description != "" {
+		return b.Description
+	}
+	src := b.Template
+	if src == "" {
+		src = b.Bck.String() + "/" + b.Prefix
+	}
+	return fmt.Sprintf("mirror %s -> %s", src, b.Bck)
+}
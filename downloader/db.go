@@ -11,11 +11,14 @@ import (
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/dbdriver"
+	jsoniter "github.com/json-iterator/go"
 )
 
 const (
 	downloaderErrors     = "errors"
 	downloaderTasks      = "tasks"
+	downloaderPartials   = "partials"
+	downloaderMirrors    = "mirrors"
 	downloaderCollection = "downloads"
 
 	// Number of errors stored in memory. When the number of errors exceeds
@@ -174,11 +177,66 @@ func (db *downloaderDB) flush(id string) error {
 	return nil
 }
 
+// progress of resumable downloads (see ranged.go) is stored per object
+func (db *downloaderDB) getPartial(key string) (*partialDl, error) {
+	pd := &partialDl{}
+	if err := db.driver.Get(downloaderCollection, key, pd); err != nil {
+		return nil, err
+	}
+	return pd, nil
+}
+
+func (db *downloaderDB) persistPartial(key string, pd *partialDl) error {
+	return db.driver.Set(downloaderCollection, key, pd)
+}
+
+func (db *downloaderDB) deletePartial(key string) {
+	if err := db.driver.Delete(downloaderCollection, key); err != nil && !dbdriver.IsErrNotFound(err) {
+		glog.Error(err)
+	}
+}
+
+// definitions of the mirror jobs are stored until the jobs finish (see mirror.go)
+func (db *downloaderDB) persistMirror(def *mirrorDef) {
+	key := path.Join(downloaderMirrors, def.ID)
+	if err := db.driver.Set(downloaderCollection, key, def); err != nil {
+		glog.Error(err)
+	}
+}
+
+func (db *downloaderDB) mirrors() (defs []*mirrorDef, err error) {
+	records, err := db.driver.GetAll(downloaderCollection, downloaderMirrors+"/")
+	if err != nil {
+		if dbdriver.IsErrNotFound(err) {
+			err = nil
+		}
+		return nil, err
+	}
+	for _, r := range records {
+		def := &mirrorDef{}
+		if err := jsoniter.UnmarshalFromString(r, def); err != nil {
+			glog.Error(err)
+			continue
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+func (db *downloaderDB) deleteMirror(id string) {
+	key := path.Join(downloaderMirrors, id)
+	if err := db.driver.Delete(downloaderCollection, key); err != nil && !dbdriver.IsErrNotFound(err) {
+		glog.Error(err)
+	}
+}
+
 func (db *downloaderDB) delete(id string) {
 	db.mtx.Lock()
 	key := path.Join(downloaderErrors, id)
 	db.driver.Delete(downloaderCollection, key)
 	key = path.Join(downloaderTasks, id)
 	db.driver.Delete(downloaderCollection, key)
+	key = path.Join(downloaderMirrors, id)
+	db.driver.Delete(downloaderCollection, key)
 	db.mtx.Unlock()
 }
@@ -0,0 +1,48 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestPersistMirror(t *testing.T) {
+	var (
+		db   = newDownloadDB(dbdriver.NewDBMock())
+		bck  = cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS}
+		body = DlMirrorBody{
+			DlBase:   DlBase{Bck: bck, Description: "mirror"},
+			Template: "https://example.com/shard-{0..9}.tar",
+			Interval: "1h",
+			Delete:   true,
+		}
+	)
+	db.persistMirror(&mirrorDef{ID: "job1", Body: body, Run: 1})
+	db.persistMirror(&mirrorDef{ID: "job2", Body: body, Run: 1})
+	db.persistMirror(&mirrorDef{ID: "job1", Body: body, Run: 2}) // next run
+	db.persistTaskInfo("job1", TaskDlInfo{Name: "obj"})
+	tassert.CheckFatal(t, db.flush("job1"))
+
+	defs, err := db.mirrors()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(defs) == 2, "expected 2 mirror jobs, got %d", len(defs))
+	for _, def := range defs {
+		tassert.Errorf(t, def.Body.Bck.Equal(bck) && def.Body.Template == body.Template &&
+			def.Body.Interval == body.Interval && def.Body.Delete, "%s: unexpected definition %+v", def.ID, def.Body)
+		if def.ID == "job1" {
+			tassert.Errorf(t, def.Run == 2, "expected run 2, got %d", def.Run)
+		}
+	}
+
+	db.deleteMirror("job2")
+	db.delete("job1")
+	defs, err = db.mirrors()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(defs) == 0, "expected no mirror jobs, got %d", len(defs))
+}
@@ -309,10 +309,10 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 
 			if result.Action == DiffResolverDelete {
 				cos.Assert(job.Sync())
-				if _, err := d.parent.t.EvictObject(result.Src); err != nil {
+				if err := removeVanished(d.parent.t, result.Src); err != nil {
 					t.markFailed(err.Error())
 				} else {
-					dlStore.incFinished(job.ID())
+					dlStore.incDeleted(job.ID())
 				}
 				continue
 			}
@@ -276,9 +276,14 @@ func (d *Downloader) stop(err error) {
 }
 
 func (d *Downloader) Download(dJob DlJob) (resp interface{}, statusCode int, err error) {
+	dlStore.setJob(dJob.ID(), dJob)
+	return d.enqueue(dJob)
+}
+
+// enqueue hands over the job to the dispatcher (see also: mirror job's next run)
+func (d *Downloader) enqueue(dJob DlJob) (resp interface{}, statusCode int, err error) {
 	d.IncPending()
 	defer d.DecPending()
-	dlStore.setJob(dJob.ID(), dJob)
 	config := cmn.GCO.Get()
 	select {
 	case d.dispatcher.downloadCh <- dJob:
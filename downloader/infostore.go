@@ -78,6 +78,10 @@ func (is *infoStore) setJob(id string, job DlJob) {
 		Description: job.Description(),
 		StartedTime: time.Now(),
 	}
+	if mj, ok := job.(*mirrorDlJob); ok {
+		jInfo.Run, jInfo.RunStarted = mj.def.Run, jInfo.StartedTime
+		is.persistMirror(&mj.def)
+	}
 
 	is.Lock()
 	is.jobInfo[id] = jInfo
@@ -97,6 +101,13 @@ func (is *infoStore) incSkipped(id string) {
 	jInfo.FinishedCnt.Inc()
 }
 
+func (is *infoStore) incDeleted(id string) {
+	jInfo, err := is.getJob(id)
+	cos.AssertNoErr(err)
+	jInfo.DeletedCnt.Inc()
+	jInfo.FinishedCnt.Inc()
+}
+
 func (is *infoStore) incScheduled(id string) {
 	jInfo, err := is.getJob(id)
 	cos.AssertNoErr(err)
@@ -122,6 +133,51 @@ func (is *infoStore) markFinished(id string) {
 	cos.Assert(jInfo.valid())
 }
 
+// (mirror job) records the current run and returns true if the job has been aborted
+func (is *infoStore) finishRun(id string) (aborted bool) {
+	jInfo, err := is.getJob(id)
+	cos.AssertNoErr(err)
+	jInfo.runMtx.Lock()
+	jInfo.Runs = append(jInfo.Runs, DlRunInfo{
+		Run:          jInfo.Run,
+		FinishedCnt:  int(jInfo.FinishedCnt.Load()),
+		SkippedCnt:   int(jInfo.SkippedCnt.Load()),
+		DeletedCnt:   int(jInfo.DeletedCnt.Load()),
+		ErrorCnt:     int(jInfo.ErrorCnt.Load()),
+		StartedTime:  jInfo.RunStarted,
+		FinishedTime: time.Now(),
+	})
+	if len(jInfo.Runs) > mirrorRunsHistory {
+		jInfo.Runs = jInfo.Runs[len(jInfo.Runs)-mirrorRunsHistory:]
+	}
+	jInfo.runMtx.Unlock()
+	return jInfo.Aborted.Load()
+}
+
+// (mirror job) resets the counters for the next run and returns its number
+func (is *infoStore) startRun(id string) (run int) {
+	jInfo, err := is.getJob(id)
+	cos.AssertNoErr(err)
+	jInfo.runMtx.Lock()
+	jInfo.Run++
+	jInfo.RunStarted = time.Now()
+	jInfo.FinishedCnt.Store(0)
+	jInfo.ScheduledCnt.Store(0)
+	jInfo.SkippedCnt.Store(0)
+	jInfo.DeletedCnt.Store(0)
+	jInfo.ErrorCnt.Store(0)
+	jInfo.AllDispatched.Store(false)
+	run = jInfo.Run
+	jInfo.runMtx.Unlock()
+	return
+}
+
+func (is *infoStore) isAborted(id string) bool {
+	jInfo, err := is.getJob(id)
+	cos.AssertNoErr(err)
+	return jInfo.Aborted.Load()
+}
+
 func (is *infoStore) setAborted(id string) {
 	jInfo, err := is.getJob(id)
 	cos.AssertNoErr(err)
@@ -141,7 +197,11 @@ func (is *infoStore) housekeep() time.Duration {
 
 	is.Lock()
 	for id, jInfo := range is.jobInfo {
-		if time.Since(jInfo.FinishedTime.Load()) > interval {
+		finished := jInfo.FinishedTime.Load()
+		if cos.IsTimeZero(finished) {
+			continue // still running
+		}
+		if time.Since(finished) > interval {
 			is.delJob(id)
 		}
 	}
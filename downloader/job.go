@@ -8,6 +8,7 @@ import (
 	"errors"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
@@ -28,6 +29,7 @@ var (
 	_ DlJob = (*sliceDlJob)(nil)
 	_ DlJob = (*backendDlJob)(nil)
 	_ DlJob = (*rangeDlJob)(nil)
+	_ DlJob = (*mirrorDlJob)(nil)
 )
 
 type (
@@ -62,6 +64,9 @@ type (
 
 		throttler() *throttler
 
+		// Resumable (ranged) download options.
+		resumableOpts() DlResumable
+
 		cleanup()
 	}
 
@@ -71,6 +76,7 @@ type (
 		timeout     time.Duration
 		description string
 		t           *throttler
+		resumable   DlResumable
 		dlXact      *Downloader
 
 		// notif
@@ -95,6 +101,7 @@ type (
 		baseDlJob
 		t     cluster.Target
 		objs  []dlObj               // objects' metas which are ready to be downloaded
+		pt    cos.ParsedTemplate    // links template
 		iter  func() (string, bool) // links iterator
 		dir   string                // objects directory(prefix) from request
 		count int                   // total number object to download by a target
@@ -119,6 +126,7 @@ type (
 		FinishedCnt  atomic.Int32 `json:"finished"` // also includes skipped
 		ScheduledCnt atomic.Int32 `json:"scheduled"`
 		SkippedCnt   atomic.Int32 `json:"skipped"`
+		DeletedCnt   atomic.Int32 `json:"deleted"`
 		ErrorCnt     atomic.Int32 `json:"errors"`
 		Total        int          `json:"total"`
 
@@ -127,6 +135,12 @@ type (
 
 		StartedTime  time.Time   `json:"started_time"`
 		FinishedTime atomic.Time `json:"finished_time"`
+
+		// mirror job only (see mirror.go)
+		Run        int         `json:"run"`
+		Runs       []DlRunInfo `json:"runs"`
+		RunStarted time.Time   `json:"run_started_time"`
+		runMtx     sync.Mutex  // protects the above
 	}
 )
 
@@ -159,8 +173,9 @@ func (j *baseDlJob) ActiveStats() (*DlStatusResp, error) {
 	return resp.(*DlStatusResp), nil
 }
 
-func (*baseDlJob) checkObj(string) bool    { debug.Assert(false); return false }
-func (j *baseDlJob) throttler() *throttler { return j.t }
+func (*baseDlJob) checkObj(string) bool         { debug.Assert(false); return false }
+func (j *baseDlJob) throttler() *throttler      { return j.t }
+func (j *baseDlJob) resumableOpts() DlResumable { return j.resumable }
 
 func (j *baseDlJob) cleanup() {
 	j.throttler().stop()
@@ -169,7 +184,8 @@ func (j *baseDlJob) cleanup() {
 	nl.OnFinished(j.Notif(), nil)
 }
 
-func newBaseDlJob(t cluster.Target, id string, bck *cluster.Bck, timeout, desc string, limits DlLimits, dlXact *Downloader) *baseDlJob {
+func newBaseDlJob(t cluster.Target, id string, bck *cluster.Bck, timeout, desc string, limits DlLimits,
+	resumable DlResumable, dlXact *Downloader) *baseDlJob {
 	// TODO: this might be inaccurate if we download 1 or 2 objects because then
 	//  other targets will have limits but will not use them.
 	if limits.BytesPerHour > 0 {
@@ -183,6 +199,7 @@ func newBaseDlJob(t cluster.Target, id string, bck *cluster.Bck, timeout, desc s
 		timeout:     td,
 		description: desc,
 		t:           newThrottler(limits),
+		resumable:   resumable,
 		dlXact:      dlXact,
 	}
 }
@@ -209,7 +226,7 @@ func newMultiDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlMul
 		objs cos.SimpleKVs
 		err  error
 	)
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, payload.Resumable, dlXact)
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
 	}
@@ -225,7 +242,7 @@ func newSingleDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlSi
 		objs cos.SimpleKVs
 		err  error
 	)
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, payload.Resumable, dlXact)
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
 	}
@@ -264,6 +281,12 @@ func (j *backendDlJob) genNext() (objs []dlObj, ok bool, err error) {
 	return j.objs, true, nil
 }
 
+func (j *backendDlJob) reset() {
+	j.objs = j.objs[:0]
+	j.continuationToken = ""
+	j.done = false
+}
+
 // Reads the content of a remote bucket page by page until any objects to
 // download found or the bucket list is over.
 func (j *backendDlJob) getNextObjs() error {
@@ -318,6 +341,12 @@ func (j *rangeDlJob) genNext() ([]dlObj, bool, error) {
 	return j.objs, true, nil
 }
 
+func (j *rangeDlJob) reset() {
+	j.objs = j.objs[:0]
+	j.iter = j.pt.Iter()
+	j.done = false
+}
+
 func (j *rangeDlJob) getNextObjs() error {
 	var (
 		smap = j.t.Sowner().Get()
@@ -349,7 +378,7 @@ func newBackendDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlB
 	} else if bck.IsHTTP() {
 		return nil, errors.New("bucket download does not support HTTP buckets")
 	}
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, payload.Resumable, dlXact)
 	job := &backendDlJob{
 		baseDlJob: *base,
 		t:         t,
@@ -397,7 +426,7 @@ func newRangeDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlRan
 		return nil, err
 	}
 
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, payload.Resumable, dlXact)
 	cnt, err := countObjects(t, pt, payload.Subdir, base.bck)
 	if err != nil {
 		return nil, err
@@ -405,6 +434,7 @@ func newRangeDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlRan
 	job := &rangeDlJob{
 		baseDlJob: *base,
 		t:         t,
+		pt:        pt,
 		iter:      pt.Iter(),
 		dir:       payload.Subdir,
 		count:     cnt,
@@ -413,18 +443,24 @@ func newRangeDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlRan
 }
 
 func (d *downloadJobInfo) ToDlJobInfo() DlJobInfo {
+	d.runMtx.Lock()
+	run, runs := d.Run, append([]DlRunInfo(nil), d.Runs...)
+	d.runMtx.Unlock()
 	return DlJobInfo{
 		ID:            d.ID,
 		Description:   d.Description,
 		FinishedCnt:   int(d.FinishedCnt.Load()),
 		ScheduledCnt:  int(d.ScheduledCnt.Load()),
 		SkippedCnt:    int(d.SkippedCnt.Load()),
+		DeletedCnt:    int(d.DeletedCnt.Load()),
 		ErrorCnt:      int(d.ErrorCnt.Load()),
 		Total:         d.Total,
 		AllDispatched: d.AllDispatched.Load(),
 		Aborted:       d.Aborted.Load(),
 		StartedTime:   d.StartedTime,
 		FinishedTime:  d.FinishedTime.Load(),
+		Run:           run,
+		Runs:          runs,
 	}
 }
 
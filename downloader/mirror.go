@@ -0,0 +1,213 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xreg"
+)
+
+// Mirror job (DlTypeMirror) incrementally syncs HTTP(S) source (range of links)
+// or remote bucket into the cluster. Each run (pass) of the job is a regular
+// range (or remote bucket) download that compares the source and the local
+// objects and fetches only new and modified ones - by version and checksum
+// (cloud), by ETag and Last-Modified (web), and by size (both) - see CompareObjects.
+//
+// Optionally, the run also deletes the objects that vanished from the source:
+// those that are not listed in the remote bucket anymore or else, in case of
+// HTTP(S), the objects whose links respond with 404 (410).
+//
+// The runs are repeated every `interval` under the same job ID until the job
+// is aborted. Each target runs its part of the job independently and keeps
+// the stats of the most recent runs - see DlJobInfo.Runs.
+//
+// The definition of the job is persisted (see mirrorDef) until the job finishes,
+// so that the target that restarts resumes the job with the next run - see
+// ResumeMirrorJobs.
+
+type (
+	mirrorSrc interface {
+		DlJob
+		reset() // prepare for the next run
+	}
+
+	mirrorDlJob struct {
+		mirrorSrc   // *rangeDlJob or *backendDlJob
+		t           cluster.Target
+		interval    time.Duration // zero - run once
+		delVanished bool
+		def         mirrorDef
+
+		mtx sync.Mutex
+		xdl *Downloader // downloader of the current run
+	}
+
+	// persisted definition of the mirror job
+	mirrorDef struct {
+		ID   string       `json:"id"`
+		Body DlMirrorBody `json:"body"`
+		Run  int          `json:"run"` // the most recently started run
+	}
+)
+
+func newMirrorDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlMirrorBody, dlXact *Downloader) (*mirrorDlJob, error) {
+	var (
+		src  mirrorSrc
+		err  error
+		base = payload.DlBase
+	)
+	base.Description = payload.Describe()
+	if payload.Template != "" {
+		src, err = newRangeDlJob(t, id, bck, &DlRangeBody{
+			DlBase:   base,
+			Template: payload.Template,
+			Subdir:   payload.Subdir,
+		}, dlXact)
+	} else {
+		// NOTE: traverse the bucket (`sync`) only to find objects that vanished
+		src, err = newBackendDlJob(t, id, bck, &DlBackendBody{
+			DlBase: base,
+			Sync:   payload.Delete,
+			Prefix: payload.Prefix,
+			Suffix: payload.Suffix,
+		}, dlXact)
+	}
+	if err != nil {
+		return nil, err
+	}
+	interval, _ := time.ParseDuration(payload.Interval) // validated
+	return &mirrorDlJob{
+		mirrorSrc:   src,
+		t:           t,
+		interval:    interval,
+		delVanished: payload.Delete,
+		def:         mirrorDef{ID: id, Body: *payload, Run: 1},
+		xdl:         dlXact,
+	}, nil
+}
+
+// ResumeMirrorJobs restarts the mirror jobs that did not finish before the target
+// restarted. Each job continues under its ID with the next run; `addNotif` adds
+// the notifications (see DlJob.AddNotif) given the job's progress interval.
+// The jobs that cannot be resumed (e.g., the bucket does not exist anymore)
+// are logged and removed.
+func ResumeMirrorJobs(t cluster.Target, statsT stats.Tracker, addNotif func(DlJob, time.Duration)) {
+	initInfoStore(t.DB()) // it will be initialized only once
+	defs, err := dlStore.mirrors()
+	if err != nil {
+		glog.Errorf("failed to load mirror download jobs: %v", err)
+		return
+	}
+	if len(defs) == 0 {
+		return
+	}
+	rns := xreg.RenewDownloader(t, statsT)
+	if rns.Err != nil {
+		glog.Errorf("failed to resume mirror download jobs: %v", rns.Err)
+		return
+	}
+	xdl := rns.Entry.Get().(*Downloader)
+	for _, def := range defs {
+		bck := cluster.NewBckEmbed(def.Body.Bck)
+		if err := bck.Init(t.Bowner()); err != nil {
+			glog.Errorf("mirror download job %q: failed to resume: %v", def.ID, err)
+			dlStore.deleteMirror(def.ID)
+			continue
+		}
+		j, err := newMirrorDlJob(t, def.ID, bck, &def.Body, xdl)
+		if err != nil {
+			glog.Errorf("mirror download job %q: failed to resume: %v", def.ID, err)
+			dlStore.deleteMirror(def.ID)
+			continue
+		}
+		j.def.Run = def.Run + 1
+		progressInterval := DownloadProgressInterval
+		if d, err := time.ParseDuration(def.Body.ProgressInterval); err == nil {
+			progressInterval = d
+		}
+		addNotif(j, progressInterval)
+
+		glog.Infof("resuming mirror download job %q (run %d)", def.ID, j.def.Run)
+		resp, statusCode, err := xdl.Download(j)
+		if err == nil && statusCode >= http.StatusBadRequest {
+			err = fmt.Errorf("%v", resp)
+		}
+		if err != nil {
+			j.skipRun(err) // (the job is registered - see Download)
+		}
+	}
+}
+
+func (j *mirrorDlJob) ActiveStats() (*DlStatusResp, error) {
+	j.mtx.Lock()
+	xdl := j.xdl
+	j.mtx.Unlock()
+	resp, _, err := xdl.JobStatus(j.ID(), true /*onlyActive*/)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*DlStatusResp), nil
+}
+
+// cleanup is called upon finishing each run
+func (j *mirrorDlJob) cleanup() {
+	if aborted := dlStore.finishRun(j.ID()); aborted || j.interval == 0 {
+		dlStore.deleteMirror(j.ID())
+		j.mirrorSrc.cleanup()
+		return
+	}
+	dlStore.flush(j.ID())
+	go j.next()
+}
+
+// waits for the interval to pass (or the job to get aborted) and starts the next run
+func (j *mirrorDlJob) next() {
+	for deadline := time.Now().Add(j.interval); time.Now().Before(deadline); time.Sleep(time.Second) {
+		if dlStore.isAborted(j.ID()) {
+			dlStore.deleteMirror(j.ID())
+			j.mirrorSrc.cleanup()
+			return
+		}
+	}
+
+	j.reset()
+	j.def.Run = dlStore.startRun(j.ID())
+	dlStore.persistMirror(&j.def)
+
+	// The downloader may have terminated (being idle) in the meantime - renew it.
+	j.mtx.Lock()
+	statsT := j.xdl.statsT
+	j.mtx.Unlock()
+	rns := xreg.RenewDownloader(j.t, statsT)
+	if rns.Err != nil {
+		j.skipRun(rns.Err)
+		return
+	}
+	xdl := rns.Entry.Get().(*Downloader)
+	j.mtx.Lock()
+	j.xdl = xdl
+	j.mtx.Unlock()
+
+	resp, statusCode, err := xdl.enqueue(j)
+	if err == nil && statusCode >= http.StatusBadRequest {
+		err = fmt.Errorf("%v", resp)
+	}
+	if err != nil {
+		j.skipRun(err)
+	}
+}
+
+func (j *mirrorDlJob) skipRun(err error) {
+	glog.Errorf("mirror download job %q: failed to start run: %v", j.ID(), err)
+	dlStore.persistError(j.ID(), "", err.Error())
+	j.cleanup()
+}
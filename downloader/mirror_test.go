@@ -0,0 +1,147 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// stores downloaded objects and records deletions and evictions
+type mirrorTargetMock struct {
+	*cluster.TargetMock
+	mu      sync.Mutex
+	deleted []string
+	evicted []string
+}
+
+func (*mirrorTargetMock) PutObject(lom *cluster.LOM, params cluster.PutObjectParams) error {
+	fh, err := cos.CreateFile(lom.FQN)
+	if err != nil {
+		return err
+	}
+	size, err := io.Copy(fh, params.Reader)
+	fh.Close()
+	if err != nil {
+		return err
+	}
+	lom.SetSize(size)
+	return lom.Persist()
+}
+
+func (t *mirrorTargetMock) DeleteObject(lom *cluster.LOM, evict bool) (int, error) {
+	t.mu.Lock()
+	if evict {
+		t.evicted = append(t.evicted, lom.ObjName)
+	} else {
+		t.deleted = append(t.deleted, lom.ObjName)
+	}
+	t.mu.Unlock()
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	return 0, lom.Remove()
+}
+
+func (t *mirrorTargetMock) EvictObject(lom *cluster.LOM) (int, error) {
+	return t.DeleteObject(lom, true)
+}
+
+// mirror job with Delete enabled removes the object that vanished from the
+// HTTP source in between the runs
+func TestMirrorDeleteVanished(t *testing.T) {
+	const jobID = "mirror-job"
+	var (
+		mu    sync.Mutex
+		files = map[string]string{"/obj-1": "content-1", "/obj-2": "content-2"}
+		srv   = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			content, ok := files[r.URL.Path]
+			mu.Unlock()
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write([]byte(content))
+		}))
+	)
+	defer srv.Close()
+
+	fs.Init()
+	fs.DisableFsIDCheck()
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_, err := fs.Add(t.TempDir(), "daeID")
+	tassert.CheckFatal(t, err)
+	bck := cluster.NewBck("mirror", cmn.ProviderAIS, cmn.NsGlobal,
+		&cmn.BucketProps{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}, BID: 1})
+	tMock := &mirrorTargetMock{TargetMock: cluster.NewTargetMock(cluster.NewBaseBownerMock(bck))}
+	if errs := fs.CreateBucket("test", bck.Bck, false /*nilbmd*/); len(errs) > 0 {
+		tassert.CheckFatal(t, errs[0])
+	}
+
+	initInfoStore(dbdriver.NewDBMock())
+	var (
+		xdl = &Downloader{t: tMock, statsT: stats.NewTrackerMock()}
+		j   = &mirrorDlJob{
+			mirrorSrc: &rangeDlJob{baseDlJob: baseDlJob{
+				id:      jobID,
+				bck:     bck,
+				timeout: time.Minute,
+				t:       newThrottler(DlLimits{}),
+				notif:   &NotifDownload{},
+			}},
+			t:           tMock,
+			delVanished: true,
+			def:         mirrorDef{ID: jobID, Run: 1},
+			xdl:         xdl,
+		}
+	)
+	dlStore.setJob(jobID, j)
+	defer dlStore.delJob(jobID)
+
+	run := func() {
+		for _, name := range []string{"obj-1", "obj-2"} {
+			task := &singleObjectTask{parent: xdl, job: j, obj: dlObj{objName: name, link: srv.URL + "/" + name}}
+			task.init()
+			task.download()
+		}
+	}
+	exists := func(name string) bool {
+		lom := cluster.AllocLOM(name)
+		defer cluster.FreeLOM(lom)
+		tassert.CheckFatal(t, lom.Init(bck.Bck))
+		return lom.Load(false, false) == nil
+	}
+
+	run()
+	tassert.Fatalf(t, exists("obj-1") && exists("obj-2"), "expected both objects to be downloaded")
+
+	mu.Lock()
+	delete(files, "/obj-2")
+	mu.Unlock()
+	run()
+
+	tassert.Errorf(t, exists("obj-1"), "expected obj-1 to remain")
+	tassert.Errorf(t, !exists("obj-2"), "expected vanished obj-2 to be deleted")
+	tassert.Errorf(t, len(tMock.evicted) == 0, "ais bucket: expected no evictions, got %v", tMock.evicted)
+	tassert.Errorf(t, strings.Join(tMock.deleted, ",") == "obj-2", "expected obj-2 deleted, got %v", tMock.deleted)
+	jInfo, err := dlStore.getJob(jobID)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, jInfo.DeletedCnt.Load() == 1 && jInfo.ErrorCnt.Load() == 0,
+		"expected 1 deleted and no errors, got %d deleted, %d errors", jInfo.DeletedCnt.Load(), jInfo.ErrorCnt.Load())
+}
@@ -0,0 +1,404 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"golang.org/x/sync/errgroup"
+)
+
+// Resumable (ranged) download of large objects from HTTP(S) sources.
+//
+// An object of at least DlResumable.MinSize bytes whose source supports range
+// requests (`Accept-Ranges: bytes`) and provides a validator (strong ETag or
+// else Last-Modified) is downloaded into its own workfile in one or more
+// (DlResumable.Parallel) ranges - chunks - fetched concurrently. The number of
+// bytes written in each chunk is periodically persisted in the downloader's DB
+// so that the download, when interrupted (dropped connection, timeout, target
+// restart followed by re-submitting the job), resumes where it left off - with
+// `Range` and `If-Range` requests. If the object has changed at the source in
+// the meantime, the download starts over.
+//
+// Once all the chunks are written, the content is verified against the MD5 the
+// source provides (cloud-specific header - see roiFromLink - or Content-MD5, or
+// MD5 ETag), if any, and the workfile gets finalized as the object.
+//
+// NOTE: chunks fetched in parallel are not subject to DlLimits.Connections.
+
+const (
+	DefaultResumableSize = 256 * cos.MiB
+	MaxParallelRanges    = 16
+
+	partialPersistInterval = 10 * time.Second
+
+	hdrContentMD5 = "Content-MD5"
+	hdrIfRange    = "If-Range"
+)
+
+var errSourceChanged = errors.New("object has changed at the source")
+
+type (
+	// progress of the resumable download (see downloaderDB.persistPartial)
+	partialDl struct {
+		Link      string        `json:"link"`
+		WorkFQN   string        `json:"work_fqn"`
+		Size      int64         `json:"size"`
+		Validator string        `json:"validator"`     // ETag or Last-Modified (for `If-Range`)
+		MD5       string        `json:"md5,omitempty"` // expected checksum, if provided by the source
+		MD        cos.SimpleKVs `json:"md,omitempty"`  // custom metadata of the object (see roiFromLink)
+		Chunks    []*dlChunk    `json:"chunks"`
+	}
+	dlChunk struct {
+		Start   int64 `json:"start"`
+		End     int64 `json:"end"`     // exclusive
+		Written int64 `json:"written"` // NOTE: updated atomically
+	}
+
+	// writes the chunk at its offset within the workfile
+	chunkWriter struct {
+		fh    *os.File
+		chunk *dlChunk
+	}
+)
+
+// objects that are currently being downloaded resumably (by partialKey)
+var inflight sync.Map
+
+func partialKey(lom *cluster.LOM) string { return path.Join(downloaderPartials, lom.Uname()) }
+
+func acquire(key string) bool {
+	_, loaded := inflight.LoadOrStore(key, struct{}{})
+	return !loaded
+}
+
+func release(key string) { inflight.Delete(key) }
+
+// loadPartial returns the persisted progress of the previously interrupted
+// download of the object from the same link, if any.
+func (t *singleObjectTask) loadPartial(lom *cluster.LOM) *partialDl {
+	key := partialKey(lom)
+	pd, err := dlStore.getPartial(key)
+	if err != nil || !acquire(key) {
+		return nil
+	}
+	if pd.Link != t.obj.link || !pd.intact() {
+		pd.discard(key)
+		release(key)
+		return nil
+	}
+	// The workfile may be named after the previous incarnation of the target
+	// (and, therefore, be considered old) - rename.
+	workFQN := fs.CSM.GenContentFQN(lom, fs.WorkfileType, fs.WorkfileDlRange)
+	if err := os.Rename(pd.WorkFQN, workFQN); err != nil {
+		glog.Errorf("%s: failed to resume download: %v", t, err)
+		pd.discard(key)
+		release(key)
+		return nil
+	}
+	pd.WorkFQN = workFQN
+	return pd
+}
+
+// newPartial returns non-nil if the object (as per the response to the initial
+// GET) can be downloaded resumably.
+func (t *singleObjectTask) newPartial(lom *cluster.LOM, resp *http.Response, roi remoteObjInfo) *partialDl {
+	var (
+		opts    = t.job.resumableOpts()
+		minSize = opts.MinSize
+	)
+	if minSize == 0 {
+		minSize = DefaultResumableSize
+	}
+	if minSize < 0 || resp.StatusCode != http.StatusOK || resp.ContentLength < minSize ||
+		resp.Header.Get(cmn.HdrAcceptRanges) != "bytes" {
+		return nil
+	}
+	validator := resp.Header.Get(cmn.HdrETag)
+	if validator == "" || strings.HasPrefix(validator, "W/") { // (weak ETag cannot be used with `If-Range`)
+		validator = resp.Header.Get(cmn.HdrLastModified)
+	}
+	if validator == "" || !acquire(partialKey(lom)) {
+		return nil
+	}
+	pd := &partialDl{
+		Link:      t.obj.link,
+		WorkFQN:   fs.CSM.GenContentFQN(lom, fs.WorkfileType, fs.WorkfileDlRange),
+		Size:      resp.ContentLength,
+		Validator: validator,
+		MD5:       expectedMD5(resp, roi),
+		MD:        roi.md,
+	}
+	pd.split(opts.Parallel)
+	return pd
+}
+
+// downloadRanged downloads (or resumes downloading) the object and finalizes it;
+// `body` is the response to the initial GET of a new download (nil when resuming).
+func (t *singleObjectTask) downloadRanged(ctx context.Context, lom *cluster.LOM, pd *partialDl,
+	body io.Reader) (fatal bool, err error) {
+	var (
+		fh  *os.File
+		key = partialKey(lom)
+	)
+	defer release(key)
+
+	t.setTotalSize(pd.Size)
+	t.currentSize.Store(pd.written())
+
+	if body != nil {
+		if fh, err = lom.CreateFile(pd.WorkFQN); err == nil {
+			if err = fh.Truncate(pd.Size); err == nil {
+				err = dlStore.persistPartial(key, pd)
+			}
+		}
+	} else {
+		if fh, err = os.OpenFile(pd.WorkFQN, os.O_WRONLY, cos.PermRWR); err == nil {
+			err = dlStore.persistPartial(key, pd) // (renamed workfile)
+		}
+	}
+	if err != nil {
+		if fh != nil {
+			cos.Close(fh)
+		}
+		pd.discard(key)
+		return true, err
+	}
+
+	err = t.fetchChunks(ctx, fh, pd, key, body)
+	if err != nil && !errors.Is(err, errSourceChanged) {
+		pd.persist(key, fh)
+	}
+	cos.Close(fh)
+	if err != nil {
+		if errors.Is(err, errSourceChanged) {
+			pd.discard(key) // start over upon retry
+		}
+		return false, err
+	}
+	return true, t.finalizeRanged(lom, pd, key)
+}
+
+// fetches all the remaining chunks while periodically persisting the progress
+func (t *singleObjectTask) fetchChunks(ctx context.Context, fh *os.File, pd *partialDl, key string,
+	body io.Reader) error {
+	group, gctx := errgroup.WithContext(ctx)
+	for i, chunk := range pd.Chunks {
+		if chunk.remaining() == 0 {
+			continue
+		}
+		var (
+			chunk = chunk
+			r     io.Reader
+		)
+		if i == 0 && body != nil {
+			r = body // (the initial GET)
+		}
+		group.Go(func() error { return t.fetchChunk(gctx, fh, pd, chunk, r) })
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- group.Wait() }()
+	ticker := time.NewTicker(partialPersistInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-errCh:
+			return err
+		case <-ticker.C:
+			pd.persist(key, fh)
+		}
+	}
+}
+
+func (t *singleObjectTask) fetchChunk(ctx context.Context, fh *os.File, pd *partialDl, chunk *dlChunk,
+	r io.Reader) error {
+	if r == nil {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, pd.Link, nil)
+		if err != nil {
+			return err
+		}
+		req.Header = cmn.RangeHdr(chunk.offset(), chunk.remaining())
+		req.Header.Set(hdrIfRange, pd.Validator)
+		if cos.IsGoogleStorageURL(req.URL) {
+			req.Header.Add("User-Agent", cmn.GcsUA)
+		}
+		resp, err := clientForURL(pd.Link).Do(req)
+		if err != nil {
+			return err
+		}
+		defer cos.Close(resp.Body)
+		if resp.StatusCode >= http.StatusBadRequest {
+			return cmn.NewHTTPErr(req, "", resp.StatusCode)
+		}
+		if resp.StatusCode != http.StatusPartialContent {
+			return errSourceChanged // (`If-Range` did not match - the entire object is being sent)
+		}
+		r = resp.Body
+	}
+	var (
+		remaining = chunk.remaining()
+		lr        = io.NopCloser(io.LimitReader(r, remaining))
+	)
+	n, err := io.Copy(&chunkWriter{fh: fh, chunk: chunk}, t.wrapReader(ctx, lr))
+	if err == nil && n < remaining {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (t *singleObjectTask) finalizeRanged(lom *cluster.LOM, pd *partialDl, key string) (err error) {
+	var cksum *cos.Cksum
+	buf, slab := t.parent.t.MMSA().Alloc()
+	cksum, err = pd.checksum(lom.CksumConf().Type, buf)
+	slab.Free(buf)
+	if err != nil {
+		pd.discard(key)
+		return
+	}
+	lom.SetSize(pd.Size)
+	lom.SetCksum(cksum)
+	lom.SetCustom(pd.MD)
+	if _, err = t.parent.t.FinalizeObj(lom, pd.WorkFQN); err != nil {
+		pd.discard(key)
+		return
+	}
+	dlStore.deletePartial(key)
+	return lom.Load(true /*cache it*/, false /*locked*/)
+}
+
+// expected MD5 of the object (hex), if provided by the source
+func expectedMD5(resp *http.Response, roi remoteObjInfo) string {
+	if v := roi.md[cluster.MD5ObjMD]; v != "" {
+		return strings.ToLower(strings.Trim(v, `"`))
+	}
+	if v := resp.Header.Get(hdrContentMD5); v != "" {
+		if b, err := base64.StdEncoding.DecodeString(v); err == nil && len(b) == md5.Size {
+			return hex.EncodeToString(b)
+		}
+	}
+	// NOTE: 32-hex-digit (strong) ETag is, in all likelihood, MD5 of the content
+	if etag := strings.Trim(resp.Header.Get(cmn.HdrETag), `"`); len(etag) == 32 {
+		if _, err := hex.DecodeString(etag); err == nil {
+			return strings.ToLower(etag)
+		}
+	}
+	return ""
+}
+
+///////////////
+// partialDl //
+///////////////
+
+func (pd *partialDl) split(n int) {
+	if n < 1 {
+		n = 1
+	}
+	size := cos.DivCeil(pd.Size, int64(n))
+	for start := int64(0); start < pd.Size; start += size {
+		pd.Chunks = append(pd.Chunks, &dlChunk{Start: start, End: cos.MinI64(start+size, pd.Size)})
+	}
+}
+
+func (pd *partialDl) written() (n int64) {
+	for _, chunk := range pd.Chunks {
+		n += atomic.LoadInt64(&chunk.Written)
+	}
+	return
+}
+
+// the workfile is (still) in place
+func (pd *partialDl) intact() bool {
+	finfo, err := os.Stat(pd.WorkFQN)
+	return err == nil && finfo.Size() == pd.Size
+}
+
+// persist the progress - only what's been synced to disk
+func (pd *partialDl) persist(key string, fh *os.File) {
+	snap := *pd
+	snap.Chunks = make([]*dlChunk, len(pd.Chunks))
+	for i, chunk := range pd.Chunks {
+		snap.Chunks[i] = &dlChunk{Start: chunk.Start, End: chunk.End, Written: atomic.LoadInt64(&chunk.Written)}
+	}
+	if err := fh.Sync(); err != nil {
+		glog.Errorf("failed to sync %q: %v", pd.WorkFQN, err)
+		return
+	}
+	if err := dlStore.persistPartial(key, &snap); err != nil {
+		glog.Error(err)
+	}
+}
+
+func (pd *partialDl) discard(key string) {
+	if err := cos.RemoveFile(pd.WorkFQN); err != nil {
+		glog.Error(err)
+	}
+	dlStore.deletePartial(key)
+}
+
+// computes the checksum of the object (as per bucket configuration) and, at the
+// same time, validates the content against the MD5 provided by the source
+func (pd *partialDl) checksum(cksumType string, buf []byte) (*cos.Cksum, error) {
+	fh, err := os.Open(pd.WorkFQN)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		md5h  *cos.CksumHash
+		cksum = cos.NewCksumHash(cksumType)
+		w     io.Writer
+	)
+	w = cksum.H
+	if pd.MD5 != "" {
+		md5h = cos.NewCksumHash(cos.ChecksumMD5)
+		w = cos.NewWriterMulti(cksum.H, md5h.H)
+	}
+	_, err = io.CopyBuffer(w, fh, buf)
+	cos.Close(fh)
+	if err != nil {
+		return nil, err
+	}
+	if md5h != nil {
+		md5h.Finalize()
+		if expected := cos.NewCksum(cos.ChecksumMD5, pd.MD5); !md5h.Equal(expected) {
+			return nil, cos.NewBadDataCksumError(expected, &md5h.Cksum, pd.Link)
+		}
+	}
+	if cksum.Type() == cos.ChecksumNone {
+		return cos.NoneCksum, nil
+	}
+	cksum.Finalize()
+	return cksum.Clone(), nil
+}
+
+/////////////
+// dlChunk //
+/////////////
+
+func (c *dlChunk) offset() int64    { return c.Start + atomic.LoadInt64(&c.Written) }
+func (c *dlChunk) remaining() int64 { return c.End - c.offset() }
+
+func (w *chunkWriter) Write(b []byte) (n int, err error) {
+	n, err = w.fh.WriteAt(b, w.chunk.offset())
+	atomic.AddInt64(&w.chunk.Written, int64(n))
+	return
+}
@@ -0,0 +1,116 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestExpectedMD5(t *testing.T) {
+	var (
+		sum    = md5.Sum([]byte("content"))
+		md5hex = hex.EncodeToString(sum[:])
+	)
+	tests := []struct {
+		hdr      http.Header
+		md       cos.SimpleKVs
+		expected string
+	}{
+		{http.Header{}, cos.SimpleKVs{cluster.MD5ObjMD: `"` + md5hex + `"`}, md5hex},
+		{http.Header{http.CanonicalHeaderKey(hdrContentMD5): {base64.StdEncoding.EncodeToString(sum[:])}}, nil, md5hex},
+		{http.Header{"Etag": {`"` + md5hex + `"`}}, nil, md5hex},
+		{http.Header{"Etag": {`W/"` + md5hex + `"`}}, nil, ""},
+		{http.Header{"Etag": {`"5f9c1c1a-1a2b"`}}, nil, ""},
+		{http.Header{}, nil, ""},
+	}
+	for _, test := range tests {
+		actual := expectedMD5(&http.Response{Header: test.hdr}, remoteObjInfo{md: test.md})
+		tassert.Errorf(t, actual == test.expected, "expected %q, got %q (headers: %v)", test.expected, actual, test.hdr)
+	}
+}
+
+func TestFetchChunks(t *testing.T) {
+	var (
+		content = bytes.Repeat([]byte("0123456789abcdef"), 4096)
+		etag    atomic.Value
+		modTime = time.Now()
+	)
+	etag.Store(`"v1"`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag.Load().(string))
+		http.ServeContent(w, r, "obj", modTime, bytes.NewReader(content))
+	}))
+	defer srv.Close()
+
+	var (
+		task = &singleObjectTask{
+			job: &sliceDlJob{baseDlJob: baseDlJob{t: newThrottler(DlLimits{}), notif: &NotifDownload{}}},
+		}
+		workFQN = filepath.Join(t.TempDir(), "obj")
+		pd      = &partialDl{Link: srv.URL, WorkFQN: workFQN, Size: int64(len(content)), Validator: `"v1"`}
+	)
+	pd.split(3)
+	tassert.Fatalf(t, len(pd.Chunks) == 3, "expected 3 chunks, got %d", len(pd.Chunks))
+	tassert.Fatalf(t, pd.Chunks[2].End == pd.Size, "last chunk must end at %d", pd.Size)
+
+	fh, err := os.OpenFile(workFQN, os.O_CREATE|os.O_WRONLY, cos.PermRWR)
+	tassert.CheckFatal(t, err)
+	defer fh.Close()
+	tassert.CheckFatal(t, fh.Truncate(pd.Size))
+
+	// the first chunk has been partially written prior to "interruption"
+	half := (pd.Chunks[0].End - pd.Chunks[0].Start) / 2
+	_, err = fh.WriteAt(content[:half], 0)
+	tassert.CheckFatal(t, err)
+	pd.Chunks[0].Written = half
+
+	for _, chunk := range pd.Chunks {
+		err := task.fetchChunk(context.Background(), fh, pd, chunk, nil)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, chunk.remaining() == 0, "chunk [%d, %d) not fully written", chunk.Start, chunk.End)
+	}
+	tassert.Errorf(t, pd.written() == pd.Size, "expected %d bytes written, got %d", pd.Size, pd.written())
+	tassert.Errorf(t, task.currentSize.Load() == pd.Size-half, "expected progress %d, got %d",
+		pd.Size-half, task.currentSize.Load())
+
+	b, err := os.ReadFile(workFQN)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, bytes.Equal(b, content), "downloaded content differs from the source")
+
+	// validate against the source's MD5 (and compute the bucket's checksum)
+	var (
+		buf = make([]byte, cos.KiB)
+		sum = md5.Sum(content)
+	)
+	pd.MD5 = hex.EncodeToString(sum[:])
+	cksum, err := pd.checksum(cos.ChecksumXXHash, buf)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, cksum.Type() == cos.ChecksumXXHash && cksum.Value() != "", "unexpected checksum %s", cksum)
+	pd.MD5 = hex.EncodeToString(make([]byte, md5.Size))
+	_, err = pd.checksum(cos.ChecksumNone, buf)
+	tassert.Errorf(t, err != nil, "expected checksum mismatch")
+
+	// the object has changed at the source
+	etag.Store(`"v2"`)
+	pd.Chunks[1].Written = 0
+	err = task.fetchChunk(context.Background(), fh, pd, pd.Chunks[1], nil)
+	tassert.Fatalf(t, errors.Is(err, errSourceChanged), "expected %v, got %v", errSourceChanged, err)
+}
@@ -92,6 +92,9 @@ func (t *singleObjectTask) download() {
 	t.ended.Store(time.Now())
 
 	if err != nil {
+		if t.vanished(lom, err) {
+			return
+		}
 		t.markFailed(err.Error())
 		return
 	}
@@ -110,6 +113,11 @@ func (t *singleObjectTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Durat
 	ctx, cancel := context.WithTimeout(t.downloadCtx, timeout)
 	defer cancel()
 
+	// Resume previously interrupted download, if any (see ranged.go).
+	if pd := t.loadPartial(lom); pd != nil {
+		return t.downloadRanged(ctx, lom, pd, nil)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.obj.link, nil)
 	if err != nil {
 		return true, err
@@ -131,11 +139,12 @@ func (t *singleObjectTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Durat
 		return false, cmn.NewHTTPErr(req, "", resp.StatusCode)
 	}
 
-	var (
-		r   = t.wrapReader(ctx, resp.Body)
-		roi = roiFromLink(t.obj.link, resp)
-	)
+	roi := roiFromLink(t.obj.link, resp)
+	if pd := t.newPartial(lom, resp, roi); pd != nil {
+		return t.downloadRanged(ctx, lom, pd, resp.Body)
+	}
 
+	r := t.wrapReader(ctx, resp.Body)
 	t.setTotalSize(roi.size)
 
 	lom.SetCustom(roi.md)
@@ -188,6 +197,35 @@ func (t *singleObjectTask) downloadLocal(lom *cluster.LOM) (err error) {
 	return err
 }
 
+// vanished handles the (web) source object that does not exist anymore -
+// mirror job deletes the local copy if requested (see DlMirrorBody.Delete).
+func (t *singleObjectTask) vanished(lom *cluster.LOM, err error) bool {
+	j, ok := t.job.(*mirrorDlJob)
+	if !ok || !j.delVanished || t.obj.fromRemote {
+		return false
+	}
+	httpErr := cmn.Err2HTTPErr(err)
+	if httpErr == nil || (httpErr.Status != http.StatusNotFound && httpErr.Status != http.StatusGone) {
+		return false
+	}
+	if err := removeVanished(t.parent.t, lom); err != nil {
+		return false // including the case when there's nothing to delete
+	}
+	dlStore.incDeleted(t.jobID())
+	return true
+}
+
+// removeVanished removes the local copy of the object that vanished from the
+// source: evicts it from remote bucket and deletes it from ais bucket.
+func removeVanished(t cluster.Target, lom *cluster.LOM) (err error) {
+	if lom.Bck().IsRemote() {
+		_, err = t.EvictObject(lom)
+	} else {
+		_, err = t.DeleteObject(lom, false /*evict*/)
+	}
+	return
+}
+
 func (t *singleObjectTask) wrapReader(ctx context.Context, r io.ReadCloser) io.ReadCloser {
 	// Create a custom reader to monitor progress every time we read from response body stream.
 	r = &progressReader{
@@ -121,8 +121,18 @@ func ParseStartDownloadRequest(t cluster.Target, bck *cluster.Bck, id string, dl
 			return nil, err
 		}
 		return newSingleDlJob(t, id, bck, dp, dlXact)
+	case DlTypeMirror:
+		dp := &DlMirrorBody{}
+		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
+		if err != nil {
+			return nil, err
+		}
+		if err := dp.Validate(); err != nil {
+			return nil, err
+		}
+		return newMirrorDlJob(t, id, bck, dp, dlXact)
 	default:
-		return nil, errors.New("input does not match any of the supported formats (single, range, multi, backend)")
+		return nil, errors.New("input does not match any of the supported formats (single, range, multi, backend, mirror)")
 	}
 }
 
@@ -197,8 +207,14 @@ func roiFromLink(link string, resp *http.Response) (roi remoteObjInfo) {
 			roi.md[cluster.MD5ObjMD] = v
 		}
 	} else {
-		roi.md = make(cos.SimpleKVs, 1)
+		roi.md = make(cos.SimpleKVs, 3)
 		roi.md[cluster.SourceObjMD] = cluster.SourceWebObjMD
+		if v := resp.Header.Get(cmn.HdrETag); v != "" {
+			roi.md[cluster.ETagObjMD] = v
+		}
+		if v := resp.Header.Get(cmn.HdrLastModified); v != "" {
+			roi.md[cluster.LastModifiedObjMD] = v
+		}
 	}
 	roi.size = resp.ContentLength
 	return
@@ -269,6 +285,10 @@ func CompareObjects(src *cluster.LOM, dst *DstElement) (equal bool, err error) {
 		if err != nil {
 			return false, err
 		}
+		if resp.StatusCode >= http.StatusBadRequest {
+			// Let the download itself fail (or, in case of mirror job, delete the object).
+			return false, nil
+		}
 		roi = roiFromLink(dst.Link, resp)
 	} else {
 		ctx, cancel := context.WithTimeout(context.Background(), headReqTimeout)
@@ -281,7 +301,8 @@ func CompareObjects(src *cluster.LOM, dst *DstElement) (equal bool, err error) {
 		roi = roiFromObjMeta(objMeta)
 	}
 
-	if roi.size != 0 && roi.size != src.SizeBytes() {
+	// NOTE: zero or negative size means unknown (e.g., no "Content-Length").
+	if roi.size > 0 && roi.size != src.SizeBytes() {
 		return false, nil
 	}
 
@@ -5,9 +5,13 @@
 package downloader_test
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/devtools/tassert"
 	"github.com/NVIDIA/aistore/devtools/tutils"
@@ -80,6 +84,62 @@ func TestCompareObject(t *testing.T) {
 	tassert.Errorf(t, equal, "expected the objects to be equal")
 }
 
+func TestCompareObjectWeb(t *testing.T) {
+	const lastModified = "Wed, 21 Oct 2015 07:28:00 GMT"
+	var (
+		src = prepareObject(t)
+		// ETag of the object is its (path) version, e.g. "/v1"
+		srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/gone" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set(cmn.HdrETag, strconv.Quote(r.URL.Path))
+			w.Header().Set(cmn.HdrLastModified, lastModified)
+			w.Header().Set(cmn.HdrContentLength, strconv.FormatInt(src.SizeBytes(), 10))
+		}))
+	)
+	defer srv.Close()
+
+	src.SetCustom(cos.SimpleKVs{
+		cluster.SourceObjMD:       cluster.SourceWebObjMD,
+		cluster.ETagObjMD:         `"/v1"`,
+		cluster.LastModifiedObjMD: lastModified,
+	})
+	equal, err := downloader.CompareObjects(src, &downloader.DstElement{Link: srv.URL + "/v1"})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, equal, "expected the objects to be equal")
+
+	// Modified at the source.
+	equal, err = downloader.CompareObjects(src, &downloader.DstElement{Link: srv.URL + "/v2"})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, !equal, "expected the objects not to be equal (ETag)")
+
+	// Vanished from the source.
+	equal, err = downloader.CompareObjects(src, &downloader.DstElement{Link: srv.URL + "/gone"})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, !equal, "expected the objects not to be equal (not found)")
+}
+
+func TestMirrorBodyValidate(t *testing.T) {
+	tests := []struct {
+		body  downloader.DlMirrorBody
+		valid bool
+	}{
+		{downloader.DlMirrorBody{Template: "https://host/shard-{0..9}.tar", Interval: "1h"}, true},
+		{downloader.DlMirrorBody{Prefix: "train/", Delete: true}, true},
+		{downloader.DlMirrorBody{Template: "https://host/shard-{0..9}.tar", Prefix: "train/"}, false},
+		{downloader.DlMirrorBody{Subdir: "dir"}, false},
+		{downloader.DlMirrorBody{Interval: "1s"}, false},
+		{downloader.DlMirrorBody{Interval: "hourly"}, false},
+	}
+	for _, test := range tests {
+		test.body.Bck.Name = "bck"
+		err := test.body.Validate()
+		tassert.Errorf(t, (err == nil) == test.valid, "%+v: expected valid=%t, got err: %v", test.body, test.valid, err)
+	}
+}
+
 func prepareObject(t *testing.T) *cluster.LOM {
 	out := tutils.PrepareObjects(t, tutils.ObjectsDesc{
 		CTs: []tutils.ContentTypeDesc{{
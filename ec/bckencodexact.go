@@ -84,17 +84,18 @@ func (r *XactBckEncode) Run() {
 		r.Finish(err)
 		return
 	}
-	if !bck.Props.EC.Enabled {
+	if !bck.Props.ECUsed() {
 		r.Finish(fmt.Errorf("bucket %q does not have EC enabled", r.bck.Name))
 		return
 	}
 
 	jg := mpather.NewJoggerGroup(&mpather.JoggerGroupOpts{
-		T:        r.t,
-		Bck:      r.bck.Bck,
-		CTs:      []string{fs.ObjectType},
-		VisitObj: r.bckEncode,
-		DoLoad:   mpather.Load,
+		T:           r.t,
+		Bck:         r.bck.Bck,
+		CTs:         []string{fs.ObjectType},
+		VisitObj:    r.bckEncode,
+		DoLoad:      mpather.Load,
+		WaitResumed: r.WaitResumed,
 	})
 	jg.Run()
 
@@ -137,6 +138,10 @@ func (r *XactBckEncode) bckEncode(lom *cluster.LOM, _ []byte) error {
 	if !local {
 		return nil
 	}
+	// The object's storage class does not use EC.
+	if !lom.ECEnabled() {
+		return nil
+	}
 	mdFQN, _, err := cluster.HrwFQN(lom.Bck(), fs.ECMetaType, lom.ObjName)
 	if err != nil {
 		glog.Warningf("metadata FQN generation failed %q: %v", lom.FQN, err)
@@ -0,0 +1,217 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/mirror"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xreg"
+)
+
+// Re-encode: brings the redundancy of the bucket's (local) objects in line with
+// the bucket's current configuration - storage classes (see cmn.StorageClass)
+// and, otherwise, bucket-wide mirroring and EC. Namely, for each object:
+// - adds or removes its local copies;
+// - erasure codes the object if it is not yet, or if its EC geometry
+//   (data and parity slices) differs from the required one;
+// - removes EC slices and metadata of the object that must not be erasure coded.
+// Re-encoding is not atomic: the new slices and metafiles overwrite the old ones
+// in place, one target at a time, and the remaining stale ones get removed only
+// once the object is re-encoded. In between, the object's slices may have mixed
+// (old and new) geometry - the object's main replica is what keeps it readable
+// until re-encoding completes (see docs/storage_svcs.md).
+
+type (
+	reencFactory struct {
+		xreg.RenewBase
+		xact *XactBckReencode
+	}
+	XactBckReencode struct {
+		xaction.XactBckJog
+		smap       *cluster.Smap
+		wg         sync.WaitGroup // pending EC encodings
+		remirrored atomic.Int64
+		encoded    atomic.Int64
+		reencoded  atomic.Int64
+		cleanedUp  atomic.Int64
+		pending    atomic.Int64
+	}
+
+	ExtReencodeStats struct {
+		Remirrored int64 `json:"remirrored,string"` // objects that got copies added or removed
+		Encoded    int64 `json:"encoded,string"`    // erasure coded for the first time
+		Reencoded  int64 `json:"reencoded,string"`  // erasure coded anew (with a different geometry)
+		CleanedUp  int64 `json:"cleaned_up,string"` // not erasure coded anymore
+		Pending    int64 `json:"pending,string"`    // EC encodings in progress
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact   = (*XactBckReencode)(nil)
+	_ xreg.Renewable = (*reencFactory)(nil)
+)
+
+//////////////////
+// reencFactory //
+//////////////////
+
+func (*reencFactory) New(args xreg.Args, bck *cluster.Bck) xreg.Renewable {
+	p := &reencFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *reencFactory) Start() error {
+	p.xact = newXactBckReencode(p.T, p.UUID, p.Bck)
+	return nil
+}
+
+func (*reencFactory) Kind() string        { return cmn.ActReencode }
+func (p *reencFactory) Get() cluster.Xact { return p.xact }
+
+// the configuration may have changed again - restart
+func (*reencFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprAbort, nil }
+
+/////////////////////
+// XactBckReencode //
+/////////////////////
+
+func newXactBckReencode(t cluster.Target, uuid string, bck *cluster.Bck) (r *XactBckReencode) {
+	r = &XactBckReencode{smap: t.Sowner().Get()}
+	mpopts := &mpather.JoggerGroupOpts{
+		T:                     t,
+		Bck:                   bck.Bck,
+		CTs:                   []string{fs.ObjectType},
+		VisitObj:              r.visitObj,
+		DoLoad:                mpather.Load,
+		SkipGloballyMisplaced: true,
+		Throttle:              true,
+	}
+	r.XactBckJog.Init(uuid, cmn.ActReencode, bck, mpopts)
+	return
+}
+
+func (r *XactBckReencode) Run() {
+	r.XactBckJog.Run()
+	glog.Infoln(r.String())
+	err := r.XactBckJog.Wait()
+	r.wg.Wait()
+	r.Finish(err)
+}
+
+func (r *XactBckReencode) visitObj(lom *cluster.LOM, buf []byte) error {
+	if _, local, err := lom.HrwTarget(r.smap); err != nil || !local {
+		return nil
+	}
+	size, err := r.remirror(lom, buf)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if cos.IsErrOOS(err) {
+			return cmn.NewAbortedError(r.String(), err.Error())
+		}
+		glog.Errorf("%s: failed to re-mirror %s: %v", r, lom, err)
+	}
+	if size > 0 {
+		r.remirrored.Inc()
+		r.ObjectsInc()
+		r.BytesAdd(size)
+	}
+	// NOTE: with EC disabled for the entire bucket, the EC manager does not
+	// accept requests - leaving the slices (if any) in place
+	if !lom.Bprops().ECUsed() {
+		return nil
+	}
+	if err := r.reencode(lom); err != nil {
+		glog.Errorf("%s: failed to re-encode %s: %v", r, lom, err)
+	}
+	return nil
+}
+
+func (r *XactBckReencode) remirror(lom *cluster.LOM, buf []byte) (int64, error) {
+	copies := 1
+	if mconf := lom.MirrorConf(); mconf.Enabled {
+		copies = int(mconf.Copies)
+	}
+	if lom.NumCopies() == copies {
+		return 0, nil
+	}
+	return mirror.MakeNCopies(lom, copies, buf)
+}
+
+func (r *XactBckReencode) reencode(lom *cluster.LOM) error {
+	mdFQN, _, err := cluster.HrwFQN(lom.Bck(), fs.ECMetaType, lom.ObjName)
+	if err != nil {
+		return err
+	}
+	md, err := LoadMetadata(mdFQN)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	ecConf := lom.ECConf()
+	if !ecConf.Enabled {
+		if md != nil {
+			ECM.CleanupObject(lom)
+			r.cleanedUp.Inc()
+			r.ObjectsInc()
+		}
+		return nil
+	}
+	if md != nil && md.Data == ecConf.DataSlices && md.Parity == ecConf.ParitySlices &&
+		md.IsCopy == IsECCopy(lom.SizeBytes(), ecConf) {
+		return nil // nothing to do
+	}
+	// the new slices (and metafiles) overwrite the old ones in place (see above);
+	// the old ones that remain get removed upon success
+	cb := func(lom *cluster.LOM, err error) {
+		if err == nil {
+			if md == nil {
+				r.encoded.Inc()
+			} else {
+				r.reencoded.Inc()
+				if errCl := ECM.CleanupStale(lom, md); errCl != nil {
+					glog.Errorf("%s: failed to cleanup stale slices of %s: %v", r, lom, errCl)
+				}
+			}
+			r.ObjectsInc()
+			r.BytesAdd(lom.SizeBytes())
+		} else {
+			glog.Errorf("%s: failed to erasure-code %s: %v", r, lom, err)
+		}
+		r.pending.Dec()
+		r.wg.Done()
+	}
+	r.wg.Add(1)
+	r.pending.Inc()
+	if err := ECM.EncodeObject(lom, cb); err != nil {
+		r.pending.Dec()
+		r.wg.Done()
+		return err
+	}
+	return nil
+}
+
+func (r *XactBckReencode) Stats() cluster.XactStats {
+	baseStats := &xaction.BaseXactStatsExt{BaseXactStats: *r.XactBckJog.Stats().(*xaction.BaseXactStats)}
+	baseStats.Ext = &ExtReencodeStats{
+		Remirrored: r.remirrored.Load(),
+		Encoded:    r.encoded.Load(),
+		Reencoded:  r.reencoded.Load(),
+		CleanedUp:  r.cleanedUp.Load(),
+		Pending:    r.pending.Load(),
+	}
+	return baseStats
+}
@@ -159,6 +159,7 @@ type (
 		workFQN string             // FQN for temporary slice/replica
 		cksum   *cos.Cksum         // checksum of the slice
 		version string             // version of the remote object
+		custom  cos.SimpleKVs      // custom metadata of the remote object
 	}
 
 	// a source for data response: the data to send to the caller
@@ -278,6 +279,7 @@ func Init(t cluster.Target) {
 	xreg.RegBckXact(&putFactory{})
 	xreg.RegBckXact(&rspFactory{})
 	xreg.RegBckXact(&encFactory{})
+	xreg.RegBckXact(&reencFactory{})
 
 	if err := initManager(t); err != nil {
 		cos.ExitLogf("Failed to init manager: %v", err)
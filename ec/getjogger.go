@@ -588,6 +588,9 @@ func (c *getJogger) restoreMainObj(ctx *restoreCtx) ([]*slice, error) {
 	if version != "" {
 		ctx.lom.SetVersion(version)
 	}
+	if ctx.meta.ObjCustom != nil {
+		ctx.lom.SetCustom(ctx.meta.ObjCustom)
+	}
 	ctx.lom.SetSize(ctx.meta.Size)
 	mainMeta := *ctx.meta
 	mainMeta.SliceID = 0
@@ -778,7 +781,7 @@ func (c *getJogger) restoreEncoded(ctx *restoreCtx) error {
 
 // Entry point: restores main objects and slices if possible
 func (c *getJogger) restore(ctx *restoreCtx) error {
-	if ctx.lom.Bprops() == nil || !ctx.lom.Bprops().EC.Enabled {
+	if ctx.lom.Bprops() == nil || !ctx.lom.Bprops().ECUsed() {
 		return ErrorECDisabled
 	}
 
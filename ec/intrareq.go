@@ -23,6 +23,10 @@ const (
 	// a target cleans up the object and notifies all other targets to do
 	// cleanup as well. Destinations do not have to respond
 	reqDel
+	// a target that has a damaged slice (or metafile) of the object asks
+	// the object's main target to erasure code the object anew.
+	// The destination does not have to respond
+	reqEncode
 )
 
 type (
@@ -279,7 +279,8 @@ func (mgr *Manager) recvResponse(hdr transport.ObjHdr, object io.Reader, err err
 //   - intra - if true, it is internal request and has low priority
 //   - cb - optional callback that is called after the object is encoded
 func (mgr *Manager) EncodeObject(lom *cluster.LOM, cb ...cluster.OnFinishObj) error {
-	if !lom.Bprops().EC.Enabled {
+	ecConf := lom.ECConf()
+	if !ecConf.Enabled {
 		return ErrorECDisabled
 	}
 
@@ -287,12 +288,12 @@ func (mgr *Manager) EncodeObject(lom *cluster.LOM, cb ...cluster.OnFinishObj) er
 		return cs.Err
 	}
 
-	isECCopy := IsECCopy(lom.SizeBytes(), &lom.Bprops().EC)
+	isECCopy := IsECCopy(lom.SizeBytes(), ecConf)
 	targetCnt := mgr.targetCnt.Load()
 
 	// tradeoff: encoding small object might require just 1 additional target available
 	// we will start xaction to satisfy this request
-	if required := lom.Bprops().EC.RequiredEncodeTargets(); !isECCopy && int(targetCnt) < required {
+	if required := ecConf.RequiredEncodeTargets(); !isECCopy && int(targetCnt) < required {
 		glog.Warningf("not enough targets to encode the object; actual: %v, required: %v", targetCnt, required)
 		return ErrorInsufficientTargets
 	}
@@ -305,7 +306,7 @@ func (mgr *Manager) EncodeObject(lom *cluster.LOM, cb ...cluster.OnFinishObj) er
 	}
 
 	req := allocateReq(ActSplit, lom.LIF())
-	req.IsCopy = IsECCopy(lom.SizeBytes(), &lom.Bprops().EC)
+	req.IsCopy = isECCopy
 	if len(cb) != 0 {
 		req.rebuild = true
 		req.Callback = cb[0]
@@ -317,7 +318,7 @@ func (mgr *Manager) EncodeObject(lom *cluster.LOM, cb ...cluster.OnFinishObj) er
 }
 
 func (mgr *Manager) CleanupObject(lom *cluster.LOM) {
-	if !lom.Bprops().EC.Enabled {
+	if !lom.Bprops().ECUsed() {
 		return
 	}
 	cos.Assert(lom.FQN != "")
@@ -326,8 +327,49 @@ func (mgr *Manager) CleanupObject(lom *cluster.LOM) {
 	mgr.RestoreBckPutXact(lom.Bck()).cleanup(req, lom)
 }
 
+// CleanupStale removes slices and metadata of the re-encoded object from the
+// targets that have them as per the previous encoding (`prev`) but do not
+// have them as per the current one.
+func (mgr *Manager) CleanupStale(lom *cluster.LOM, prev *Metadata) error {
+	md, err := LoadMetadata(cluster.NewCTFromLOM(lom, fs.ECMetaType).FQN())
+	if err != nil {
+		return err
+	}
+	nodes := make([]*cluster.Snode, 0, len(prev.Daemons))
+	for _, tsi := range prev.RemoteTargets(mgr.t) {
+		if _, ok := md.Daemons[tsi.ID()]; !ok {
+			nodes = append(nodes, tsi)
+		}
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+	request := newIntraReq(reqDel, nil, lom.Bck()).NewPack(mgr.t.SmallMMSA())
+	o := transport.AllocSend()
+	o.Hdr = transport.ObjHdr{Bck: lom.Bucket(), ObjName: lom.ObjName, Opaque: request, Opcode: reqDel}
+	o.Callback = mgr.cleanupSentCallback
+	return mgr.req().Send(o, nil, nodes...)
+}
+
+// RequestEncode asks the object's main target (`tsi`) to erasure code the object
+// anew - to regenerate its damaged slices and metafiles stored on other targets.
+func (mgr *Manager) RequestEncode(bck *cluster.Bck, objName string, tsi *cluster.Snode) error {
+	request := newIntraReq(reqEncode, nil, bck).NewPack(mgr.t.SmallMMSA())
+	o := transport.AllocSend()
+	o.Hdr = transport.ObjHdr{Bck: bck.Bck, ObjName: objName, Opaque: request, Opcode: reqEncode}
+	o.Callback = mgr.cleanupSentCallback
+	return mgr.req().Send(o, nil, tsi)
+}
+
+func (mgr *Manager) cleanupSentCallback(hdr transport.ObjHdr, _ io.ReadCloser, _ interface{}, err error) {
+	mgr.t.SmallMMSA().Free(hdr.Opaque)
+	if err != nil {
+		glog.Errorf("failed to send o[%s]: %v", hdr.FullName(), err)
+	}
+}
+
 func (mgr *Manager) RestoreObject(lom *cluster.LOM) error {
-	if !lom.Bprops().EC.Enabled {
+	if !lom.Bprops().ECUsed() {
 		return ErrorECDisabled
 	}
 
@@ -336,7 +378,7 @@ func (mgr *Manager) RestoreObject(lom *cluster.LOM) error {
 	}
 	targetCnt := mgr.targetCnt.Load()
 	// NOTE: Restore replica object is done with GFN, safe to always abort.
-	if required := lom.Bprops().EC.RequiredRestoreTargets(); int(targetCnt) < required {
+	if required := lom.ECConf().RequiredRestoreTargets(); int(targetCnt) < required {
 		glog.Warningf("not enough targets to restore the object; actual: %v, required: %v", targetCnt, required)
 		return ErrorInsufficientTargets
 	}
@@ -387,14 +429,14 @@ func (mgr *Manager) BucketsMDChanged() error {
 	newBckMD.Range(&provider, nil, func(nbck *cluster.Bck) bool {
 		oprops, ok := oldBckMD.Get(nbck)
 		if !ok {
-			if nbck.Props.EC.Enabled {
+			if nbck.Props.ECUsed() {
 				mgr.enableBck(nbck)
 			}
 			return false
 		}
-		if !oprops.EC.Enabled && nbck.Props.EC.Enabled {
+		if !oprops.ECUsed() && nbck.Props.ECUsed() {
 			mgr.enableBck(nbck)
-		} else if oprops.EC.Enabled && !nbck.Props.EC.Enabled {
+		} else if oprops.ECUsed() && !nbck.Props.ECUsed() {
 			mgr.disableBck(nbck)
 		}
 
@@ -423,10 +465,11 @@ func (mgr *Manager) ListenSmapChanged() {
 	mgr.bmd.Range(&provider, nil, func(bck *cluster.Bck) bool {
 		bckName, bckProps := bck.Name, bck.Props
 		bckXacts := mgr.getBckXactsUnlocked(bckName)
-		if !bckProps.EC.Enabled {
+		if !bckProps.ECUsed() {
 			return false
 		}
-		if required := bckProps.EC.RequiredEncodeTargets(); targetCnt < required {
+		requiredEncode, requiredRestore := bckProps.ECRequiredTargets()
+		if required := requiredEncode; targetCnt < required {
 			glog.Warningf("not enough targets for EC encoding for bucket %s; actual: %v, expected: %v",
 				bckName, targetCnt, required)
 			bckXacts.AbortPut()
@@ -434,7 +477,7 @@ func (mgr *Manager) ListenSmapChanged() {
 		// NOTE: this doesn't guarantee that present targets are sufficient to restore an object
 		// if one target was killed, and a new one joined, this condition will be satisfied even though
 		// slices of the object are not present on the new target
-		if required := bckProps.EC.RequiredRestoreTargets(); targetCnt < required {
+		if required := requiredRestore; targetCnt < required {
 			glog.Warningf("not enough targets for EC restoring for bucket %s; actual: %v, expected: %v",
 				bckName, targetCnt, required)
 			bckXacts.AbortGet()
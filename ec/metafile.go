@@ -15,7 +15,10 @@ import (
 	"github.com/OneOfOne/xxhash"
 )
 
-const MDVersionLast = 1 // current version of metadata
+const (
+	MDVersionLast = 2 // current version of metadata
+	mdVersionV1   = 1 // older version without custom object metadata (still supported)
+)
 
 // Metadata - EC information stored in metafiles for every encoded object
 type Metadata struct {
@@ -32,6 +35,7 @@ type Metadata struct {
 	SliceID     int              // 0 for full replica, 1 to N for slices
 	MDVersion   uint32           // Metadata format version
 	IsCopy      bool             // object is replicated(true) or encoded(false)
+	ObjCustom   cos.SimpleKVs    // custom metadata of the original object
 }
 
 // interface guard
@@ -127,9 +131,11 @@ func (md *Metadata) Unpack(unpacker *cos.ByteUnpack) (err error) {
 	switch md.MDVersion {
 	case MDVersionLast:
 		err = md.unpackLastVersion(unpacker)
+	case mdVersionV1:
+		err = md.unpackV1(unpacker)
 	default:
-		err = fmt.Errorf("unsupported metadata format version %d. Only %d supported",
-			md.MDVersion, MDVersionLast)
+		err = fmt.Errorf("unsupported metadata format version %d. Only %d and %d supported",
+			md.MDVersion, mdVersionV1, MDVersionLast)
 	}
 	if err != nil {
 		return
@@ -147,6 +153,28 @@ func (md *Metadata) Unpack(unpacker *cos.ByteUnpack) (err error) {
 }
 
 func (md *Metadata) unpackLastVersion(unpacker *cos.ByteUnpack) (err error) {
+	if err = md.unpackV1(unpacker); err != nil {
+		return
+	}
+	var cnt int32
+	if cnt, err = unpacker.ReadInt32(); err != nil || cnt == 0 {
+		return
+	}
+	md.ObjCustom = make(cos.SimpleKVs, cnt)
+	for ; cnt > 0; cnt-- {
+		var k, v string
+		if k, err = unpacker.ReadString(); err != nil {
+			return
+		}
+		if v, err = unpacker.ReadString(); err != nil {
+			return
+		}
+		md.ObjCustom[k] = v
+	}
+	return
+}
+
+func (md *Metadata) unpackV1(unpacker *cos.ByteUnpack) (err error) {
 	var i16 uint16
 	if md.Generation, err = unpacker.ReadInt64(); err != nil {
 		return
@@ -202,6 +230,13 @@ func (md *Metadata) Pack(packer *cos.BytePack) {
 	packer.WriteString(md.CksumType)
 	packer.WriteString(md.CksumValue)
 	packer.WriteMapStrUint16(md.Daemons)
+	if md.MDVersion != mdVersionV1 {
+		packer.WriteInt32(int32(len(md.ObjCustom)))
+		for k, v := range md.ObjCustom {
+			packer.WriteString(k)
+			packer.WriteString(v)
+		}
+	}
 	h := xxhash.Checksum64S(packer.Bytes(), cos.MLCG32)
 	packer.WriteUint64(h)
 }
@@ -211,8 +246,15 @@ func (md *Metadata) PackedSize() int {
 	for k := range md.Daemons {
 		daemonListSz += cos.PackedStrLen(k) + cos.SizeofI16
 	}
+	var customSz int
+	if md.MDVersion != mdVersionV1 {
+		customSz = cos.SizeofLen
+		for k, v := range md.ObjCustom {
+			customSz += cos.PackedStrLen(k) + cos.PackedStrLen(v)
+		}
+	}
 	return cos.SizeofI32 + cos.SizeofI64*2 + cos.SizeofI16*3 + 1 /*isCopy*/ +
 		cos.PackedStrLen(md.ObjCksum) + cos.PackedStrLen(md.ObjVersion) +
 		cos.PackedStrLen(md.CksumType) + cos.PackedStrLen(md.CksumValue) +
-		cos.PackedStrLen(md.FullReplica) + daemonListSz + cos.SizeofI64 /*md cksum*/
+		cos.PackedStrLen(md.FullReplica) + daemonListSz + customSz + cos.SizeofI64 /*md cksum*/
 }
@@ -80,8 +80,9 @@ func (ctx *encodeCtx) freeReplica() {
 func (*putJogger) newCtx(lom *cluster.LOM, meta *Metadata) (ctx *encodeCtx, err error) {
 	ctx = allocCtx()
 	ctx.lom = lom
-	ctx.dataSlices = lom.Bprops().EC.DataSlices
-	ctx.paritySlices = lom.Bprops().EC.ParitySlices
+	ecConf := lom.ECConf()
+	ctx.dataSlices = ecConf.DataSlices
+	ctx.paritySlices = ecConf.ParitySlices
 	ctx.meta = meta
 
 	totalCnt := ctx.paritySlices + ctx.dataSlices
@@ -117,7 +118,14 @@ func (c *putJogger) processRequest(req *request) {
 		if err = lom.Load(false /*cache it*/, false /*locked*/); err != nil {
 			return
 		}
-		ecConf := lom.Bprops().EC
+		ecConf := lom.ECConf()
+		if !ecConf.Enabled { // e.g., storage class has changed in the meantime
+			err = ErrorECDisabled
+			if req.Callback != nil {
+				req.Callback(lom, err)
+			}
+			return
+		}
 		memRequired = lom.SizeBytes() * int64(ecConf.DataSlices+ecConf.ParitySlices) / int64(ecConf.ParitySlices)
 		c.toDisk = useDisk(memRequired)
 	}
@@ -206,7 +214,7 @@ func (c *putJogger) splitAndDistribute(ctx *encodeCtx) error {
 func (c *putJogger) encode(req *request, lom *cluster.LOM) error {
 	var (
 		cksumValue, cksumType string
-		ecConf                = lom.Bprops().EC
+		ecConf                = lom.ECConf()
 	)
 	if glog.FastV(4, glog.SmoduleEC) {
 		glog.Infof("Encoding %q...", lom.FQN)
@@ -237,6 +245,7 @@ func (c *putJogger) encode(req *request, lom *cluster.LOM) error {
 		CksumType:   cksumType,
 		FullReplica: c.parent.t.Snode().ID(),
 		Daemons:     make(cos.MapStrUint16, reqTargets),
+		ObjCustom:   lom.Custom(),
 	}
 
 	c.parent.ObjectsInc()
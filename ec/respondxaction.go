@@ -163,6 +163,20 @@ func (r *XactRespond) trySendCT(iReq intraReq, hdr *transport.ObjHdr, bck *clust
 	return r.dataResponse(respPut, hdr, fqn, bck, objName, md)
 }
 
+// erasure code the (local) object anew - the new slices and metafiles replace
+// the existing ones on all targets
+func (*XactRespond) encode(bck *cluster.Bck, objName string) error {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.Init(bck.Bck); err != nil {
+		return err
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		return err
+	}
+	return ECM.EncodeObject(lom)
+}
+
 // DispatchReq is responsible for handling request from other targets
 func (r *XactRespond) DispatchReq(iReq intraReq, hdr *transport.ObjHdr, bck *cluster.Bck) {
 	switch hdr.Opcode {
@@ -176,6 +190,11 @@ func (r *XactRespond) DispatchReq(iReq intraReq, hdr *transport.ObjHdr, bck *clu
 		if err != nil {
 			glog.Error(err)
 		}
+	case reqEncode:
+		// another target has a damaged slice or metafile of the object
+		if err := r.encode(bck, hdr.ObjName); err != nil {
+			glog.Errorf("%s failed to re-encode %s/%s: %v", r.t.Snode(), bck.Name, hdr.ObjName, err)
+		}
 	default:
 		// invalid request detected
 		glog.Errorf("Invalid request type %d", hdr.Opcode)
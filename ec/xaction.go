@@ -131,6 +131,7 @@ func (r *xactReqBase) ecRequestsEnabled() bool {
 func newSliceResponse(md *Metadata, attrs *cmn.ObjAttrs, fqn string) (reader cos.ReadOpenCloser, err error) {
 	attrs.Ver = md.ObjVersion
 	attrs.Cksum = cos.NewCksum(md.CksumType, md.CksumValue)
+	attrs.AddMD = md.ObjCustom
 
 	stat, err := os.Stat(fqn)
 	if err != nil {
@@ -170,6 +171,7 @@ func newReplicaResponse(attrs *cmn.ObjAttrs, bck *cluster.Bck,
 	attrs.Ver = lom.Version()
 	attrs.Atime = lom.AtimeUnix()
 	attrs.Cksum = lom.Checksum()
+	attrs.AddMD = lom.Custom()
 	return reader, nil
 }
 
@@ -298,6 +300,9 @@ func (r *xactECBase) readRemote(lom *cluster.LOM, daemonID, uname string, reques
 	if sw.version != "" {
 		lom.SetVersion(sw.version)
 	}
+	if sw.custom != nil {
+		lom.SetCustom(sw.custom)
+	}
 	lom.SetCksum(sw.cksum)
 	lom.Uncache(true)
 	return sw.n, nil
@@ -356,6 +361,7 @@ func (r *xactECBase) writeRemote(daemonIDs []string, lom *cluster.LOM, src *data
 		Size:  src.size,
 		Ver:   lom.Version(),
 		Atime: lom.AtimeUnix(),
+		AddMD: lom.Custom(),
 	}
 	if src.metadata != nil && src.metadata.SliceID != 0 {
 		// for a slice read everything from slice's metadata
@@ -407,6 +413,9 @@ func _writerReceive(writer *slice, exists bool, objAttrs cmn.ObjAttrs, reader io
 	if writer.version == "" && objAttrs.Ver != "" {
 		writer.version = objAttrs.Ver
 	}
+	if writer.custom == nil && objAttrs.AddMD != nil {
+		writer.custom = objAttrs.AddMD
+	}
 
 	writer.wg.Done()
 	slab.Free(buf)
@@ -25,6 +25,9 @@ type (
 		Deps        []byte       `json:"dependencies"`
 		Runtime     string       `json:"runtime"`
 		WaitTimeout cos.Duration `json:"wait_timeout"`
+
+		// arguments of the in-process transform (RuntimeBuiltin)
+		Args cos.SimpleKVs `json:"args,omitempty"`
 	}
 
 	InfoList []Info
@@ -34,6 +37,17 @@ type (
 		ObjCount int64 `json:"obj_count"`
 		InBytes  int64 `json:"in_bytes"`
 		OutBytes int64 `json:"out_bytes"`
+
+		Stages []StageInfo `json:"stages,omitempty"` // as a stage of ETL pipeline(s)
+	}
+	// Stats of the ETL as a given stage of a given ETL pipeline (see Pipeline).
+	StageInfo struct {
+		Pipeline string `json:"pipeline"` // pipeline ID (separated IDs of the stages)
+		Stage    int    `json:"stage"`    // starting from 0
+
+		ObjCount int64 `json:"obj_count"`
+		InBytes  int64 `json:"in_bytes"`
+		OutBytes int64 `json:"out_bytes"`
 	}
 
 	PodsLogsMsg []PodLogsMsg
@@ -70,6 +84,13 @@ func (m BuildMsg) Validate() error {
 	if m.Runtime == "" {
 		return fmt.Errorf("runtime is not specified")
 	}
+	switch m.Runtime {
+	case RuntimeBuiltin:
+		if _, ok := getBuiltin(string(m.Code)); !ok {
+			return fmt.Errorf("unknown built-in transform %q (expecting one of: %v)", m.Code, Builtins())
+		}
+		return nil
+	}
 	if _, ok := runtime.Runtimes[m.Runtime]; !ok {
 		return fmt.Errorf("unsupported runtime provided: %s", m.Runtime)
 	}
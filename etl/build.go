@@ -8,16 +8,25 @@ import (
 	"strings"
 
 	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/etl/runtime"
 )
 
 func Build(t cluster.Target, msg BuildMsg) error {
+	if isInproc(msg.Runtime) {
+		return startInproc(t, msg)
+	}
 	// Initialize runtime.
 	r, exists := runtime.Runtimes[msg.Runtime]
 	cos.Assert(exists) // Runtime should be checked in proxy during validation.
 
+	if local, err := checkRuntime(cmn.GCO.Get()); err != nil {
+		return err
+	} else if local {
+		return buildLocal(t, msg, r)
+	}
 	var (
 		// We clean up the `msg.ID` as K8s doesn't allow `_` and uppercase
 		// letters in the names.
@@ -0,0 +1,189 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Built-in in-process transforms (see inproc.go) and their arguments (BuildMsg.Args):
+//
+// * gzip:         compress; "level" - compression level (1 through 9), optional.
+// * gunzip:       decompress.
+// * sniff:        output the content type (e.g., "image/png") of the object
+//                 as detected by the first 512 bytes (see http.DetectContentType).
+// * json-project: project each JSON object in the stream (e.g., JSON lines)
+//                 onto the given top-level fields; "fields" - comma-separated, required.
+// * tar-filter:   output only those members of the tarball that match "include"
+//                 and do not match "exclude" - both comma-separated shell patterns
+//                 (see path.Match), both optional.
+
+const (
+	BuiltinGzip        = "gzip"
+	BuiltinGunzip      = "gunzip"
+	BuiltinSniff       = "sniff"
+	BuiltinJSONProject = "json-project"
+	BuiltinTarFilter   = "tar-filter"
+)
+
+const sniffLen = 512 // see http.DetectContentType
+
+func init() {
+	RegisterBuiltin(BuiltinGzip, newGzip)
+	RegisterBuiltin(BuiltinGunzip, newGunzip)
+	RegisterBuiltin(BuiltinSniff, newSniff)
+	RegisterBuiltin(BuiltinJSONProject, newJSONProject)
+	RegisterBuiltin(BuiltinTarFilter, newTarFilter)
+}
+
+func newGzip(args cos.SimpleKVs) (XformFunc, error) {
+	level := gzip.DefaultCompression
+	if s, ok := args["level"]; ok {
+		var err error
+		if level, err = strconv.Atoi(s); err != nil || level < gzip.BestSpeed || level > gzip.BestCompression {
+			return nil, fmt.Errorf("invalid compression level %q (expecting %d through %d)",
+				s, gzip.BestSpeed, gzip.BestCompression)
+		}
+	}
+	return func(w io.Writer, r io.Reader, buf []byte) error {
+		gzw, err := gzip.NewWriterLevel(w, level)
+		if err != nil {
+			return err
+		}
+		if _, err = io.CopyBuffer(gzw, r, buf); err != nil {
+			gzw.Close()
+			return err
+		}
+		return gzw.Close()
+	}, nil
+}
+
+func newGunzip(cos.SimpleKVs) (XformFunc, error) {
+	return func(w io.Writer, r io.Reader, buf []byte) error {
+		gzr, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		_, err = io.CopyBuffer(w, gzr, buf)
+		gzr.Close()
+		return err
+	}, nil
+}
+
+func newSniff(cos.SimpleKVs) (XformFunc, error) {
+	return func(w io.Writer, r io.Reader, buf []byte) error {
+		n, err := io.ReadFull(r, buf[:sniffLen])
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return err
+		}
+		_, err = io.WriteString(w, http.DetectContentType(buf[:n]))
+		return err
+	}, nil
+}
+
+func newJSONProject(args cos.SimpleKVs) (XformFunc, error) {
+	var fields []string
+	for _, field := range strings.Split(args["fields"], ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("no fields to project (\"fields\" argument is empty or missing)")
+	}
+	return func(w io.Writer, r io.Reader, _ []byte) error {
+		var (
+			dec    = jsoniter.NewDecoder(r)
+			enc    = jsoniter.NewEncoder(w)
+			object map[string]jsoniter.RawMessage
+		)
+		for {
+			object = nil
+			if err := dec.Decode(&object); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+			projected := make(map[string]jsoniter.RawMessage, len(fields))
+			for _, field := range fields {
+				if v, ok := object[field]; ok {
+					projected[field] = v
+				}
+			}
+			if err := enc.Encode(projected); err != nil { // (newline-terminated)
+				return err
+			}
+		}
+	}, nil
+}
+
+func newTarFilter(args cos.SimpleKVs) (XformFunc, error) {
+	include, err := parsePatterns(args["include"])
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := parsePatterns(args["exclude"])
+	if err != nil {
+		return nil, err
+	}
+	return func(w io.Writer, r io.Reader, buf []byte) error {
+		var (
+			tr = tar.NewReader(r)
+			tw = tar.NewWriter(w)
+		)
+		for {
+			hdr, err := tr.Next()
+			if err != nil {
+				if err == io.EOF {
+					return tw.Close()
+				}
+				return err
+			}
+			if (len(include) > 0 && !matchAny(include, hdr.Name)) || matchAny(exclude, hdr.Name) {
+				continue
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := io.CopyBuffer(tw, tr, buf); err != nil {
+				return err
+			}
+		}
+	}, nil
+}
+
+func parsePatterns(s string) (patterns []string, err error) {
+	for _, pattern := range strings.Split(s, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern == "" {
+			continue
+		}
+		if _, err = path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"archive/tar"
+	"bytes"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuiltinTest", func() {
+	xform := func(name string, args cos.SimpleKVs, in []byte) ([]byte, error) {
+		newXform, exists := getBuiltin(name)
+		Expect(exists).To(BeTrue())
+		f, err := newXform(args)
+		Expect(err).NotTo(HaveOccurred())
+		out := &bytes.Buffer{}
+		err = f(out, bytes.NewReader(in), make([]byte, cos.KiB))
+		return out.Bytes(), err
+	}
+
+	It("should compress and decompress", func() {
+		data := []byte(strings.Repeat("in-process ETL ", 1000))
+		compressed, err := xform(BuiltinGzip, cos.SimpleKVs{"level": "9"}, data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(len(compressed)).To(BeNumerically("<", len(data)))
+
+		decompressed, err := xform(BuiltinGunzip, nil, compressed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decompressed).To(Equal(data))
+
+		_, err = xform(BuiltinGunzip, nil, data)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject invalid arguments", func() {
+		for name, args := range map[string]cos.SimpleKVs{
+			BuiltinGzip:        {"level": "10"},
+			BuiltinJSONProject: {},
+			BuiltinTarFilter:   {"include": "[a-"},
+		} {
+			newXform, _ := getBuiltin(name)
+			_, err := newXform(args)
+			Expect(err).To(HaveOccurred())
+		}
+	})
+
+	It("should sniff content type", func() {
+		png := []byte("\x89PNG\x0D\x0A\x1A\x0A" + strings.Repeat("\x00", 1000))
+		out, err := xform(BuiltinSniff, nil, png)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(Equal("image/png"))
+
+		out, err = xform(BuiltinSniff, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(Equal("text/plain; charset=utf-8"))
+	})
+
+	It("should project JSON fields", func() {
+		in := []byte(`{"id": 1, "label": "cat", "pixels": [1, 2, 3]}
+{"id": 2, "pixels": [4, 5, 6]}`)
+		out, err := xform(BuiltinJSONProject, cos.SimpleKVs{"fields": "id, label"}, in)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(Equal("{\"id\":1,\"label\":\"cat\"}\n{\"id\":2}\n"))
+	})
+
+	It("should filter tar members", func() {
+		var (
+			in    = &bytes.Buffer{}
+			tw    = tar.NewWriter(in)
+			names = []string{"a.jpg", "a.cls", "b.jpg", "b.json", "c.jpg"}
+		)
+		for _, name := range names {
+			Expect(tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(name)), Mode: 0o644})).To(Succeed())
+			_, err := tw.Write([]byte(name))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(tw.Close()).To(Succeed())
+
+		out, err := xform(BuiltinTarFilter, cos.SimpleKVs{"include": "*.jpg,*.cls", "exclude": "c.*"}, in.Bytes())
+		Expect(err).NotTo(HaveOccurred())
+
+		var (
+			tr       = tar.NewReader(bytes.NewReader(out))
+			filtered []string
+		)
+		for {
+			hdr, err := tr.Next()
+			if err != nil {
+				break
+			}
+			content := &bytes.Buffer{}
+			_, err = content.ReadFrom(tr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content.String()).To(Equal(hdr.Name))
+			filtered = append(filtered, hdr.Name)
+		}
+		Expect(filtered).To(Equal([]string{"a.jpg", "a.cls", "b.jpg"}))
+	})
+})
@@ -19,7 +19,6 @@ import (
 	"github.com/NVIDIA/aistore/fs"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
-	corev1 "k8s.io/api/core/v1"
 )
 
 var _ = Describe("CommunicatorTest", func() {
@@ -28,6 +27,7 @@ var _ = Describe("CommunicatorTest", func() {
 		comm              Communicator
 		tMock             cluster.Target
 		transformerServer *httptest.Server
+		echoServer        *httptest.Server
 		targetServer      *httptest.Server
 		proxyServer       *httptest.Server
 
@@ -78,6 +78,13 @@ var _ = Describe("CommunicatorTest", func() {
 			_, err := w.Write(transformData)
 			Expect(err).NotTo(HaveOccurred())
 		}))
+		echoServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// (HTTP/1.x server may not allow reading the request once the response is being written)
+			b, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = w.Write(b)
+			Expect(err).NotTo(HaveOccurred())
+		}))
 		targetServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			err := comm.Do(w, r, clusterBck, objName)
 			Expect(err).NotTo(HaveOccurred())
@@ -91,6 +98,7 @@ var _ = Describe("CommunicatorTest", func() {
 		_ = os.RemoveAll(tmpDir)
 		proxyServer.Close()
 		transformerServer.Close()
+		echoServer.Close()
 		targetServer.Close()
 	})
 
@@ -102,12 +110,9 @@ var _ = Describe("CommunicatorTest", func() {
 
 	for _, commType := range tests {
 		It("should perform transformation "+commType, func() {
-			pod := &corev1.Pod{}
-			pod.SetName("somename")
-
 			comm = makeCommunicator(commArgs{
 				t:              tMock,
-				pod:            pod,
+				podName:        "somename",
 				commType:       commType,
 				transformerURL: transformerServer.URL,
 			})
@@ -120,6 +125,52 @@ var _ = Describe("CommunicatorTest", func() {
 			Expect(len(b)).To(Equal(len(transformData)))
 			Expect(b).To(Equal(transformData))
 		})
+
+		It("should perform transformation by ETL pipeline "+commType, func() {
+			first := makeCommunicator(commArgs{
+				t:              tMock,
+				podName:        "first",
+				commType:       commType,
+				transformerURL: transformerServer.URL,
+			})
+			second := makeCommunicator(commArgs{
+				t:              tMock,
+				podName:        "second",
+				commType:       PushCommType,
+				transformerURL: echoServer.URL,
+			})
+			Expect(reg.put("first", first)).NotTo(HaveOccurred())
+			Expect(reg.put("second", second)).NotTo(HaveOccurred())
+			defer func() {
+				reg.removeByUUID("first")
+				reg.removeByUUID("second")
+				pstats.remove("first")
+			}()
+
+			pipe, err := GetPipeline("first"+cmn.ETLPipelineSepa+"second", tMock.Snode())
+			Expect(err).NotTo(HaveOccurred())
+			r, err := pipe.Get(clusterBck, objName, 0)
+			Expect(err).NotTo(HaveOccurred())
+			b, err := io.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			r.Close()
+			Expect(b).To(Equal(transformData))
+
+			stages := pstats.stages("second")
+			Expect(stages).To(HaveLen(1))
+			Expect(stages[0].Stage).To(Equal(1))
+			Expect(stages[0].ObjCount).To(Equal(int64(1)))
+			Expect(stages[0].InBytes).To(Equal(dataSize))
+			Expect(stages[0].OutBytes).To(Equal(dataSize))
+
+			// only the first stage may use communication type other than push
+			_, err = GetPipeline("second"+cmn.ETLPipelineSepa+"first", tMock.Snode())
+			if commType == PushCommType {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		})
 	}
 })
 
@@ -18,7 +18,6 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/memsys"
-	corev1 "k8s.io/api/core/v1"
 )
 
 type (
@@ -56,7 +55,7 @@ type (
 	commArgs struct {
 		listener       cluster.Slistener
 		t              cluster.Target
-		pod            *corev1.Pod
+		podName        string
 		name           string
 		commType       string
 		transformerURL string
@@ -109,7 +108,7 @@ func makeCommunicator(args commArgs) Communicator {
 		Slistener:      args.listener,
 		t:              args.t,
 		name:           args.name,
-		podName:        args.pod.GetName(),
+		podName:        args.podName,
 		transformerURL: args.transformerURL,
 
 		stats: &commStats{},
@@ -186,25 +185,47 @@ func (pc *pushComm) tryDoRequest(lom *cluster.LOM, timeout time.Duration) (cos.R
 	if err != nil {
 		return nil, err
 	}
+	r, err := pc.put(fh, lom.SizeBytes(), timeout)
+	if err == nil {
+		pc.stats.inBytes.Add(lom.SizeBytes())
+	}
+	return r, err
+}
 
+// transform the output of the previous stage of ETL pipeline (see Pipeline)
+func (pc *pushComm) transform(r cos.ReadCloseSizer, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	body := cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:      r,
+		Size:   r.Size(),
+		ReadCb: func(i int, err error) { pc.stats.inBytes.Add(int64(i)) },
+	})
+	return pc.put(body, r.Size(), timeout)
+}
+
+// PUT `body` (closed in any case) to the ETL container and return the response
+// (the transformed content)
+func (pc *pushComm) put(body io.ReadCloser, size int64, timeout time.Duration) (cos.ReadCloseSizer, error) {
 	var (
 		req    *http.Request
 		resp   *http.Response
 		cancel func()
+		err    error
 	)
 	if timeout != 0 {
 		var ctx context.Context
 		ctx, cancel = context.WithTimeout(context.Background(), timeout)
-		req, err = http.NewRequestWithContext(ctx, http.MethodPut, pc.transformerURL, fh)
+		req, err = http.NewRequestWithContext(ctx, http.MethodPut, pc.transformerURL, body)
 	} else {
-		req, err = http.NewRequest(http.MethodPut, pc.transformerURL, fh)
+		req, err = http.NewRequest(http.MethodPut, pc.transformerURL, body)
 	}
 	if err != nil {
-		cos.Close(fh)
+		cos.Close(body)
 		goto finish
 	}
 
-	req.ContentLength = lom.SizeBytes()
+	if size >= 0 {
+		req.ContentLength = size
+	}
 	req.Header.Set(cmn.HdrContentType, cmn.ContentBinary)
 	resp, err = pc.t.DataClient().Do(req) // nolint:bodyclose // Closed by the caller.
 finish:
@@ -215,7 +236,6 @@ finish:
 		return nil, err
 	}
 
-	pc.stats.inBytes.Add(lom.SizeBytes())
 	return cos.NewReaderWithArgs(cos.ReaderArgs{
 		R:      resp.Body,
 		Size:   resp.ContentLength,
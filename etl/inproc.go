@@ -0,0 +1,259 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// In-process ETL: transformation that runs on the target's own goroutines,
+// without the round-trip to an ETL container (or local process). The
+// transformation is one of the built-in (Go) transforms (see builtin.go).
+// User code (e.g., compiled to WebAssembly) cannot run in process - out of
+// scope, see docs/etl.md.
+//
+// In-process ETLs are started via the `build` request (see BuildMsg) with
+// RuntimeBuiltin and, once started, are addressable by their IDs like any
+// other ETL (on-the-fly and offline transformations, ETL pipelines).
+//
+// The transformation is given the caller's timeout (if any) via its reader
+// and writer: once the timeout expires, both fail with context.DeadlineExceeded.
+
+const RuntimeBuiltin = "builtin" // BuildMsg.Code: name of the built-in transform
+
+type (
+	// XformFunc reads `r` and writes the transformed content into `w`.
+	// `buf` is a scratch buffer (allocated from memsys) the function may use.
+	// The function is called concurrently (for different objects).
+	XformFunc func(w io.Writer, r io.Reader, buf []byte) error
+
+	// NewXformFunc creates XformFunc given the (optional) arguments - see BuildMsg.Args.
+	NewXformFunc func(args cos.SimpleKVs) (XformFunc, error)
+
+	inprocComm struct {
+		baseComm
+		mem   *memsys.MMSA
+		xform XformFunc
+	}
+
+	// counts the transformed (output) bytes
+	outCounter struct {
+		w     io.Writer
+		stats *commStats
+	}
+
+	// fails reads and writes once the context is done
+	ctxReader struct {
+		ctx context.Context
+		r   io.Reader
+	}
+	ctxWriter struct {
+		ctx context.Context
+		w   io.Writer
+	}
+)
+
+var (
+	builtins    = make(map[string]NewXformFunc, 8)
+	builtinsMtx sync.RWMutex
+)
+
+// interface guard
+var _ Communicator = (*inprocComm)(nil)
+
+// RegisterBuiltin adds in-process transform to the set of built-in transforms
+// (to be further started via `build` with RuntimeBuiltin).
+func RegisterBuiltin(name string, newXform NewXformFunc) {
+	builtinsMtx.Lock()
+	_, exists := builtins[name]
+	cos.AssertMsg(!exists, name)
+	builtins[name] = newXform
+	builtinsMtx.Unlock()
+}
+
+// Builtins returns the (sorted) names of the built-in transforms.
+func Builtins() (names []string) {
+	builtinsMtx.RLock()
+	names = make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	builtinsMtx.RUnlock()
+	sort.Strings(names)
+	return
+}
+
+func getBuiltin(name string) (newXform NewXformFunc, exists bool) {
+	builtinsMtx.RLock()
+	newXform, exists = builtins[name]
+	builtinsMtx.RUnlock()
+	return
+}
+
+func isInproc(runtime string) bool { return runtime == RuntimeBuiltin }
+
+func startInproc(t cluster.Target, msg BuildMsg) (err error) {
+	errCtx := &cmn.ETLErrorContext{TID: t.SID(), UUID: msg.ID, ETLName: string(msg.Code)}
+	if _, exists := reg.getByUUID(msg.ID); exists {
+		return fmt.Errorf("ETL %q already exists", msg.ID)
+	}
+	newXform, exists := getBuiltin(string(msg.Code))
+	if !exists {
+		return cmn.NewETLError(errCtx, "unknown built-in transform")
+	}
+	xform, err := newXform(msg.Args)
+	if err != nil {
+		return cmn.NewETLError(errCtx, err.Error())
+	}
+	c := &inprocComm{
+		baseComm: baseComm{
+			Slistener: newAborter(t, msg.ID),
+			t:         t,
+			name:      msg.ID,
+			stats:     &commStats{},
+		},
+		mem:   t.MMSA(),
+		xform: xform,
+	}
+	if err = reg.put(msg.ID, c); err != nil {
+		return
+	}
+	t.Sowner().Listeners().Reg(c)
+	glog.Infof("%s: started in-process ETL %q (%s)", t.Snode(), msg.ID, msg.Runtime)
+	return nil
+}
+
+////////////////
+// inprocComm //
+////////////////
+
+func (ic *inprocComm) Do(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName string) error {
+	lom, fh, err := ic.open(bck, objName)
+	if err != nil {
+		return err
+	}
+	buf, slab := ic.mem.Alloc()
+	err = ic.xform(&outCounter{w: w, stats: ic.stats}, fh, buf)
+	slab.Free(buf)
+	ic.finish(lom, fh)
+	ic.stats.objCount.Inc()
+	return err
+}
+
+func (ic *inprocComm) Get(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	lom, fh, err := ic.open(bck, objName)
+	if err != nil {
+		return nil, err
+	}
+	return ic.stream(fh, timeout, func() { ic.finish(lom, fh) }), nil
+}
+
+// transform the output of the previous stage of ETL pipeline (see Pipeline)
+func (ic *inprocComm) transform(r cos.ReadCloseSizer, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	in := cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:      r,
+		Size:   r.Size(),
+		ReadCb: func(i int, err error) { ic.stats.inBytes.Add(int64(i)) },
+	})
+	return ic.stream(in, timeout, func() { cos.Close(r) }), nil
+}
+
+// runs the transformation in a separate goroutine that writes into the returned reader;
+// non-zero `timeout` limits the time of the entire transformation (same as for the
+// ETL containers - see pushComm.put)
+func (ic *inprocComm) stream(r io.Reader, timeout time.Duration, done func()) cos.ReadCloseSizer {
+	var (
+		ctx    = context.Background()
+		cancel func()
+		pr, pw = io.Pipe()
+	)
+	if timeout != 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		// unblock the reader even if the transformation neither reads nor writes
+		go func() {
+			<-ctx.Done()
+			pw.CloseWithError(ctx.Err()) // (no-op if already closed)
+		}()
+	}
+	go func() {
+		buf, slab := ic.mem.Alloc()
+		err := ic.xform(&outCounter{w: &ctxWriter{ctx, pw}, stats: ic.stats}, &ctxReader{ctx, r}, buf)
+		slab.Free(buf)
+		done()
+		pw.CloseWithError(err)
+	}()
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:    pr,
+		Size: -1,
+		DeferCb: func() {
+			if cancel != nil {
+				cancel()
+			}
+			ic.stats.objCount.Inc()
+		},
+	})
+}
+
+// open the object for reading (under read lock - see finish)
+func (ic *inprocComm) open(bck *cluster.Bck, objName string) (lom *cluster.LOM, fh *cos.FileHandle, err error) {
+	lom = cluster.AllocLOM(objName)
+	if err = lom.Init(bck.Bck); err != nil {
+		cluster.FreeLOM(lom)
+		return nil, nil, err
+	}
+	lom.Lock(false)
+	if err = lom.Load(false /*cache it*/, true /*locked*/); err == nil {
+		fh, err = cos.NewFileHandle(lom.FQN)
+	}
+	if err != nil {
+		lom.Unlock(false)
+		cluster.FreeLOM(lom)
+		return nil, nil, err
+	}
+	ic.stats.inBytes.Add(lom.SizeBytes())
+	return
+}
+
+func (*inprocComm) finish(lom *cluster.LOM, fh *cos.FileHandle) {
+	cos.Close(fh)
+	lom.Unlock(false)
+	cluster.FreeLOM(lom)
+}
+
+////////////////
+// outCounter //
+////////////////
+
+func (oc *outCounter) Write(b []byte) (n int, err error) {
+	n, err = oc.w.Write(b)
+	oc.stats.outBytes.Add(int64(n))
+	return
+}
+
+func (cr *ctxReader) Read(b []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(b)
+}
+
+func (cw *ctxWriter) Write(b []byte) (int, error) {
+	if err := cw.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cw.w.Write(b)
+}
@@ -0,0 +1,74 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InprocTest", func() {
+	var (
+		data  = []byte(strings.Repeat("in-process ETL ", 1000))
+		input = func() cos.ReadCloseSizer {
+			return cos.NewReaderWithArgs(cos.ReaderArgs{R: bytes.NewReader(data), Size: int64(len(data))})
+		}
+		newComm = func(xform XformFunc) *inprocComm {
+			return &inprocComm{baseComm: baseComm{stats: &commStats{}}, mem: memsys.DefaultPageMM(), xform: xform}
+		}
+	)
+
+	It("should transform the stream", func() {
+		newXform, _ := getBuiltin(BuiltinGzip)
+		gz, err := newXform(nil)
+		Expect(err).NotTo(HaveOccurred())
+		newXform, _ = getBuiltin(BuiltinGunzip)
+		gunzip, err := newXform(nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		r, err := newComm(gz).transform(input(), time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		r, err = newComm(gunzip).transform(r, 0)
+		Expect(err).NotTo(HaveOccurred())
+		out, err := io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Close()).To(Succeed())
+		Expect(out).To(Equal(data))
+	})
+
+	It("should time out", func() {
+		for _, xform := range []XformFunc{
+			// endless output
+			func(w io.Writer, _ io.Reader, buf []byte) error {
+				for {
+					if _, err := w.Write(buf[:1]); err != nil {
+						return err
+					}
+				}
+			},
+			// neither reads nor writes
+			func(io.Writer, io.Reader, []byte) error {
+				time.Sleep(2 * time.Second)
+				return nil
+			},
+		} {
+			started := time.Now()
+			r, err := newComm(xform).transform(input(), 100*time.Millisecond)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = io.Copy(io.Discard, r)
+			Expect(err).To(Equal(context.DeadlineExceeded))
+			Expect(time.Since(started)).To(BeNumerically("<", time.Second))
+			r.Close()
+		}
+	})
+})
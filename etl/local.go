@@ -0,0 +1,455 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/k8s"
+	"github.com/NVIDIA/aistore/etl/runtime"
+	"github.com/NVIDIA/aistore/sys"
+)
+
+// Local ETL runtime: in the absence of Kubernetes, each target spawns the
+// transformer as its own child process listening on a local port.
+//
+// The process is defined by the same Pod specification: the command, args, and
+// env of its (only) container - the image, init containers, and the rest of the
+// spec are ignored. As in Kubernetes, `$(VAR)` references in the command and
+// args get expanded - which is also how the transformer finds out its port
+// (see `AIS_ETL_PORT`). The `build` runtimes run their (embedded) servers with
+// a locally installed interpreter (see runtime.LocalInterpreter).
+//
+// The target supervises the process: restarts it if it exits unexpectedly,
+// collects its output (see PodLogs) and resource usage (see PodHealth), and
+// terminates it upon Stop.
+//
+// Since this lets anyone who can initialize ETL run arbitrary commands on every
+// target, the local runtime must be explicitly enabled, optionally limiting the
+// executables that are allowed to run (see cmn.ETLConf).
+
+const (
+	etlPortEnv = "AIS_ETL_PORT"
+
+	localHost        = "127.0.0.1"
+	localLogName     = "etl.log"
+	localMaxRestarts = 5
+	localStopTimeout = 30 * time.Second
+)
+
+type (
+	localSpec struct {
+		name      string            // ETL name
+		cmdline   []string          // executable and its arguments
+		env       map[string]string // in addition to the target's own environment
+		probePath string            // readiness probe (HTTP GET) path
+		dir       string            // working directory
+	}
+
+	localProc struct {
+		name     string // ETL name and target ID (same as the pod's name in Kubernetes)
+		dir      string
+		args     []string
+		env      []string
+		log      *os.File
+		cmd      *exec.Cmd
+		exited   chan struct{} // closed when the current process exits
+		mtx      sync.Mutex
+		restarts int
+		stopping bool
+	}
+
+	localRegistry struct {
+		mtx    sync.Mutex
+		byUUID map[string]*localProc
+	}
+)
+
+var procs = &localRegistry{byUUID: make(map[string]*localProc)}
+
+// checkRuntime returns true if ETL must run as local process (no Kubernetes),
+// or error if the local runtime is not enabled (see cmn.ETLConf).
+func checkRuntime(config *cmn.Config) (local bool, err error) {
+	if err = k8s.Detect(); err == nil {
+		return false, nil
+	}
+	if config.ETL.LocalRuntime {
+		return true, nil
+	}
+	return true, err
+}
+
+func checkAllowed(errCtx *cmn.ETLErrorContext, executable string, allowlist []string) error {
+	if len(allowlist) == 0 {
+		return nil
+	}
+	// (relative paths would resolve against the process's working directory)
+	if filepath.IsAbs(executable) || !strings.ContainsRune(executable, filepath.Separator) {
+		if path, err := resolveExecutable(executable); err == nil {
+			for _, allowed := range allowlist {
+				if p, err := resolveExecutable(allowed); err == nil && p == path {
+					return nil
+				}
+			}
+		}
+	}
+	return cmn.NewETLError(errCtx, "executable %q is not allowed to run as ETL (see etl.local_allowlist)", executable)
+}
+
+func resolveExecutable(name string) (string, error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(path)
+}
+
+func startLocal(t cluster.Target, msg InitMsg, customEnv map[string]string) error {
+	errCtx := &cmn.ETLErrorContext{TID: t.SID(), UUID: msg.ID}
+	pod, err := ParsePodSpec(errCtx, msg.Spec)
+	if err != nil {
+		return err
+	}
+	errCtx.ETLName = pod.GetName()
+	container := pod.Spec.Containers[0]
+	spec := &localSpec{
+		name:    pod.GetName(),
+		cmdline: append(append([]string{}, container.Command...), container.Args...),
+		env:     make(map[string]string, len(container.Env)+len(customEnv)),
+	}
+	if len(spec.cmdline) == 0 {
+		return cmn.NewETLError(errCtx, "container command is required to run ETL as a local process")
+	}
+	if probe := container.ReadinessProbe; probe != nil && probe.HTTPGet != nil {
+		spec.probePath = probe.HTTPGet.Path
+	}
+	for _, v := range container.Env {
+		spec.env[v.Name] = v.Value
+	}
+	for k, v := range customEnv {
+		spec.env[k] = v
+	}
+	if spec.dir, err = os.MkdirTemp("", "ais-etl-"); err != nil {
+		return cmn.NewETLError(errCtx, err.Error())
+	}
+	return runLocal(t, errCtx, msg, spec)
+}
+
+func buildLocal(t cluster.Target, msg BuildMsg, r runtime.Runtime) (err error) {
+	var (
+		dir    string
+		errCtx = &cmn.ETLErrorContext{TID: t.SID(), UUID: msg.ID, ETLName: msg.ID}
+	)
+	if r.LocalInterpreter() == "" {
+		return cmn.NewETLError(errCtx, "runtime %q requires Kubernetes deployment", r.Type())
+	}
+	if err = checkAllowed(errCtx, r.LocalInterpreter(), cmn.GCO.Get().ETL.LocalAllowlist); err != nil {
+		return
+	}
+	if dir, err = os.MkdirTemp("", "ais-etl-"); err != nil {
+		return cmn.NewETLError(errCtx, err.Error())
+	}
+	if err = writeLocalRuntime(dir, msg, r); err != nil {
+		os.RemoveAll(dir)
+		return cmn.NewETLError(errCtx, err.Error())
+	}
+	spec := &localSpec{
+		name:    k8s.CleanName(msg.ID),
+		cmdline: []string{r.LocalInterpreter(), "server.py"},
+		env: map[string]string{
+			"MOD_NAME":     "code",
+			"FUNC_HANDLER": "transform",
+			"PYTHONPATH":   filepath.Join(dir, "runtime") + string(os.PathListSeparator) + dir,
+		},
+		probePath: "/health",
+		dir:       dir,
+	}
+	return runLocal(t, errCtx, InitMsg{ID: msg.ID, CommType: PushCommType, WaitTimeout: msg.WaitTimeout}, spec)
+}
+
+// writes the server, user's code, and its dependencies into the working directory
+func writeLocalRuntime(dir string, msg BuildMsg, r runtime.Runtime) error {
+	if err := os.WriteFile(filepath.Join(dir, "server.py"), []byte(r.LocalServer()), cos.PermRWR); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "code.py"), msg.Code, cos.PermRWR); err != nil {
+		return err
+	}
+	if len(msg.Deps) == 0 {
+		return nil
+	}
+	if err := os.WriteFile(filepath.Join(dir, "requirements.txt"), msg.Deps, cos.PermRWR); err != nil {
+		return err
+	}
+	cmd := exec.Command(r.LocalInterpreter(), "-m", "pip", "install",
+		"--target", filepath.Join(dir, "runtime"), "-r", "requirements.txt")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to install dependencies: %v\n%s", err, out)
+	}
+	return nil
+}
+
+func runLocal(t cluster.Target, errCtx *cmn.ETLErrorContext, msg InitMsg, spec *localSpec) (err error) {
+	var (
+		port   int
+		exited chan struct{}
+	)
+	if _, exists := reg.getByUUID(msg.ID); exists {
+		os.RemoveAll(spec.dir)
+		return fmt.Errorf("ETL %q already exists", msg.ID)
+	}
+	if port, err = freeLocalPort(); err != nil {
+		os.RemoveAll(spec.dir)
+		return cmn.NewETLError(errCtx, err.Error())
+	}
+	addr := net.JoinHostPort(localHost, strconv.Itoa(port))
+	spec.env["AIS_TARGET_URL"] = t.Snode().URL(cmn.NetworkPublic) + cmn.URLPathETLObject.Join(reqSecret)
+	spec.env[etlPortEnv] = strconv.Itoa(port)
+
+	proc := newLocalProc(k8s.CleanName(spec.name+"-"+t.SID()), spec)
+	errCtx.PodName = proc.name
+	if err = checkAllowed(errCtx, proc.args[0], cmn.GCO.Get().ETL.LocalAllowlist); err != nil {
+		os.RemoveAll(spec.dir)
+		return
+	}
+	if exited, err = proc.start(); err != nil {
+		proc.stop()
+		return cmn.NewETLError(errCtx, "failed to start %v: %v", spec.cmdline, err)
+	}
+	if spec.probePath != "" {
+		err = waitLocalReady(errCtx, "http://"+addr+spec.probePath, exited, msg.WaitTimeout)
+	} else {
+		err = checkETLConnection(addr, proc.name)
+	}
+	if err != nil {
+		proc.stop()
+		return err
+	}
+
+	c := makeCommunicator(commArgs{
+		listener:       newAborter(t, msg.ID),
+		t:              t,
+		podName:        proc.name,
+		name:           spec.name,
+		commType:       msg.CommType,
+		transformerURL: "http://" + addr,
+	})
+	procs.put(msg.ID, proc)
+	if err = reg.put(msg.ID, c); err != nil {
+		procs.remove(msg.ID)
+		proc.stop()
+		return err
+	}
+	t.Sowner().Listeners().Reg(c)
+	glog.Infof("%s: started ETL %q as local process %v (%s)", t.Snode(), msg.ID, spec.cmdline, addr)
+	return nil
+}
+
+func freeLocalPort() (int, error) {
+	l, err := net.Listen("tcp", net.JoinHostPort(localHost, "0"))
+	if err != nil {
+		return 0, err
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	cos.Close(l)
+	return port, nil
+}
+
+// waitLocalReady polls the readiness probe until it returns OK - the local
+// counterpart of waitPodReady.
+func waitLocalReady(errCtx *cmn.ETLErrorContext, probeURL string, exited chan struct{}, waitTimeout cos.Duration) error {
+	var (
+		started = time.Now()
+		client  = cmn.NewClient(cmn.TransportArgs{Timeout: 5 * time.Second})
+	)
+	for {
+		resp, err := client.Get(probeURL) // nolint:bodyclose // closed below
+		if err == nil {
+			cos.DrainReader(resp.Body)
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		select {
+		case <-exited:
+			return cmn.NewETLError(errCtx, "process exited prior to becoming ready (see ETL logs)")
+		case <-time.After(time.Second):
+		}
+		if waitTimeout != 0 && time.Since(started) > time.Duration(waitTimeout) {
+			return cmn.NewETLError(errCtx, "timed out waiting for %s to respond", probeURL)
+		}
+	}
+}
+
+///////////////
+// localProc //
+///////////////
+
+func newLocalProc(name string, spec *localSpec) *localProc {
+	p := &localProc{name: name, dir: spec.dir}
+	for _, arg := range spec.cmdline {
+		p.args = append(p.args, expandEnv(arg, spec.env))
+	}
+	p.env = os.Environ()
+	for k, v := range spec.env {
+		p.env = append(p.env, k+"="+v)
+	}
+	return p
+}
+
+func (p *localProc) String() string { return "etl-process[" + p.name + "]" }
+
+// expands `$(VAR)` references (see "Define a command and arguments" in Kubernetes docs)
+func expandEnv(s string, env map[string]string) string {
+	if !strings.Contains(s, "$(") {
+		return s
+	}
+	for k, v := range env {
+		s = strings.ReplaceAll(s, "$("+k+")", v)
+	}
+	return s
+}
+
+// returns the channel that gets closed when the process exits
+func (p *localProc) start() (chan struct{}, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p._start()
+}
+
+func (p *localProc) _start() (exited chan struct{}, err error) {
+	if p.log == nil {
+		if p.log, err = os.OpenFile(filepath.Join(p.dir, localLogName),
+			os.O_CREATE|os.O_APPEND|os.O_WRONLY, cos.PermRWR); err != nil {
+			return
+		}
+	}
+	cmd := exec.Command(p.args[0], p.args[1:]...)
+	cmd.Dir, cmd.Env = p.dir, p.env
+	cmd.Stdout, cmd.Stderr = p.log, p.log
+	setProcAttrs(cmd)
+	if err = cmd.Start(); err != nil {
+		return
+	}
+	exited = make(chan struct{})
+	p.cmd, p.exited = cmd, exited
+	go p.wait(cmd, exited)
+	return
+}
+
+// supervise: restart the process unless it's been stopped
+func (p *localProc) wait(cmd *exec.Cmd, exited chan struct{}) {
+	err := cmd.Wait()
+	close(exited)
+
+	p.mtx.Lock()
+	if p.stopping {
+		p.mtx.Unlock()
+		return
+	}
+	p.restarts++
+	restarts := p.restarts
+	p.mtx.Unlock()
+
+	if restarts > localMaxRestarts {
+		glog.Errorf("%s exited (%v) - not restarting after %d restarts", p, err, localMaxRestarts)
+		return
+	}
+	glog.Warningf("%s exited unexpectedly (%v) - restarting...", p, err)
+	time.Sleep(time.Duration(restarts) * time.Second)
+
+	p.mtx.Lock()
+	if !p.stopping {
+		if _, err := p._start(); err != nil {
+			glog.Errorf("%s: failed to restart: %v", p, err)
+		}
+	}
+	p.mtx.Unlock()
+}
+
+// first, gracefully terminate the process; upon failure to do so, kill it
+func (p *localProc) stop() {
+	p.mtx.Lock()
+	p.stopping = true
+	cmd, exited := p.cmd, p.exited
+	p.mtx.Unlock()
+
+	if cmd != nil {
+		_ = cmd.Process.Signal(syscall.SIGTERM)
+		select {
+		case <-exited:
+		case <-time.After(localStopTimeout):
+			glog.Warningf("%s: failed to terminate in %v - killing", p, localStopTimeout)
+			_ = cmd.Process.Kill()
+			<-exited
+		}
+	}
+	if p.log != nil {
+		cos.Close(p.log)
+	}
+	if err := os.RemoveAll(p.dir); err != nil {
+		glog.Errorf("%s: failed to cleanup %q: %v", p, p.dir, err)
+	}
+}
+
+func (p *localProc) logs() ([]byte, error) {
+	return os.ReadFile(filepath.Join(p.dir, localLogName))
+}
+
+func (p *localProc) health() (cpuCores float64, mem int64, err error) {
+	p.mtx.Lock()
+	cmd := p.cmd
+	p.mtx.Unlock()
+	if cmd == nil {
+		return 0, 0, fmt.Errorf("%s is not running", p)
+	}
+	stats, err := sys.ProcessStats(cmd.Process.Pid)
+	if err != nil {
+		return 0, 0, err
+	}
+	return stats.CPU.Percent / 100, int64(stats.Mem.Resident), nil
+}
+
+///////////////////
+// localRegistry //
+///////////////////
+
+func (r *localRegistry) put(uuid string, p *localProc) {
+	r.mtx.Lock()
+	r.byUUID[uuid] = p
+	r.mtx.Unlock()
+}
+
+func (r *localRegistry) get(uuid string) (p *localProc) {
+	r.mtx.Lock()
+	p = r.byUUID[uuid]
+	r.mtx.Unlock()
+	return
+}
+
+func (r *localRegistry) remove(uuid string) (p *localProc) {
+	r.mtx.Lock()
+	if p = r.byUUID[uuid]; p != nil {
+		delete(r.byUUID, uuid)
+	}
+	r.mtx.Unlock()
+	return
+}
@@ -0,0 +1,40 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/k8s"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LocalRuntime", func() {
+	errCtx := &cmn.ETLErrorContext{}
+
+	It("should require the local runtime to be enabled", func() {
+		if k8s.Detect() == nil {
+			Skip("requires deployment without Kubernetes")
+		}
+		config := &cmn.Config{}
+		_, err := checkRuntime(config)
+		Expect(err).To(HaveOccurred())
+		config.ETL.LocalRuntime = true
+		local, err := checkRuntime(config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(local).To(BeTrue())
+	})
+
+	It("should only run allowed executables", func() {
+		Expect(checkAllowed(errCtx, "/tmp/any", nil)).NotTo(HaveOccurred())
+
+		allowlist := []string{"sh"}
+		Expect(checkAllowed(errCtx, "sh", allowlist)).NotTo(HaveOccurred())
+		Expect(checkAllowed(errCtx, "/bin/sh", allowlist)).NotTo(HaveOccurred())
+		Expect(checkAllowed(errCtx, "./sh", allowlist)).To(HaveOccurred())
+		Expect(checkAllowed(errCtx, "ls", allowlist)).To(HaveOccurred())
+		Expect(checkAllowed(errCtx, "non-existing-executable", allowlist)).To(HaveOccurred())
+	})
+})
@@ -15,7 +15,7 @@ import (
 type (
 	OfflineDataProvider struct {
 		bckMsg         *cmn.TransCpyBckMsg
-		comm           Communicator
+		pipe           *Pipeline
 		requestTimeout time.Duration
 	}
 )
@@ -24,11 +24,11 @@ type (
 var _ cluster.LomReaderProvider = (*OfflineDataProvider)(nil)
 
 func NewOfflineDataProvider(msg *cmn.TransCpyBckMsg, lsnode *cluster.Snode) (*OfflineDataProvider, error) {
-	comm, err := GetCommunicator(msg.ID, lsnode)
+	pipe, err := GetPipeline(msg.ETLID(), lsnode)
 	if err != nil {
 		return nil, err
 	}
-	pr := &OfflineDataProvider{bckMsg: msg, comm: comm}
+	pr := &OfflineDataProvider{bckMsg: msg, pipe: pipe}
 	pr.requestTimeout = time.Duration(msg.RequestTimeout)
 	return pr, nil
 }
@@ -40,7 +40,7 @@ func (dp *OfflineDataProvider) Reader(lom *cluster.LOM) (cos.ReadOpenCloser, cmn
 		err error
 	)
 	call := func() (int, error) {
-		r, err = dp.comm.Get(lom.Bck(), lom.ObjName, dp.requestTimeout)
+		r, err = dp.pipe.Get(lom.Bck(), lom.ObjName, dp.requestTimeout)
 		return 0, err
 	}
 	// TODO: Check if ETL pod is healthy and wait some more if not (yet).
@@ -0,0 +1,219 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// ETL pipeline: a sequence of (registered) ETLs - stages - applied to an object
+// one after another. The pipeline is identified by the IDs of its stages separated
+// by cmn.ETLPipelineSepa, e.g. "decode,resize,normalize".
+//
+// The first stage may use any communication type. The output of each stage is
+// then streamed into the next one - all the subsequent stages must therefore
+// either use `hpush://` (see PushCommType) or else run in-process (see inproc.go).
+//
+// In addition to the regular (per-ETL) stats, the target keeps the stats of each
+// stage of each pipeline - see Info.Stages.
+
+type (
+	Pipeline struct {
+		ids   []string
+		comms []Communicator
+		stats []*commStats // per stage (multi-stage pipelines only)
+	}
+	// ETL that can transform the output of the previous stage
+	streamer interface {
+		transform(r cos.ReadCloseSizer, timeout time.Duration) (cos.ReadCloseSizer, error)
+	}
+	pipelineStats struct {
+		mtx  sync.RWMutex
+		byID map[string][]*commStats // pipeline ID => per-stage stats
+	}
+)
+
+var pstats = &pipelineStats{byID: make(map[string][]*commStats)}
+
+// interface guard
+var (
+	_ streamer = (*pushComm)(nil)
+	_ streamer = (*inprocComm)(nil)
+)
+
+// GetPipeline returns the pipeline of the ETLs with the given (separated) IDs.
+// A single ID makes a single-stage pipeline that simply delegates to the
+// corresponding Communicator.
+func GetPipeline(id string, lsnode *cluster.Snode) (*Pipeline, error) {
+	ids := strings.Split(id, cmn.ETLPipelineSepa)
+	p := &Pipeline{ids: ids, comms: make([]Communicator, 0, len(ids))}
+	for i, stageID := range ids {
+		comm, err := GetCommunicator(stageID, lsnode)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := comm.(streamer); !ok && i > 0 {
+			return nil, fmt.Errorf("ETL pipeline %q: stage #%d (ETL %q) must use %s communication type or run in-process",
+				id, i, stageID, PushCommType)
+		}
+		p.comms = append(p.comms, comm)
+	}
+	if len(ids) > 1 {
+		p.stats = pstats.get(id, len(ids))
+	}
+	return p, nil
+}
+
+// Do performs on-the-fly transformation of the object by all the stages and
+// writes the result into `w`.
+func (p *Pipeline) Do(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string) error {
+	if len(p.comms) == 1 {
+		if err := p.comms[0].Do(w, r, bck, objName); err != nil {
+			return p.stageErr(0, err)
+		}
+		return nil
+	}
+	rc, stage, err := p.get(bck, objName, 0 /*timeout*/)
+	if err != nil {
+		return p.stageErr(stage, err)
+	}
+	size := rc.Size()
+	if size < 0 {
+		size = memsys.DefaultBufSize
+	}
+	buf, slab := memsys.DefaultPageMM().AllocSize(size)
+	_, err = io.CopyBuffer(w, rc, buf)
+	slab.Free(buf)
+	rc.Close()
+	return err
+}
+
+// Get returns the object transformed by all the stages (see Communicator.Get).
+func (p *Pipeline) Get(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if len(p.comms) == 1 {
+		return p.comms[0].Get(bck, objName, timeout)
+	}
+	r, _, err := p.get(bck, objName, timeout)
+	return r, err
+}
+
+// returns the output of the last stage or else the (failed) stage and error
+func (p *Pipeline) get(bck *cluster.Bck, objName string, timeout time.Duration) (r cos.ReadCloseSizer,
+	stage int, err error) {
+	size, err := determineSize(bck, objName)
+	if err != nil {
+		return nil, 0, err
+	}
+	if r, err = p.comms[0].Get(bck, objName, timeout); err != nil {
+		return nil, 0, err
+	}
+	p.stats[0].inBytes.Add(size)
+	for stage = 1; stage < len(p.comms); stage++ {
+		// (the input gets closed in any case)
+		if r, err = p.comms[stage].(streamer).transform(p.output(stage-1, r), timeout); err != nil {
+			return nil, stage, err
+		}
+	}
+	return p.output(len(p.comms)-1, r), 0, nil
+}
+
+// wraps the output of the stage to account for it in the stats of the stage
+// and, as the input, of the next one
+func (p *Pipeline) output(stage int, r cos.ReadCloseSizer) cos.ReadCloseSizer {
+	var (
+		stats = p.stats[stage]
+		next  *commStats
+	)
+	if stage < len(p.stats)-1 {
+		next = p.stats[stage+1]
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:    r,
+		Size: r.Size(),
+		ReadCb: func(i int, err error) {
+			stats.outBytes.Add(int64(i))
+			if next != nil {
+				next.inBytes.Add(int64(i))
+			}
+		},
+		DeferCb: func() { stats.objCount.Inc() },
+	})
+}
+
+func (p *Pipeline) stageErr(stage int, err error) error {
+	comm := p.comms[stage]
+	return cmn.NewETLError(&cmn.ETLErrorContext{
+		UUID:    p.ids[stage],
+		PodName: comm.PodName(),
+		SvcName: comm.SvcName(),
+	}, err.Error())
+}
+
+///////////////////
+// pipelineStats //
+///////////////////
+
+func (ps *pipelineStats) get(id string, n int) (stats []*commStats) {
+	ps.mtx.RLock()
+	stats, ok := ps.byID[id]
+	ps.mtx.RUnlock()
+	if ok {
+		return
+	}
+	ps.mtx.Lock()
+	if stats, ok = ps.byID[id]; !ok {
+		stats = make([]*commStats, n)
+		for i := range stats {
+			stats[i] = &commStats{}
+		}
+		ps.byID[id] = stats
+	}
+	ps.mtx.Unlock()
+	return
+}
+
+// stages of all the pipelines that include the ETL
+func (ps *pipelineStats) stages(etlID string) (stages []StageInfo) {
+	ps.mtx.RLock()
+	for id, stats := range ps.byID {
+		for i, stageID := range strings.Split(id, cmn.ETLPipelineSepa) {
+			if stageID != etlID {
+				continue
+			}
+			stages = append(stages, StageInfo{
+				Pipeline: id,
+				Stage:    i,
+				ObjCount: stats[i].objCount.Load(),
+				InBytes:  stats[i].inBytes.Load(),
+				OutBytes: stats[i].outBytes.Load(),
+			})
+		}
+	}
+	ps.mtx.RUnlock()
+	sort.Slice(stages, func(i, j int) bool { return stages[i].Pipeline < stages[j].Pipeline })
+	return
+}
+
+// removes the stats of all the pipelines that include the (stopped) ETL
+func (ps *pipelineStats) remove(etlID string) {
+	ps.mtx.Lock()
+	for id := range ps.byID {
+		if cos.StringInSlice(etlID, strings.Split(id, cmn.ETLPipelineSepa)) {
+			delete(ps.byID, id)
+		}
+	}
+	ps.mtx.Unlock()
+}
@@ -48,8 +48,8 @@ func preparePodSpec(errCtx *cmn.ETLErrorContext, t cluster.Target, pod *corev1.P
 	// The following combination of Affinity and Anti-Affinity allows one to
 	// achieve the following:
 	//  1. The ETL container is always scheduled on the target invoking it.
-	//  2. Not more than one ETL container with the same target, is scheduled on
-	//     the same node, at a given point of time.
+	//  2. Not more than one container of the same ETL (with the same target), is
+	//     scheduled on the same node, at a given point of time.
 	if err = setTransformAffinity(errCtx, pod); err != nil {
 		return
 	}
@@ -57,7 +57,7 @@ func preparePodSpec(errCtx *cmn.ETLErrorContext, t cluster.Target, pod *corev1.P
 		return
 	}
 
-	updatePodLabels(t, pod)
+	updatePodLabels(errCtx, t, pod)
 	updateReadinessProbe(pod)
 	setPodEnvVariables(t, pod, env)
 	return
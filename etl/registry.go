@@ -80,6 +80,8 @@ func (r *registry) list() []Info {
 			ObjCount: comm.ObjCount(),
 			InBytes:  comm.InBytes(),
 			OutBytes: comm.OutBytes(),
+
+			Stages: pstats.stages(uuid),
 		})
 	}
 	r.mtx.RUnlock()
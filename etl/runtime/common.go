@@ -10,21 +10,26 @@ const (
 	Python3 = "python3"
 )
 
-var Runtimes map[string]runtime
+var Runtimes map[string]Runtime
 
 type (
-	runtime interface {
+	Runtime interface {
 		Type() string
 		PodSpec() string
 		CodeEnvName() string
 		DepsEnvName() string
+
+		// Local (non-Kubernetes) runtime: the interpreter and the source of
+		// the server that runs user's transform function - empty if not supported.
+		LocalInterpreter() string
+		LocalServer() string
 	}
 )
 
 func init() {
-	Runtimes = make(map[string]runtime, 2)
+	Runtimes = make(map[string]Runtime, 2)
 
-	for _, r := range []runtime{py2{}, py3{}} {
+	for _, r := range []Runtime{py2{}, py3{}} {
 		Runtimes[r.Type()] = r
 	}
 }
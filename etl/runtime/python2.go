@@ -18,3 +18,6 @@ func (py2) Type() string        { return Python2 }
 func (py2) CodeEnvName() string { return "AISTORE_CODE" }
 func (py2) DepsEnvName() string { return "AISTORE_DEPS" }
 func (py2) PodSpec() string     { return py2PodSpec }
+
+func (py2) LocalInterpreter() string { return "" }
+func (py2) LocalServer() string      { return "" }
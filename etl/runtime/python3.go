@@ -14,7 +14,13 @@ type (
 //go:embed python3.yaml
 var py3PodSpec string
 
+//go:embed python3_server.py
+var py3Server string
+
 func (py3) Type() string        { return Python3 }
 func (py3) CodeEnvName() string { return "AISTORE_CODE" }
 func (py3) DepsEnvName() string { return "AISTORE_DEPS" }
 func (py3) PodSpec() string     { return py3PodSpec }
+
+func (py3) LocalInterpreter() string { return "python3" }
+func (py3) LocalServer() string      { return py3Server }
@@ -0,0 +1,53 @@
+#!/usr/bin/env python3
+#
+# Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+#
+# Serves user's transform function when ETL runs as a local process
+# (without Kubernetes) - see etl/local.go.
+#
+# Environment:
+#   MOD_NAME, FUNC_HANDLER - module and function to transform the objects with;
+#   AIS_TARGET_URL         - URL to GET the (original) objects from;
+#   AIS_ETL_PORT           - local port to listen on.
+
+import importlib
+import os
+import urllib.request
+from http.server import BaseHTTPRequestHandler, HTTPServer
+from socketserver import ThreadingMixIn
+
+transform = getattr(importlib.import_module(os.environ["MOD_NAME"]), os.environ["FUNC_HANDLER"])
+
+
+class Handler(BaseHTTPRequestHandler):
+    def _reply(self, data):
+        if isinstance(data, str):
+            data = data.encode()
+        self.send_response(200)
+        self.send_header("Content-Length", str(len(data)))
+        self.end_headers()
+        self.wfile.write(data)
+
+    # hpush://
+    def do_PUT(self):
+        size = int(self.headers.get("Content-Length", 0))
+        self._reply(transform(self.rfile.read(size)))
+
+    # health check, hpull://, and hrev://
+    def do_GET(self):
+        if self.path == "/health":
+            self._reply(b"Running")
+            return
+        with urllib.request.urlopen(os.environ["AIS_TARGET_URL"] + self.path) as resp:
+            self._reply(transform(resp.read()))
+
+    def log_message(self, *args):
+        pass
+
+
+class Server(ThreadingMixIn, HTTPServer):
+    daemon_threads = True
+
+
+if __name__ == "__main__":
+    Server(("127.0.0.1", int(os.environ["AIS_ETL_PORT"])), Handler).serve_forever()
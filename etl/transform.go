@@ -35,6 +35,9 @@ const (
 	podNameLabel = "nvidia.com/ais-etl-name"
 	svcNameLabel = "nvidia.com/ais-etl-name"
 
+	// ETL Pod's label describing which ETL (ID) the pod runs.
+	podETLLabel = "nvidia.com/ais-etl-id"
+
 	// ETL Pod's label describing which target ETL is associated with.
 	podNodeLabel   = "nvidia.com/ais-etl-node"
 	podTargetLabel = "nvidia.com/ais-etl-target"
@@ -77,7 +80,7 @@ const (
 //   terminate the pod with a 30s timeout. Upon failure to do so, we perform
 //   a force delete.
 //
-// * A single ETL container runs per target at any point of time.
+// * A single ETL container per ETL (ID) runs per target at any point of time.
 //
 // * Recreating a ETL container with the same name will delete all running
 //   containers with the same name.
@@ -145,6 +148,15 @@ func (e *Aborter) ListenSmapChanged() {
 }
 
 func Start(t cluster.Target, msg InitMsg, opts ...StartOpts) (err error) {
+	if local, err := checkRuntime(cmn.GCO.Get()); err != nil {
+		return err
+	} else if local {
+		var customEnv map[string]string
+		if len(opts) > 0 {
+			customEnv = opts[0].Env
+		}
+		return startLocal(t, msg, customEnv)
+	}
 	errCtx, podName, svcName, err := tryStart(t, msg, opts...)
 	if err != nil {
 		glog.Warning(cmn.NewETLError(errCtx, "Performing cleanup after unsuccessful Start"))
@@ -196,9 +208,8 @@ func tryStart(t cluster.Target, msg InitMsg, opts ...StartOpts) (errCtx *cmn.ETL
 		customEnv = opts[0].Env
 	}
 
-	// Make sure only one ETL is running.
-	if len(List()) > 0 {
-		err = cmn.ErrETLOnlyOne
+	if _, exists := reg.getByUUID(msg.ID); exists {
+		err = fmt.Errorf("ETL %q already exists", msg.ID)
 		return
 	}
 
@@ -256,7 +267,7 @@ func tryStart(t cluster.Target, msg InitMsg, opts ...StartOpts) (errCtx *cmn.ETL
 	c := makeCommunicator(commArgs{
 		listener:       newAborter(t, msg.ID),
 		t:              t,
-		pod:            pod,
+		podName:        pod.GetName(),
 		name:           originalPodName,
 		commType:       msg.CommType,
 		transformerURL: "http://" + etlSocketAddr,
@@ -334,24 +345,24 @@ func Stop(t cluster.Target, id string) error {
 	errCtx.PodName = c.PodName()
 	errCtx.SvcName = c.SvcName()
 
-	if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
-		return err
+	if _, ok := c.(*inprocComm); !ok { // (in-process ETL - nothing to clean up)
+		if proc := procs.remove(id); proc != nil {
+			proc.stop()
+		} else if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
+			return err
+		}
 	}
 
 	if c := reg.removeByUUID(id); c != nil {
 		t.Sowner().Listeners().Unreg(c)
 	}
+	pstats.remove(id)
 
 	return nil
 }
 
 // StopAll deletes all running ETLs.
 func StopAll(t cluster.Target) {
-	// Skip if K8s isn't even available.
-	if k8s.Detect() != nil {
-		return
-	}
-
 	for _, e := range List() {
 		if err := Stop(t, e.ID); err != nil {
 			glog.Error(err)
@@ -374,11 +385,19 @@ func PodLogs(t cluster.Target, transformID string) (logs PodLogsMsg, err error)
 	if err != nil {
 		return logs, err
 	}
-	client, err := k8s.GetClient()
-	if err != nil {
-		return logs, err
+	var b []byte
+	if _, ok := c.(*inprocComm); ok {
+		return logs, fmt.Errorf("%s: ETL %q runs in-process - see the target's own log", t.Snode(), transformID)
+	}
+	if proc := procs.get(transformID); proc != nil {
+		b, err = proc.logs()
+	} else {
+		var client k8s.Client
+		if client, err = k8s.GetClient(); err != nil {
+			return logs, err
+		}
+		b, err = client.Logs(c.PodName())
 	}
-	b, err := client.Logs(c.PodName())
 	if err != nil {
 		return logs, err
 	}
@@ -396,6 +415,17 @@ func PodHealth(t cluster.Target, etlID string) (stats *PodHealthMsg, err error)
 	if c, err = GetCommunicator(etlID, t.Snode()); err != nil {
 		return
 	}
+	if _, ok := c.(*inprocComm); ok {
+		return nil, fmt.Errorf("%s: ETL %q runs in-process - see the target's own CPU and memory usage",
+			t.Snode(), etlID)
+	}
+	if proc := procs.get(etlID); proc != nil {
+		cpuUsed, memUsed, err := proc.health()
+		if err != nil {
+			return nil, err
+		}
+		return &PodHealthMsg{TargetID: t.SID(), CPU: cpuUsed, Mem: memUsed}, nil
+	}
 	if client, err = k8s.GetClient(); err != nil {
 		return
 	}
@@ -473,6 +503,7 @@ func setTransformAntiAffinity(errCtx *cmn.ETLErrorContext, pod *corev1.Pod) erro
 		reqAntiAffinities = []corev1.PodAffinityTerm{{
 			LabelSelector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{
+					podETLLabel:  errCtx.UUID,
 					podNodeLabel: k8s.NodeName,
 				},
 			},
@@ -484,13 +515,14 @@ func setTransformAntiAffinity(errCtx *cmn.ETLErrorContext, pod *corev1.Pod) erro
 	return nil
 }
 
-func updatePodLabels(t cluster.Target, pod *corev1.Pod) {
+func updatePodLabels(errCtx *cmn.ETLErrorContext, t cluster.Target, pod *corev1.Pod) {
 	if pod.Labels == nil {
-		pod.Labels = make(map[string]string, 6)
+		pod.Labels = make(map[string]string, 7)
 	}
 
 	pod.Labels[appLabel] = "ais"
 	pod.Labels[podNameLabel] = pod.GetName()
+	pod.Labels[podETLLabel] = errCtx.UUID
 	pod.Labels[podNodeLabel] = k8s.NodeName
 	pod.Labels[podTargetLabel] = t.SID()
 	pod.Labels[appK8sNameLabel] = "etl"
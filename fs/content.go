@@ -36,6 +36,9 @@ const (
 	WorkfileType   = "wk"
 	ECSliceType    = "ec"
 	ECMetaType     = "mt"
+	ObjVersionType = "vr" // previous (non-current) versions of objects
+
+	objVersionDirSuffix = ".ver"
 )
 
 type (
@@ -154,8 +157,9 @@ func (f *ContentSpecMgr) PermToProcess(fqn string) (ok bool) {
 // FIXME: This should be probably placed somewhere else \/
 
 type (
-	ObjectContentResolver   struct{}
-	WorkfileContentResolver struct{}
+	ObjectContentResolver     struct{}
+	WorkfileContentResolver   struct{}
+	ObjVersionContentResolver struct{}
 )
 
 func (*ObjectContentResolver) PermToMove() bool                   { return true }
@@ -204,3 +208,24 @@ func (*WorkfileContentResolver) ParseUniqueFQN(base string) (orig string, old, o
 
 	return base[:tieIndex], filePID != pid, true
 }
+
+// NOTE: previous versions are moved by rebalance along with the object
+// itself rather than on their own
+func (*ObjVersionContentResolver) PermToMove() bool    { return false }
+func (*ObjVersionContentResolver) PermToEvict() bool   { return true }
+func (*ObjVersionContentResolver) PermToProcess() bool { return false }
+
+// prefix - the version; all versions of a given object are stored in the
+// object's own directory: <object name>.ver/<version>
+func (*ObjVersionContentResolver) GenUniqueFQN(base, prefix string) string {
+	return filepath.Join(base+objVersionDirSuffix, prefix)
+}
+
+func (*ObjVersionContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	dir, ver := filepath.Split(base)
+	dir = strings.TrimSuffix(dir, string(filepath.Separator))
+	if ver == "" || len(dir) <= len(objVersionDirSuffix) || !strings.HasSuffix(dir, objVersionDirSuffix) {
+		return "", false, false
+	}
+	return dir[:len(dir)-len(objVersionDirSuffix)], false, true
+}
@@ -26,6 +26,10 @@ const (
 	WorkfilePut     = "put"     // object PUT
 	WorkfileAppend  = "append"  // object APPEND
 	WorkfileArchive = "archive" // Archive list/range
+	WorkfileArchMod = "archmod" // APPEND to (or delete from) existing archive
+	WorkfileScrub   = "scrub"   // repairing corrupted object
+	WorkfileS3Mpt   = "s3mpt"   // S3 multipart upload part
+	WorkfileDlRange = "dlrange" // resumable (ranged) download
 )
 
 type ParsedFQN struct {
@@ -927,6 +927,9 @@ func DestroyBucket(op string, bck cmn.Bck, bid uint64) error {
 	availablePaths, _ := Get()
 	for _, mi := range availablePaths {
 		dir := mi.makeDelPathBck(bck, bid)
+		if err := mi.MoveToTrash(mi.makePathDeletedObjs(bck)); err != nil {
+			glog.Errorf("%s: %v", op, err)
+		}
 		if err := mi.MoveToTrash(dir); err != nil {
 			glog.Errorf("%s: failed to %s (dir: %q, err: %v)", op, destroyStr, dir, err)
 		} else {
@@ -42,6 +42,10 @@ type (
 		VisitCT  func(ct *cluster.CT, buf []byte) error
 		Slab     *memsys.Slab
 
+		// Optional: blocks while the parent xaction is paused; returns true
+		// if the xaction got aborted in the meantime.
+		WaitResumed func() bool
+
 		DoLoad   LoadType // Loads the LOM and if specified takes requested lock.
 		Parallel int      // How many parallel calls each jogger should execute.
 
@@ -52,6 +56,7 @@ type (
 		IncludeCopy           bool // Traverses LOMs that are copies.
 		SkipGloballyMisplaced bool // Skips content types that are globally misplaced.
 		Throttle              bool // Determines if the jogger should throttle itself.
+		Sorted                bool // Traverses each directory in lexicographical order.
 	}
 
 	// JoggerGroup runs jogger per mountpath which walk the entire bucket and
@@ -206,7 +211,7 @@ func (j *jogger) runBck(bck cmn.Bck) (aborted bool, err error) {
 		Bck:      bck,
 		CTs:      j.opts.CTs,
 		Callback: j.jog,
-		Sorted:   false,
+		Sorted:   j.opts.Sorted,
 	}
 
 	err = fs.Walk(opts)
@@ -240,6 +245,9 @@ func (j *jogger) jog(fqn string, de fs.DirEntry) error {
 	if err := j.checkStopped(); err != nil {
 		return err
 	}
+	if j.opts.WaitResumed != nil && j.opts.WaitResumed() {
+		return cmn.NewAbortedError(j.String())
+	}
 
 	if j.syncGroup == nil {
 		if err := j.visitFQN(fqn, j.getBuf(0)); err != nil {
@@ -280,7 +288,11 @@ func (j *jogger) visitFQN(fqn string, buf []byte) error {
 	}
 
 	if j.opts.SkipGloballyMisplaced {
-		uname := ct.Bck().MakeUname(ct.ObjectName())
+		objName := ct.ObjectName()
+		if ct.ContentType() == fs.ObjVersionType { // belongs to the object itself
+			objName, _, _ = fs.CSM.RegisteredContentTypes[fs.ObjVersionType].ParseUniqueFQN(objName)
+		}
+		uname := ct.Bck().MakeUname(objName)
 		tsi, err := cluster.HrwTarget(uname, j.opts.T.Sowner().Get()) // TODO: should we get smap once?
 		if err != nil {
 			return err
@@ -10,6 +10,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cmn"
@@ -27,6 +28,8 @@ var mdFilesDirs = []string{
 	cmn.BmdPreviousFname,
 
 	cmn.VmdFname,
+
+	cmn.ScrubFname,
 }
 
 func MarkerExists(marker string) bool {
@@ -79,6 +82,57 @@ func RemoveMarker(marker string) {
 	}
 }
 
+// SaveMarkerMD stores `v` (in JSON) as the named marker on all available
+// mountpaths - for markers that carry state.
+func SaveMarkerMD(marker string, v interface{}) error {
+	var (
+		relname            = filepath.Join(cmn.MarkersDirName, marker)
+		availableMpaths, _ = Get()
+		cnt                int
+	)
+	for _, mi := range availableMpaths {
+		fpath := filepath.Join(mi.Path, relname)
+		if err := jsp.Save(fpath, v, jsp.Plain(), nil); err != nil {
+			glog.Errorf("Failed to save %q marker: %v", fpath, err)
+		} else {
+			cnt++
+		}
+	}
+	if cnt == 0 {
+		return fmt.Errorf("failed to save %q marker (%d)", marker, len(availableMpaths))
+	}
+	return nil
+}
+
+// LoadMarkerMD loads the most recently saved copy of the named marker
+// (see SaveMarkerMD) into `v`; returns false if there is none.
+func LoadMarkerMD(marker string, v interface{}) bool {
+	var (
+		fpath              string
+		mtime              time.Time
+		relname            = filepath.Join(cmn.MarkersDirName, marker)
+		availableMpaths, _ = Get()
+	)
+	for _, mi := range availableMpaths {
+		fqn := filepath.Join(mi.Path, relname)
+		finfo, err := os.Stat(fqn)
+		if err != nil {
+			continue
+		}
+		if fpath == "" || finfo.ModTime().After(mtime) {
+			fpath, mtime = fqn, finfo.ModTime()
+		}
+	}
+	if fpath == "" {
+		return false
+	}
+	if _, err := jsp.Load(fpath, v, jsp.Plain()); err != nil {
+		glog.Errorf("Failed to load %q marker: %v", fpath, err)
+		return false
+	}
+	return true
+}
+
 // PersistOnMpaths persists `what` on mountpaths under "mountpath.Path/path" filename.
 // It does it on maximum `atMost` mountPaths. If `atMost == 0`, it does it on every mountpath.
 // If `backupPath != ""`, it removes files from `backupPath` and moves files from `path` to `backupPath`.
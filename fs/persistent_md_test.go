@@ -95,3 +95,26 @@ func TestMarkersClear(t *testing.T) {
 		markerEntry{marker: cmn.ResilverMarker, exists: false},
 	)
 }
+
+func TestMarkerMD(t *testing.T) {
+	const mpathsCnt = 3
+	mpaths := tutils.PrepareMountPaths(t, mpathsCnt)
+	defer tutils.RemoveMountPaths(t, mpaths)
+
+	type progress struct {
+		Buckets map[string]string `json:"buckets"`
+	}
+	var loaded progress
+	tassert.Fatalf(t, !fs.LoadMarkerMD(cmn.RebalanceCkpt, &loaded), "unexpected %q marker", cmn.RebalanceCkpt)
+
+	saved := progress{Buckets: map[string]string{"ais://abc": "a/b/c"}}
+	err := fs.SaveMarkerMD(cmn.RebalanceCkpt, &saved)
+	tassert.CheckFatal(t, err)
+	checkMarkersExist(t, markerEntry{marker: cmn.RebalanceCkpt, exists: true})
+
+	tassert.Fatalf(t, fs.LoadMarkerMD(cmn.RebalanceCkpt, &loaded), "failed to load %q marker", cmn.RebalanceCkpt)
+	tassert.Errorf(t, loaded.Buckets["ais://abc"] == "a/b/c", "loaded %v vs saved %v", loaded, saved)
+
+	fs.RemoveMarker(cmn.RebalanceCkpt)
+	checkMarkersExist(t, markerEntry{marker: cmn.RebalanceCkpt, exists: false})
+}
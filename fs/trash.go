@@ -0,0 +1,207 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+)
+
+// Soft delete (see cmn.TrashConf): with delete retention configured, deleted
+// objects and destroyed buckets are moved to the (per-mountpath) $deleted
+// directory that has the following layout:
+//
+// $deleted/objects/<relative object FQN> - deleted object that retains its
+//     metadata (xattr); its mtime is the time of deletion;
+// $deleted/buckets/<BID>                 - bucket directory of a destroyed bucket;
+// $deleted/buckets/<BID>.objects         - objects deleted prior to destroying the bucket;
+// $deleted/buckets/<BID>.json            - the bucket's props and the time of deletion.
+//
+// Unlike $trash, the content of $deleted is restorable until it expires
+// and gets removed by LRU.
+
+const (
+	DeletedDir = "$deleted"
+
+	deletedObjs  = "objects"
+	deletedBcks  = "buckets"
+	deletedBmeta = ".json"
+	deletedBobjs = ".objects"
+)
+
+func (mi *MountpathInfo) MakePathDeletedObj(bck cmn.Bck, objName string) string {
+	fqn := mi.MakePathFQN(bck, ObjectType, objName)
+	return filepath.Join(mi.Path, DeletedDir, deletedObjs, fqn[len(mi.Path)+1:])
+}
+
+func (mi *MountpathInfo) makePathDeletedObjs(bck cmn.Bck) string {
+	buf := mi.makePathBuf(bck, ObjectType, 0)
+	return filepath.Join(mi.Path, DeletedDir, deletedObjs, string(buf[len(mi.Path)+1:]))
+}
+
+func (mi *MountpathInfo) makePathDeletedBck(bid uint64) string {
+	return filepath.Join(mi.Path, DeletedDir, deletedBcks, strconv.FormatUint(bid, 10))
+}
+
+// WalkDeletedObjs visits deleted objects of a given bucket (see MakePathDeletedObj).
+func (mi *MountpathInfo) WalkDeletedObjs(bck cmn.Bck, cb func(fqn, objName string, finfo os.FileInfo) error) error {
+	dir := mi.makePathDeletedObjs(bck)
+	if err := Access(dir); err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return err
+	}
+	opts := &Options{
+		Dir: dir,
+		Callback: func(fqn string, de DirEntry) error {
+			if de.IsDir() {
+				return nil
+			}
+			finfo, err := os.Stat(fqn)
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			return cb(fqn, fqn[len(dir)+1:], finfo)
+		},
+	}
+	return Walk(opts)
+}
+
+// DeletedBcks returns destroyed buckets retained on a given mountpath.
+func (mi *MountpathInfo) DeletedBcks() (entries []*cmn.TrashEntry, err error) {
+	dir := filepath.Join(mi.Path, DeletedDir, deletedBcks)
+	dentries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	for _, de := range dentries {
+		if de.IsDir() || !strings.HasSuffix(de.Name(), deletedBmeta) {
+			continue
+		}
+		entry := &cmn.TrashEntry{}
+		if _, err := jsp.Load(filepath.Join(dir, de.Name()), entry, jsp.Plain()); err != nil {
+			glog.Errorf("%s: failed to load deleted bucket %q: %v", mi, de.Name(), err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return
+}
+
+// PurgeDeletedBck permanently removes a destroyed bucket (identified by its BID).
+func (mi *MountpathInfo) PurgeDeletedBck(bid uint64) error {
+	dir := mi.makePathDeletedBck(bid)
+	if err := mi.MoveToTrash(dir); err != nil {
+		return err
+	}
+	if err := mi.MoveToTrash(dir + deletedBobjs); err != nil {
+		return err
+	}
+	return cos.RemoveFile(dir + deletedBmeta)
+}
+
+// TrashBucket is DestroyBucket that retains the bucket's content in $deleted
+// along with its (individually) deleted objects (see RestoreBucket).
+func TrashBucket(op string, bck cmn.Bck, props *cmn.BucketProps, deleted int64) error {
+	const trashStr = "trash-ais-bucket-dir"
+	var (
+		n     int
+		entry = &cmn.TrashEntry{Bck: bck, Props: props, Deleted: deleted}
+	)
+	entry.Bck.Props = nil
+	availablePaths, _ := Get()
+	for _, mi := range availablePaths {
+		var (
+			dir  = mi.makeDelPathBck(bck, props.BID)
+			dst  = mi.makePathDeletedBck(props.BID)
+			dobj = mi.makePathDeletedObjs(bck)
+		)
+		if err := Access(dir); err != nil {
+			if err := mi.MoveToTrash(dobj); err != nil { // (can't be restored without the bucket)
+				glog.Errorf("%s: %v", op, err)
+			}
+			n++ // nothing to retain
+			continue
+		}
+		err := cos.Rename(dir, dst)
+		if err == nil {
+			err = renameIfExists(dobj, dst+deletedBobjs)
+		}
+		if err == nil {
+			if err = jsp.Save(dst+deletedBmeta, entry, jsp.Plain(), nil); err != nil {
+				mi.MoveToTrash(dst)
+				mi.MoveToTrash(dst + deletedBobjs)
+			}
+		}
+		if err != nil {
+			glog.Errorf("%s: failed to %s (dir: %q, err: %v)", op, trashStr, dir, err)
+		} else {
+			n++
+		}
+	}
+	if count := len(availablePaths); n < count {
+		return fmt.Errorf("bucket %s: failed to trash %d/%d dirs", bck, count-n, count)
+	}
+	return nil
+}
+
+// RestoreBucket brings back the content of a destroyed bucket (see TrashBucket)
+// and creates the remaining (missing) bucket directories.
+func RestoreBucket(op string, bck cmn.Bck, bid uint64) (errs []error) {
+	availablePaths, _ := Get()
+	for _, mi := range availablePaths {
+		var (
+			src = mi.makePathDeletedBck(bid)
+			dst = mi.MakePathBck(bck)
+		)
+		if err := Access(src); err == nil {
+			if _, empty, _ := IsDirEmpty(dst); empty {
+				os.Remove(dst)
+			}
+			if err := cos.Rename(src, dst); err != nil {
+				errs = append(errs, fmt.Errorf("bucket %s: failed to restore %s: %w", bck, src, err))
+				continue
+			}
+			if err := renameIfExists(src+deletedBobjs, mi.makePathDeletedObjs(bck)); err != nil {
+				glog.Errorf("%s: failed to restore deleted objects of %s: %v", op, bck, err)
+			}
+			if err := cos.RemoveFile(src + deletedBmeta); err != nil {
+				glog.Errorf("%s: %v", op, err)
+			}
+		}
+		if err := mi.CreateMissingBckDirs(bck); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs == nil && glog.FastV(4, glog.SmoduleFS) {
+		glog.Infof("%s(restore bucket dirs): %s, bid=%d", op, bck, bid)
+	}
+	return
+}
+
+func renameIfExists(src, dst string) error {
+	if err := Access(src); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return cos.Rename(src, dst)
+}
@@ -0,0 +1,87 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+)
+
+func TestTrashBucket(t *testing.T) {
+	const bid = 10
+	var (
+		bck   = cmn.Bck{Name: "trash", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+		props = &cmn.BucketProps{BID: bid, Trash: cmn.TrashConf{Retention: cos.Duration(time.Hour)}}
+	)
+	fs.Init(ios.NewIOStaterMock())
+	fs.DisableFsIDCheck()
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+
+	mpath := t.TempDir()
+	_, err := fs.Add(mpath, "daeID")
+	tassert.CheckFatal(t, err)
+	defer fs.Remove(mpath)
+
+	var (
+		availablePaths, _ = fs.Get()
+		mi                = availablePaths[mpath]
+		fqn               = mi.MakePathFQN(bck, fs.ObjectType, "obj")
+		dfqn              = mi.MakePathDeletedObj(bck, "deleted-obj")
+	)
+	tassert.CheckFatal(t, mi.CreateMissingBckDirs(bck))
+	for _, path := range []string{fqn, dfqn} {
+		fh, err := cos.CreateFile(path)
+		tassert.CheckFatal(t, err)
+		fh.Close()
+	}
+
+	// destroy and restore
+	tassert.CheckFatal(t, fs.TrashBucket("test", bck, props, time.Now().UnixNano()))
+	checkExists(t, fqn, false)
+	checkExists(t, dfqn, false)
+	entries, err := mi.DeletedBcks()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(entries) == 1 && entries[0].Props.BID == bid, "expected deleted bucket (BID %d), got %v",
+		bid, entries)
+
+	errs := fs.RestoreBucket("test", bck, bid)
+	tassert.Fatalf(t, len(errs) == 0, "failed to restore bucket: %v", errs)
+	checkExists(t, fqn, true)
+	checkExists(t, dfqn, true) // individually deleted objects are restorable as well
+	entries, err = mi.DeletedBcks()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(entries) == 0, "expected no deleted buckets, got %v", entries)
+
+	var names []string
+	err = mi.WalkDeletedObjs(bck, func(_, objName string, _ os.FileInfo) error {
+		names = append(names, objName)
+		return nil
+	})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(names) == 1 && names[0] == "deleted-obj", "expected deleted object, got %v", names)
+
+	// destroy and purge
+	tassert.CheckFatal(t, fs.TrashBucket("test", bck, props, time.Now().UnixNano()))
+	tassert.CheckFatal(t, mi.PurgeDeletedBck(bid))
+	entries, err = mi.DeletedBcks()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(entries) == 0, "expected no deleted buckets, got %v", entries)
+	errs = fs.RestoreBucket("test", bck, bid)
+	tassert.Fatalf(t, len(errs) == 0, "failed to create bucket dirs: %v", errs)
+	checkExists(t, fqn, false)
+	checkExists(t, dfqn, false)
+}
+
+func checkExists(t *testing.T, path string, exists bool) {
+	err := fs.Access(path)
+	tassert.Errorf(t, (err == nil) == exists, "%q: expected exists=%t, got %v", path, exists, err)
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -364,6 +365,29 @@ func (wcb *walkCb) walkBckMpath(fqn string, de DirEntry) error {
 	}
 }
 
+// Returns true if the (object) name `a` comes after `b` in the order of sorted
+// traversal (see Options.Sorted) - that is, lexicographically, one path
+// component at a time.
+func NameAfter(a, b string) bool {
+	for {
+		ia, ib := strings.IndexByte(a, '/'), strings.IndexByte(b, '/')
+		ca, cb := a, b
+		if ia >= 0 {
+			ca = a[:ia]
+		}
+		if ib >= 0 {
+			cb = b[:ib]
+		}
+		if ca != cb {
+			return ca > cb
+		}
+		if ia < 0 || ib < 0 {
+			return ia >= 0 && ib < 0
+		}
+		a, b = a[ia+1:], b[ib+1:]
+	}
+}
+
 func Scanner(dir string, cb func(fqn string, entry DirEntry) error) error {
 	scanner, err := godirwalk.NewScanner(dir)
 	if err != nil {
@@ -195,3 +195,22 @@ func TestWalkBckSkipDir(t *testing.T) {
 	}
 	tassert.Fatalf(t, expectedTotal == len(fqns), "expected %d objects, got %d", expectedTotal, len(fqns))
 }
+
+func TestNameAfter(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		after bool
+	}{
+		{"b", "a", true},
+		{"a", "b", false},
+		{"a", "a", false},
+		{"a/b", "a", true},
+		{"a", "a/b", false},
+		{"a/c", "a/b/z", true},
+		{"a-b", "a/b", true}, // component-wise ("a-b" > "a"), even though '-' < '/'
+	}
+	for _, test := range tests {
+		tassert.Errorf(t, fs.NameAfter(test.a, test.b) == test.after,
+			"NameAfter(%q, %q) != %t", test.a, test.b, test.after)
+	}
+}
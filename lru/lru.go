@@ -20,6 +20,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/stats"
@@ -34,6 +35,13 @@ import (
 // When and if exceeded, AIStore target will start gradually evicting objects from its
 // stable storage: oldest first access-time wise.
 //
+// The order of eviction is further determined by the (per-bucket) LRU policy:
+//   - cmn.LRUPolicyLRU - oldest first (above);
+//   - cmn.LRUPolicyLFU - least frequently accessed first (see cluster.LOM.AccessCnt);
+//   - cmn.LRUPolicyGDS - size-weighted GreedyDual, whereby each object gets credited
+//     with the gdsCost/size time (that is, one hour per MiB) on top of its access time;
+// and by the bucket's lru.priority: buckets with lower priority get evicted first.
+//
 // LRU is implemented as a so-called extended action (aka x-action, see xaction.go) that gets
 // triggered when/if a used local capacity exceeds high watermark (config.LRU.HighWM). LRU then
 // runs automatically. In order to reduce its impact on the live workload, LRU throttles itself
@@ -43,12 +51,20 @@ import (
 //   - runLRU - to initiate a new LRU extended action on the local target
 // All other methods are private to this module and are used only internally.
 
-// TODO: extend LRU to remove CTs beyond just []string{fs.WorkfileType, fs.ObjectType}
+// Previous versions of objects (fs.ObjVersionType) are evicted before any
+// current object, oldest first; the ones that are past their bucket's
+// versioning.keep_ttl (or no longer retained) are removed along with old workfiles.
+//
+// Deleted objects and destroyed buckets (see cmn.TrashConf) that are past their
+// trash.retention are removed at the beginning of each run.
+
+// TODO: extend LRU to remove CTs beyond just []string{fs.WorkfileType, fs.ObjectType, fs.ObjVersionType}
 
 // LRU defaults/tunables
 const (
 	minEvictThresh = 10 * cos.MiB
 	capCheckThresh = 256 * cos.MiB // capacity checking threshold, when exceeded may result in lru throttling
+	gdsCost        = int64(time.Hour) * cos.MiB
 )
 
 type (
@@ -62,9 +78,23 @@ type (
 		GetFSStats          func(path string) (blocks, bavail uint64, bsize int64, err error)
 	}
 
-	// minHeap keeps fileInfo sorted by access time with oldest
-	// on top of the heap.
-	minHeap []*cluster.LOM
+	// eviction candidate: lower (prio, atime) gets evicted first,
+	// where prio is policy-specific (see lruJ.prio)
+	lruCand struct {
+		lom   *cluster.LOM
+		prio  int64
+		atime int64
+	}
+	// minHeap keeps eviction candidates sorted with the first
+	// to evict on top of the heap.
+	minHeap []lruCand
+
+	// previous version of an object (see cluster/lom_ver.go)
+	verFile struct {
+		fqn   string
+		mtime int64
+		size  int64
+	}
 
 	// parent - contains mpath joggers
 	lruP struct {
@@ -79,11 +109,13 @@ type (
 		// runtime
 		curSize   int64
 		totalSize int64 // difference between lowWM size and used size
-		newest    int64
+		newest    lruCand
 		heap      *minHeap
 		oldWork   []string
+		versions  []verFile
 		misplaced []*cluster.LOM
 		bck       cmn.Bck
+		policy    string
 		now       int64
 		// init-time
 		p         *lruP
@@ -106,6 +138,17 @@ type (
 		xaction.DemandBase
 		Renewed           chan struct{}
 		OkRemoveMisplaced func() bool
+		mu                sync.Mutex
+		evicted           map[string]*EvictStats // by policy
+	}
+
+	EvictStats struct {
+		Objs  int64 `json:"obj_count,string"`
+		Bytes int64 `json:"bytes_count,string"`
+	}
+	ExtLRUStats struct {
+		Evicted map[string]*EvictStats `json:"evicted"` // by policy
+		IsIdle  bool                   `json:"is_idle"`
 	}
 )
 
@@ -183,6 +226,9 @@ repeat:
 		go func(j *lruJ) {
 			var err error
 			defer j.p.wg.Done()
+			if err = j.removeDeleted(); err != nil {
+				goto ex
+			}
 			if err = j.removeTrash(); err != nil {
 				goto ex
 			}
@@ -246,9 +292,32 @@ func (r *Xaction) stop() {
 	r.Finish(nil)
 }
 
+func (r *Xaction) addEvicted(policy string, objs, bytes int64) {
+	r.ObjectsAdd(objs)
+	r.BytesAdd(bytes)
+	r.mu.Lock()
+	if r.evicted == nil {
+		r.evicted = make(map[string]*EvictStats, len(cmn.SupportedLRUPolicies))
+	}
+	st, ok := r.evicted[policy]
+	if !ok {
+		st = &EvictStats{}
+		r.evicted[policy] = st
+	}
+	st.Objs += objs
+	st.Bytes += bytes
+	r.mu.Unlock()
+}
+
 func (r *Xaction) Stats() cluster.XactStats {
 	baseStats := r.DemandBase.Stats().(*xaction.BaseXactStatsExt)
-	baseStats.Ext = &xaction.BaseXactDemandStatsExt{IsIdle: r.Pending() == 0}
+	ext := &ExtLRUStats{Evicted: make(map[string]*EvictStats, len(cmn.SupportedLRUPolicies)), IsIdle: r.Pending() == 0}
+	r.mu.Lock()
+	for policy, st := range r.evicted {
+		ext.Evicted[policy] = &EvictStats{Objs: st.Objs, Bytes: st.Bytes}
+	}
+	r.mu.Unlock()
+	baseStats.Ext = ext
 	return baseStats
 }
 
@@ -263,6 +332,7 @@ func (j *lruJ) stop() { j.stopCh <- struct{}{} }
 
 func (j *lruJ) jog(providers []string) (err error) {
 	glog.Infof("%s: freeing-up %s", j, cos.B2S(j.totalSize, 2))
+	var all []cmn.Bck
 	for _, provider := range providers { // for each provider (NOTE: ordering is random)
 		var (
 			bcks []cmn.Bck
@@ -274,12 +344,10 @@ func (j *lruJ) jog(providers []string) (err error) {
 		if bcks, err = fs.AllMpathBcks(&opts); err != nil {
 			return
 		}
-
-		if err = j.jogBcks(bcks, false); err != nil {
-			return
-		}
+		all = append(all, bcks...)
 	}
-	return
+	// all providers at once - to honor bucket priorities (see sortBcks)
+	return j.jogBcks(all, false)
 }
 
 func (j *lruJ) jogBcks(bcks []cmn.Bck, force bool) (err error) {
@@ -287,7 +355,7 @@ func (j *lruJ) jogBcks(bcks []cmn.Bck, force bool) (err error) {
 		return
 	}
 	if len(bcks) > 1 {
-		j.sortBsize(bcks)
+		j.sortBcks(bcks)
 	}
 
 	for _, bck := range bcks { // for each bucket under a given provider
@@ -342,6 +410,66 @@ func (j *lruJ) removeTrash() (err error) {
 	return
 }
 
+// remove deleted objects and destroyed buckets that are past their retention
+// (see fs/trash.go); destroyed buckets get moved to $trash (see removeTrash)
+func (j *lruJ) removeDeleted() (err error) {
+	var (
+		now      = time.Now().UnixNano()
+		provider = cmn.ProviderAIS
+	)
+	dbcks, err := j.mpathInfo.DeletedBcks()
+	if err != nil {
+		return
+	}
+	for _, entry := range dbcks {
+		if entry.Props == nil || entry.Deleted+int64(entry.Props.Trash.Retention) > now {
+			continue
+		}
+		if err := j.mpathInfo.PurgeDeletedBck(entry.Props.BID); err != nil {
+			glog.Errorf("%s: %v", j, err)
+		}
+	}
+	j.ini.T.Bowner().Get().Range(&provider, nil, func(bck *cluster.Bck) bool {
+		retention := int64(bck.Props.Trash.Retention)
+		err = j.mpathInfo.WalkDeletedObjs(bck.Bck, func(fqn, objName string, finfo os.FileInfo) error {
+			if finfo.ModTime().UnixNano()+retention > now {
+				return nil
+			}
+			j.purgeDeleted(bck, fqn, objName)
+			return j.yieldTerm()
+		})
+		return err != nil
+	})
+	return
+}
+
+// Soft delete retains previous versions and EC slices of the deleted object
+// (see DeleteObject in ais/target.go) - remove them along with the object
+// unless the latter has been re-created (or restored) in the meantime.
+func (j *lruJ) purgeDeleted(bck *cluster.Bck, fqn, objName string) {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.Init(bck.Bck); err != nil {
+		glog.Errorf("%s: %v", j, err)
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := cos.RemoveFile(fqn); err != nil {
+		glog.Errorf("%s: %v", j, err)
+		return
+	}
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err == nil || !cmn.IsObjNotExist(err) {
+		return
+	}
+	if lom.KeepsHistory() {
+		if err := lom.DelAllVersions(); err != nil {
+			glog.Errorf("%s: failed to delete previous versions of %s: %v", j, lom, err)
+		}
+	}
+	ec.ECM.CleanupObject(lom)
+}
+
 func (j *lruJ) jogBck() (size int64, err error) {
 	// 1. init per-bucket min-heap (and reuse the slice)
 	h := (*j.heap)[:0]
@@ -353,7 +481,7 @@ func (j *lruJ) jogBck() (size int64, err error) {
 	opts := &fs.Options{
 		Mpath:    j.mpathInfo,
 		Bck:      j.bck,
-		CTs:      []string{fs.WorkfileType, fs.ObjectType},
+		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ObjVersionType},
 		Callback: j.walk,
 		Sorted:   false,
 	}
@@ -388,6 +516,10 @@ func (j *lruJ) walk(fqn string, de fs.DirEntry) error {
 		}
 		return nil
 	}
+	if parsedFQN.ContentType == fs.ObjVersionType {
+		j.walkVersion(fqn, parsedFQN.ObjName)
+		return nil
+	}
 	lom := &cluster.LOM{ObjName: parsedFQN.ObjName}
 	err = lom.Init(j.bck)
 	if err != nil {
@@ -412,18 +544,59 @@ func (j *lruJ) walk(fqn string, de fs.DirEntry) error {
 	}
 
 	// do nothing if the heap's curSize >= totalSize and
-	// the file is more recent then the the heap's newest.
-	if j.curSize >= j.totalSize && lom.AtimeUnix() > j.newest {
+	// the file is to be evicted later than the heap's newest.
+	cand := lruCand{lom: lom, prio: j.prio(lom), atime: lom.AtimeUnix()}
+	if j.curSize >= j.totalSize && j.newest.less(&cand) {
 		return nil
 	}
-	heap.Push(h, lom)
+	heap.Push(h, cand)
 	j.curSize += lom.SizeBytes()
-	if lom.AtimeUnix() > j.newest {
-		j.newest = lom.AtimeUnix()
+	if j.newest.less(&cand) {
+		j.newest = lruCand{prio: cand.prio, atime: cand.atime}
 	}
 	return nil
 }
 
+// policy-specific eviction priority (see lruCand)
+func (j *lruJ) prio(lom *cluster.LOM) int64 {
+	switch j.policy {
+	case cmn.LRUPolicyLFU:
+		return int64(lom.AccessCnt())
+	case cmn.LRUPolicyGDS:
+		return lom.AtimeUnix() + gdsCost/cos.MaxI64(lom.SizeBytes(), 1)
+	default:
+		return 0
+	}
+}
+
+// previous versions: remove expired or collect
+func (j *lruJ) walkVersion(fqn, name string) {
+	contentResolver := fs.CSM.RegisteredContentTypes[fs.ObjVersionType]
+	objName, _, ok := contentResolver.ParseUniqueFQN(name)
+	if !ok {
+		return
+	}
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		return
+	}
+	lom := &cluster.LOM{ObjName: objName}
+	if lom.Init(j.bck) != nil {
+		return
+	}
+	var (
+		mtime = finfo.ModTime().UnixNano()
+		ttl   = lom.VersionConf().KeepTTL
+	)
+	if !lom.KeepsHistory() || (ttl > 0 && mtime+int64(ttl) < j.now) {
+		j.oldWork = append(j.oldWork, fqn)
+		return
+	}
+	if j.allowDelObj {
+		j.versions = append(j.versions, verFile{fqn: fqn, mtime: mtime, size: finfo.Size()})
+	}
+}
+
 func (j *lruJ) evict() (size int64, err error) {
 	var (
 		fevicted, bevicted int64
@@ -471,8 +644,28 @@ func (j *lruJ) evict() (size int64, err error) {
 	}
 	j.misplaced = j.misplaced[:0]
 	// 3.
+	sort.Slice(j.versions, func(i, k int) bool { return j.versions[i].mtime < j.versions[k].mtime })
+	for _, v := range j.versions {
+		if j.totalSize <= 0 {
+			break
+		}
+		if err := cos.RemoveFile(v.fqn); err != nil {
+			glog.Warningf("Failed to remove previous version %q: %v", v.fqn, err)
+			continue
+		}
+		bevicted += v.size
+		size += v.size
+		fevicted++
+		j.totalSize -= v.size
+		if err = j.yieldTerm(); err != nil {
+			j.versions = j.versions[:0]
+			return
+		}
+	}
+	j.versions = j.versions[:0]
+	// 4.
 	for h.Len() > 0 && j.totalSize > 0 {
-		lom := heap.Pop(h).(*cluster.LOM)
+		lom := heap.Pop(h).(lruCand).lom
 		if evictObj(lom) {
 			bevicted += lom.SizeBytes(true /*not loaded*/)
 			size += lom.SizeBytes(true)
@@ -484,8 +677,9 @@ func (j *lruJ) evict() (size int64, err error) {
 	}
 	j.ini.StatsT.Add(stats.LruEvictSize, bevicted)
 	j.ini.StatsT.Add(stats.LruEvictCount, fevicted)
-	xlru.ObjectsAdd(fevicted)
-	xlru.BytesAdd(bevicted)
+	if fevicted > 0 {
+		xlru.addEvicted(j.policy, fevicted, bevicted)
+	}
 	return
 }
 
@@ -576,18 +770,28 @@ func (j *lruJ) yieldTerm() error {
 	return nil
 }
 
-// sort buckets by size
-func (j *lruJ) sortBsize(bcks []cmn.Bck) {
-	sized := make([]struct {
-		b cmn.Bck
-		v uint64
-	}, len(bcks))
+// sort buckets by priority (lower first) and size (larger first)
+func (j *lruJ) sortBcks(bcks []cmn.Bck) {
+	var (
+		bmd   = j.ini.T.Bowner().Get()
+		sized = make([]struct {
+			b    cmn.Bck
+			v    uint64
+			prio int
+		}, len(bcks))
+	)
 	for i := range bcks {
 		path := j.mpathInfo.MakePathCT(bcks[i], fs.ObjectType)
 		sized[i].b = bcks[i]
 		sized[i].v, _ = ios.GetDirSize(path)
+		if props, ok := bmd.Get(cluster.NewBckEmbed(bcks[i])); ok {
+			sized[i].prio = props.LRU.Priority
+		}
 	}
 	sort.Slice(sized, func(i, j int) bool {
+		if sized[i].prio != sized[j].prio {
+			return sized[i].prio < sized[j].prio
+		}
 		return sized[i].v > sized[j].v
 	})
 	for i := range bcks {
@@ -604,6 +808,7 @@ func (j *lruJ) allow() (ok bool, err error) {
 		return
 	}
 	ok = b.Props.LRU.Enabled && b.Allow(cmn.AccessObjDELETE) == nil
+	j.policy = b.Props.LRU.EvictPolicy()
 	return
 }
 
@@ -611,10 +816,17 @@ func (j *lruJ) allow() (ok bool, err error) {
 // min-heap //
 //////////////
 
+func (c *lruCand) less(o *lruCand) bool {
+	if c.prio != o.prio {
+		return c.prio < o.prio
+	}
+	return c.atime < o.atime
+}
+
 func (h minHeap) Len() int            { return len(h) }
-func (h minHeap) Less(i, j int) bool  { return h[i].Atime().Before(h[j].Atime()) }
+func (h minHeap) Less(i, j int) bool  { return h[i].less(&h[j]) }
 func (h minHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
-func (h *minHeap) Push(x interface{}) { *h = append(*h, x.(*cluster.LOM)) }
+func (h *minHeap) Push(x interface{}) { *h = append(*h, x.(lruCand)) }
 func (h *minHeap) Pop() interface{} {
 	old := *h
 	n := len(old)
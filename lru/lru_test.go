@@ -130,13 +130,14 @@ func createAndAddMountpath(path string) {
 
 	fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
 	fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	fs.CSM.RegisterContentType(fs.ObjVersionType, &fs.ObjVersionContentResolver{})
 }
 
 func getRandomFileName(fileCounter int) string {
 	return fmt.Sprintf("%v-%v.txt", cos.RandString(13), fileCounter)
 }
 
-func saveRandomFile(filename string, size int64) {
+func saveRandomFile(filename string, size int64, accessCnt ...int) {
 	buff := make([]byte, size)
 	_, err := cos.SaveReader(filename, rand.Reader, buff, cos.ChecksumNone, size, "")
 	Expect(err).NotTo(HaveOccurred())
@@ -145,9 +146,20 @@ func saveRandomFile(filename string, size int64) {
 	Expect(err).NotTo(HaveOccurred())
 	lom.SetSize(size)
 	lom.IncVersion()
+	if len(accessCnt) > 0 {
+		for i := 0; i < accessCnt[0]; i++ {
+			lom.IncAccessCnt()
+		}
+	}
 	Expect(lom.Persist()).NotTo(HaveOccurred())
 }
 
+func setPolicy(t cluster.Target, policy string) {
+	bck := cluster.NewBck(bucketName, cmn.ProviderAIS, cmn.NsGlobal)
+	Expect(bck.Init(t.Bowner())).NotTo(HaveOccurred())
+	bck.Props.LRU.Policy = policy
+}
+
 func saveRandomFilesWithMetadata(filesPath string, files []fileMetadata) {
 	for _, file := range files {
 		saveRandomFile(path.Join(filesPath, file.name), file.size)
@@ -273,6 +285,65 @@ var _ = Describe("LRU tests", func() {
 				}
 			})
 
+			It("should evict larger files first [gds policy]", func() {
+				const totalSize = 32 * cos.MiB
+
+				setPolicy(t, cmn.LRUPolicyGDS)
+				ini.GetFSStats = func(string) (blocks, bavail uint64, bsize int64, err error) {
+					bsize = blockSize
+					btaken := uint64(totalSize / blockSize)
+					blocks = uint64(float64(btaken) / initialDiskUsagePct)
+					bavail = blocks - btaken
+					return
+				}
+				files := []fileMetadata{
+					{getRandomFileName(0), int64(4 * cos.MiB)},
+					{getRandomFileName(1), int64(16 * cos.MiB)},
+					{getRandomFileName(2), int64(4 * cos.MiB)},
+					{getRandomFileName(3), int64(8 * cos.MiB)},
+				}
+				saveRandomFilesWithMetadata(filesPath, files)
+
+				// evicting the 16MiB file (despite it not being the oldest) suffices to go under lwm
+				lru.Run(ini)
+
+				filesLeft, err := os.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(filesLeft)).To(Equal(3))
+				for _, name := range filesLeft {
+					Expect(name.Name()).NotTo(Equal(files[1].name))
+				}
+			})
+
+			It("should evict least frequently accessed files [lfu policy]", func() {
+				const numberOfFiles = 6
+
+				setPolicy(t, cmn.LRUPolicyLFU)
+				ini.GetFSStats = getMockGetFSStats(numberOfFiles)
+
+				oldFiles := []fileMetadata{
+					{getRandomFileName(3), fileSize},
+					{getRandomFileName(4), fileSize},
+					{getRandomFileName(5), fileSize},
+				}
+				for _, file := range oldFiles {
+					saveRandomFile(path.Join(filesPath, file.name), file.size, 10 /*access count*/)
+				}
+				time.Sleep(1 * time.Second)
+				saveRandomFiles(filesPath, 3)
+
+				lru.Run(ini)
+
+				files, err := os.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(3))
+
+				oldFilesNames := namesFromFilesMetadatas(oldFiles)
+				for _, name := range files {
+					Expect(cos.StringInSlice(name.Name(), oldFilesNames)).To(BeTrue())
+				}
+			})
+
 			It("should evict only files from requested bucket [ignores LRU prop]", func() {
 				saveRandomFiles(fpAnother, numberOfCreatedFiles)
 				saveRandomFiles(filesPath, numberOfCreatedFiles)
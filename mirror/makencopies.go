@@ -97,13 +97,23 @@ func (r *xactMNC) Run() {
 }
 
 func (r *xactMNC) visitObj(lom *cluster.LOM, buf []byte) (err error) {
-	var size int64
-	if n := lom.NumCopies(); n == r.copies {
+	var (
+		size   int64
+		copies = r.copies
+	)
+	// storage class (if any) overrides the bucket's n-way mirror
+	if sc := lom.StorageClass(); sc != nil {
+		copies = 1
+		if mconf := sc.MirrorConf(&lom.Bprops().Mirror); mconf.Enabled {
+			copies = int(mconf.Copies)
+		}
+	}
+	if n := lom.NumCopies(); n == copies {
 		return nil
-	} else if n > r.copies {
-		size, err = delCopies(lom, r.copies)
+	} else if n > copies {
+		size, err = delCopies(lom, copies)
 	} else {
-		size, err = addCopies(lom, r.copies, buf)
+		size, err = addCopies(lom, copies, buf)
 	}
 
 	if os.IsNotExist(err) {
@@ -108,7 +108,7 @@ func runXactPut(lom *cluster.LOM, slab *memsys.Slab, t cluster.Target) (r *XactP
 
 // mpather/worker callback (one worker per mountpath)
 func (r *XactPut) workCb(lom *cluster.LOM, buf []byte) {
-	copies := int(lom.Bprops().Mirror.Copies)
+	copies := int(lom.MirrorConf().Copies)
 	if _, err := addCopies(lom, copies, buf); err != nil {
 		glog.Error(err)
 	} else {
@@ -86,6 +86,17 @@ func addCopies(lom *cluster.LOM, copies int, buf []byte) (size int64, err error)
 	return
 }
 
+// MakeNCopies adds or removes copies of the object, so that it ends up
+// having exactly the specified number of replicas.
+func MakeNCopies(lom *cluster.LOM, copies int, buf []byte) (size int64, err error) {
+	if n := lom.NumCopies(); n > copies {
+		size, err = delCopies(lom, copies)
+	} else if n < copies {
+		size, err = addCopies(lom, copies, buf)
+	}
+	return
+}
+
 func drainWorkCh(workCh chan cluster.LIF) (n int) {
 	for {
 		select {
@@ -129,7 +129,16 @@ func (wi *WalkInfo) lsObject(lom *cluster.LOM, objStatus uint16) *cmn.BucketEntr
 		return nil
 	}
 	if wi.Marker != "" && cmn.TokenIncludesObject(wi.Marker, objName) {
-		return nil
+		// unless (listing archives or versions and) the marker is one of the archived files
+		// or previous versions
+		var (
+			inArch = wi.msg.IsFlagSet(cmn.SelectArchDir) && strings.HasPrefix(wi.Marker, objName+"/")
+			isVer  = wi.msg.IsFlagSet(cmn.SelectVersions) &&
+				strings.HasPrefix(wi.Marker, cmn.VersionEntryName(objName, ""))
+		)
+		if !inArch && !isVer {
+			return nil
+		}
 	}
 	if wi.objectFilter != nil && !wi.objectFilter(lom) {
 		return nil
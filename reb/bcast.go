@@ -172,6 +172,9 @@ func (reb *Manager) rxReady(tsi *cluster.Snode, md *rebArgs) (ok bool) {
 		logHdr = reb.logHdr(md)
 	)
 	for curwt < maxwt {
+		if reb.xact().Paused() {
+			curwt = 0 // do not time out while paused
+		}
 		if reb.stages.isInStage(tsi, rebStageTraverse) {
 			// do not request the node stage if it has sent push notification
 			return true
@@ -206,6 +209,9 @@ func (reb *Manager) waitFinExtended(tsi *cluster.Snode, md *rebArgs) (ok bool) {
 			glog.Infof("%s: abort wack", logHdr)
 			return
 		}
+		if reb.xact().Paused() {
+			curwt = 0 // do not time out while paused
+		}
 		if reb.stages.isInStage(tsi, rebStageFin) {
 			// do not request the node stage if it has sent push notification
 			return true
@@ -0,0 +1,169 @@
+// Package reb provides local resilver and global rebalance for AIStore.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Bandwidth limiting: rebalance and resilver pace themselves to stay within
+// the configured rates - per target and per mountpath (see cmn.BwidthConf).
+// The limits are looked up in the current config upon every transfer and can,
+// therefore, be changed at runtime.
+//
+// In adaptive mode, the rates get additionally scaled down (halved, down to
+// bwMinFactor) while the mountpath is busy - its utilization is above
+// disk.disk_util_high_wm - or the average GET latency exceeds twice its baseline;
+// and scaled back up once the utilization drops below disk.disk_util_low_wm.
+// The baseline follows the latency down immediately and up slowly (EWMA), so
+// that a sustained change eventually becomes the new normal. With no rates
+// configured, adaptive mode backs off by sleeping between transfers instead.
+
+const (
+	bwMinFactor   = 1.0 / 16
+	bwAdaptIval   = time.Second
+	bwSlowLatMult = 2
+	bwLatWeight   = 1.0 / 32 // EWMA weight of the latest latency sample
+)
+
+type (
+	bwLimiter struct {
+		conf   func(config *cmn.Config) *cmn.BwidthConf
+		statsT stats.Tracker
+		target bwPacer
+		mu     sync.Mutex
+		mpaths map[string]*bwMpath
+		lat    struct {
+			sync.Mutex
+			base    int64 // baseline average GET latency (see baseLat)
+			updated int64
+		}
+	}
+	bwPacer struct {
+		mu   sync.Mutex
+		next int64 // (mono) time when the next transfer can start
+	}
+	bwMpath struct {
+		bwPacer
+		factor  float64 // rate multiplier in [bwMinFactor, 1] (adaptive)
+		adapted int64   // last time the factor was adjusted
+	}
+)
+
+func newBwLimiter(statsT stats.Tracker, conf func(config *cmn.Config) *cmn.BwidthConf) *bwLimiter {
+	return &bwLimiter{conf: conf, statsT: statsT, mpaths: make(map[string]*bwMpath, 4)}
+}
+
+// Paces the transfer of `size` bytes read from the given mountpath -
+// returns when it is time to start the next one (or upon abort).
+func (bl *bwLimiter) wait(mi *fs.MountpathInfo, size int64, abrt <-chan struct{}) {
+	var (
+		config = cmn.GCO.Get()
+		bconf  = bl.conf(config)
+	)
+	if !bconf.IsSet() {
+		return
+	}
+	var (
+		delay  time.Duration
+		mpath  = bl.mpath(mi)
+		factor = 1.0
+	)
+	if bconf.Adaptive {
+		factor = bl.adapt(config, mpath, mi)
+	}
+	if bconf.Target > 0 {
+		delay = bl.target.reserve(size, float64(bconf.Target)*factor)
+	}
+	if bconf.Mpath > 0 {
+		if d := mpath.reserve(size, float64(bconf.Mpath)*factor); d > delay {
+			delay = d
+		}
+	} else if bconf.Target == 0 && factor < 1 {
+		delay = time.Duration((1/factor - 1) * float64(cmn.ThrottleMin))
+	}
+	if delay <= 0 {
+		return
+	}
+	timer := time.NewTimer(delay)
+	select {
+	case <-timer.C:
+	case <-abrt:
+		timer.Stop()
+	}
+}
+
+func (bl *bwLimiter) mpath(mi *fs.MountpathInfo) (mpath *bwMpath) {
+	bl.mu.Lock()
+	if mpath = bl.mpaths[mi.Path]; mpath == nil {
+		mpath = &bwMpath{factor: 1}
+		bl.mpaths[mi.Path] = mpath
+	}
+	bl.mu.Unlock()
+	return
+}
+
+func (bl *bwLimiter) adapt(config *cmn.Config, mpath *bwMpath, mi *fs.MountpathInfo) (factor float64) {
+	now := mono.NanoTime()
+	mpath.mu.Lock()
+	defer mpath.mu.Unlock()
+	if time.Duration(now-mpath.adapted) < bwAdaptIval {
+		return mpath.factor
+	}
+	mpath.adapted = now
+	var (
+		util    = fs.GetMpathUtil(mi.Path)
+		lat     = bl.statsT.GetAvg(stats.GetLatency)
+		baseLat = bl.baseLat(lat, now)
+	)
+	switch {
+	case util >= config.Disk.DiskUtilHighWM || (baseLat > 0 && lat > bwSlowLatMult*baseLat):
+		mpath.factor /= 2
+		if mpath.factor < bwMinFactor {
+			mpath.factor = bwMinFactor
+		}
+	case util < config.Disk.DiskUtilLowWM:
+		mpath.factor *= 2
+		if mpath.factor > 1 {
+			mpath.factor = 1
+		}
+	}
+	return mpath.factor
+}
+
+// updates (at most once per bwAdaptIval, unless dropping) and returns the baseline latency
+func (bl *bwLimiter) baseLat(lat, now int64) int64 {
+	bl.lat.Lock()
+	defer bl.lat.Unlock()
+	switch {
+	case lat <= 0:
+	case bl.lat.base == 0 || lat < bl.lat.base:
+		bl.lat.base, bl.lat.updated = lat, now
+	case time.Duration(now-bl.lat.updated) >= bwAdaptIval:
+		bl.lat.base += int64(float64(lat-bl.lat.base) * bwLatWeight)
+		bl.lat.updated = now
+	}
+	return bl.lat.base
+}
+
+// reserves the time to transfer `size` bytes at the given rate (bytes/s),
+// returns how long to wait until the transfer can start
+func (p *bwPacer) reserve(size int64, rate float64) (delay time.Duration) {
+	now := mono.NanoTime()
+	p.mu.Lock()
+	if p.next < now {
+		p.next = now
+	}
+	delay = time.Duration(p.next - now)
+	p.next += int64(float64(size) / rate * float64(time.Second))
+	p.mu.Unlock()
+	return
+}
@@ -0,0 +1,92 @@
+// Package reb provides local resilver and global rebalance for AIStore.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+type latTrackerMock struct {
+	stats.TrackerMock
+	lat int64
+}
+
+func (m *latTrackerMock) GetAvg(string) int64 { return m.lat }
+
+func TestBwPacerReserve(t *testing.T) {
+	const rate = cos.MiB // bytes/s
+	p := &bwPacer{}
+	delay := p.reserve(cos.MiB/2, rate)
+	tassert.Errorf(t, delay == 0, "first transfer must not wait, got %v", delay)
+	delay = p.reserve(cos.MiB/2, rate)
+	tassert.Errorf(t, delay > 400*time.Millisecond && delay <= 500*time.Millisecond,
+		"expected ~500ms delay, got %v", delay)
+	delay = p.reserve(cos.MiB, rate)
+	tassert.Errorf(t, delay > 900*time.Millisecond && delay <= time.Second, "expected ~1s delay, got %v", delay)
+
+	// idle time is not accumulated
+	p.next = mono.NanoTime() - int64(time.Minute)
+	delay = p.reserve(cos.MiB, 2*rate)
+	tassert.Errorf(t, delay == 0, "expected no delay after idle, got %v", delay)
+	delay = p.reserve(cos.MiB, 2*rate)
+	tassert.Errorf(t, delay > 400*time.Millisecond && delay <= 500*time.Millisecond,
+		"expected ~500ms delay, got %v", delay)
+}
+
+func TestBwAdapt(t *testing.T) {
+	var (
+		mios   = ios.NewIOStaterMock()
+		mi     = &fs.MountpathInfo{Path: "/tmp/bwidth-test"}
+		statsT = &latTrackerMock{}
+		bl     = newBwLimiter(statsT, func(config *cmn.Config) *cmn.BwidthConf { return &config.Rebalance.Bwidth })
+		mpath  = bl.mpath(mi)
+		config = &cmn.Config{}
+	)
+	fs.Init(mios)
+	config.Disk.DiskUtilLowWM, config.Disk.DiskUtilHighWM = 60, 80
+	adapt := func(util int64, lat time.Duration) float64 {
+		mios.Utils.Store(mi.Path, util)
+		statsT.lat = int64(lat)
+		mpath.adapted, bl.lat.updated = 0, 0 // skip bwAdaptIval
+		return bl.adapt(config, mpath, mi)
+	}
+
+	tassert.Errorf(t, adapt(70, 10*time.Millisecond) == 1, "expected no change")
+	tassert.Errorf(t, bl.lat.base == int64(10*time.Millisecond), "expected baseline 10ms, got %d", bl.lat.base)
+
+	// busy mountpath
+	for i, expected := range []float64{0.5, 0.25, 0.125, bwMinFactor, bwMinFactor} {
+		factor := adapt(90, 10*time.Millisecond)
+		tassert.Errorf(t, factor == expected, "%d: expected factor %f, got %f", i, expected, factor)
+	}
+	tassert.Errorf(t, adapt(70, 10*time.Millisecond) == bwMinFactor, "expected no change")
+	for i, expected := range []float64{0.125, 0.25, 0.5, 1, 1} {
+		factor := adapt(50, 10*time.Millisecond)
+		tassert.Errorf(t, factor == expected, "%d: expected factor %f, got %f", i, expected, factor)
+	}
+
+	// latency spike followed by the sustained increase - the baseline catches up
+	tassert.Errorf(t, adapt(50, 30*time.Millisecond) == 0.5, "expected slow down")
+	var n int
+	for n = 0; n < 64 && mpath.factor < 1; n++ {
+		adapt(50, 30*time.Millisecond)
+	}
+	tassert.Errorf(t, mpath.factor == 1, "expected full rate once the baseline adapts, got %f", mpath.factor)
+	tassert.Errorf(t, n > 1 && bl.lat.base*bwSlowLatMult >= int64(30*time.Millisecond),
+		"unexpected baseline %v after %d iterations", time.Duration(bl.lat.base), n)
+
+	// the baseline drops immediately
+	adapt(50, 5*time.Millisecond)
+	tassert.Errorf(t, bl.lat.base == int64(5*time.Millisecond), "expected baseline 5ms, got %d", bl.lat.base)
+}
@@ -0,0 +1,209 @@
+// Package reb provides local resilver and global rebalance for AIStore.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Rebalance checkpoint: per-target progress of the (non-EC) rebalance traversal
+// persisted next to the rebalance marker (see cmn.RebalanceCkpt). For each
+// mountpath, the checkpoint records the last object (per bucket) such that it
+// and all the objects that precede it in the sorted traversal order have been
+// either sent and acknowledged or else found not to require migration.
+//
+// When interrupted rebalance restarts (with the same set of active targets),
+// the mountpath joggers skip the objects recorded as done. The checkpoint is
+// removed once the rebalance successfully completes.
+//
+// An object that fails to be sent is never acknowledged: the checkpoint of its
+// mountpath stalls right before it for the remainder of the run.
+
+const rebCkptSaveInterval = 30 * time.Second
+
+type (
+	rebCkpt struct {
+		Targets []string              `json:"targets"` // active targets (sorted IDs)
+		Mpaths  map[string]*mpathCkpt `json:"mpaths"`  // by mountpath
+		mu      sync.Mutex
+		saved   int64 // mono time
+		dirty   bool
+	}
+	mpathCkpt struct {
+		Buckets cos.SimpleKVs `json:"buckets,omitempty"` // bucket => last done object
+		Done    bool          `json:"done,omitempty"`    // fully traversed and acknowledged
+	}
+
+	// in-flight (sent but not yet acknowledged) or skipped object
+	// in the order of traversal
+	rebPending struct {
+		bck   string
+		name  string
+		uname string // empty when not sent
+		idx   int    // lomAcks shard
+	}
+)
+
+func activeTargets(smap *cluster.Smap) []string {
+	tids := make([]string, 0, len(smap.Tmap))
+	for tid := range smap.Tmap {
+		if smap.GetNodeNotMaint(tid) != nil {
+			tids = append(tids, tid)
+		}
+	}
+	sort.Strings(tids)
+	return tids
+}
+
+// (progress recorded with a different set of targets does not apply)
+func loadRebCkpt(smap *cluster.Smap) *rebCkpt {
+	ckpt := &rebCkpt{Targets: activeTargets(smap), saved: mono.NanoTime()}
+	prev := &rebCkpt{}
+	if fs.LoadMarkerMD(cmn.RebalanceCkpt, prev) && prev.Mpaths != nil {
+		if cos.StrSlicesEqual(prev.Targets, ckpt.Targets) {
+			ckpt.Mpaths = prev.Mpaths
+			glog.Infof("resuming rebalance from the checkpoint (%d mountpaths)", len(ckpt.Mpaths))
+		} else {
+			glog.Infof("discarding rebalance checkpoint: targets changed (%v => %v)", prev.Targets, ckpt.Targets)
+		}
+	}
+	if ckpt.Mpaths == nil {
+		ckpt.Mpaths = make(map[string]*mpathCkpt)
+	}
+	return ckpt
+}
+
+func (ckpt *rebCkpt) mpathDone(mpath string) (done bool) {
+	ckpt.mu.Lock()
+	if mc, ok := ckpt.Mpaths[mpath]; ok {
+		done = mc.Done
+	}
+	ckpt.mu.Unlock()
+	return
+}
+
+// returns the last done object of the bucket, if any
+func (ckpt *rebCkpt) last(mpath, bck string) (name string, ok bool) {
+	ckpt.mu.Lock()
+	if mc, exists := ckpt.Mpaths[mpath]; exists {
+		name, ok = mc.Buckets[bck]
+	}
+	ckpt.mu.Unlock()
+	return
+}
+
+func (ckpt *rebCkpt) update(mpath, bck, name string) {
+	ckpt.mu.Lock()
+	mc := ckpt._mpath(mpath)
+	if mc.Buckets == nil {
+		mc.Buckets = make(cos.SimpleKVs, 4)
+	}
+	mc.Buckets[bck] = name
+	ckpt.dirty = true
+	if mono.Since(ckpt.saved) > rebCkptSaveInterval {
+		ckpt._save()
+	}
+	ckpt.mu.Unlock()
+}
+
+func (ckpt *rebCkpt) finish(mpath string) {
+	ckpt.mu.Lock()
+	ckpt._mpath(mpath).Done = true
+	ckpt.dirty = true
+	ckpt.mu.Unlock()
+}
+
+func (ckpt *rebCkpt) save() {
+	ckpt.mu.Lock()
+	ckpt._save()
+	ckpt.mu.Unlock()
+}
+
+func (ckpt *rebCkpt) _mpath(mpath string) *mpathCkpt {
+	mc, ok := ckpt.Mpaths[mpath]
+	if !ok {
+		mc = &mpathCkpt{}
+		ckpt.Mpaths[mpath] = mc
+	}
+	return mc
+}
+
+func (ckpt *rebCkpt) _save() {
+	if !ckpt.dirty {
+		return
+	}
+	if err := fs.SaveMarkerMD(cmn.RebalanceCkpt, ckpt); err != nil {
+		glog.Errorf("failed to save rebalance progress: %v", err)
+	}
+	ckpt.saved, ckpt.dirty = mono.NanoTime(), false
+}
+
+func (ckpt *rebCkpt) remove() { fs.RemoveMarker(cmn.RebalanceCkpt) }
+
+//////////////////////////////////
+// rebJogger: progress tracking //
+//////////////////////////////////
+
+// record the visited object that either has been sent (and is now waiting for ACK)
+// or does not need to be
+func (rj *rebJogger) track(lom *cluster.LOM, sent bool) {
+	if rj.stalled {
+		return
+	}
+	p := rebPending{bck: rj.bck, name: lom.ObjName}
+	if sent {
+		p.uname = lom.Uname()
+		_, p.idx = lom.Hkey()
+	} else if len(rj.pending) == 0 {
+		rj.m.ckpt.update(rj.mi.Path, p.bck, p.name)
+		return
+	}
+	rj.pending = append(rj.pending, p)
+	rj.advance()
+}
+
+// move the checkpoint past the leading objects that have been acknowledged
+// (or skipped) - up to the first one that is still waiting for ACK or failed
+func (rj *rebJogger) advance() {
+	if rj.stalled {
+		return
+	}
+	var n int
+	for ; n < len(rj.pending); n++ {
+		p := &rj.pending[n]
+		if p.uname == "" {
+			continue
+		}
+		// NOTE: the order matters - failure is recorded prior to removing the ACK
+		if rj.m.waitingAck(p.uname, p.idx) {
+			break
+		}
+		if rj.m.sendErrs.has(p.uname) {
+			rj.stalled = true
+			break
+		}
+	}
+	for i := 0; i < n; i++ {
+		if p := &rj.pending[i]; i == n-1 || rj.pending[i+1].bck != p.bck {
+			rj.m.ckpt.update(rj.mi.Path, p.bck, p.name)
+		}
+	}
+	if rj.stalled {
+		rj.pending = nil
+	} else if n == len(rj.pending) {
+		rj.pending = rj.pending[:0]
+	} else {
+		rj.pending = rj.pending[n:]
+	}
+}
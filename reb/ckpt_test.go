@@ -0,0 +1,66 @@
+// Package reb provides local resilver and global rebalance for AIStore.
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+func TestRebCkptAdvance(t *testing.T) {
+	const bck = "ais://bck"
+	var (
+		m  = &Manager{ckpt: &rebCkpt{Mpaths: make(map[string]*mpathCkpt), saved: mono.NanoTime()}}
+		rj = &rebJogger{joggerBase: joggerBase{m: m}, mi: &fs.MountpathInfo{Path: "/mpath"}, bck: bck}
+	)
+	for i := range m.lomacks {
+		m.lomacks[i] = &lomAcks{mu: &sync.Mutex{}, q: make(map[string]*cluster.LOM)}
+	}
+	send := func(name string) rebPending {
+		m.lomacks[0].q[name] = nil
+		return rebPending{bck: bck, name: name, uname: name}
+	}
+	checkLast := func(expected string) {
+		last, ok := m.ckpt.last(rj.mi.Path, bck)
+		tassert.Errorf(t, ok && last == expected, "expected checkpoint at %q, got %q", expected, last)
+	}
+
+	rj.pending = []rebPending{
+		{bck: bck, name: "o1"}, // skipped
+		send("o2"),
+		{bck: bck, name: "o3"},
+		send("o4"),
+		send("o5"),
+	}
+	rj.advance()
+	checkLast("o1")
+	tassert.Errorf(t, len(rj.pending) == 4, "expected 4 pending, got %d", len(rj.pending))
+
+	// ACK
+	delete(m.lomacks[0].q, "o2")
+	rj.advance()
+	checkLast("o3")
+
+	// out of order ACK does not move the checkpoint
+	delete(m.lomacks[0].q, "o5")
+	rj.advance()
+	checkLast("o3")
+
+	// failed send (see objSentCallback) - the checkpoint stalls
+	m.sendErrs.add("o4")
+	delete(m.lomacks[0].q, "o4")
+	rj.advance()
+	checkLast("o3")
+	tassert.Errorf(t, rj.stalled && len(rj.pending) == 0, "expected stalled checkpoint")
+
+	rj.pending = append(rj.pending, rebPending{bck: bck, name: "o6"})
+	rj.advance()
+	checkLast("o3")
+}
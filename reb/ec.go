@@ -155,14 +155,16 @@ func (reb *Manager) sendFromDisk(ct *cluster.CT, meta *ec.Metadata, target *clus
 	reb.onAir.Inc()
 	o.Hdr.Opaque = req.NewPack(rebMsgEC)
 	o.Callback = reb.transportECCB
+	size := o.Hdr.ObjAttrs.Size
 	if err = reb.dm.Send(o, roc, target); err != nil {
 		err = fmt.Errorf("failed to send slices to nodes [%s..]: %v", target.ID(), err)
 		return
 	}
 	reb.statTracker.AddMany(
 		stats.NamedVal64{Name: stats.RebTxCount, Value: 1},
-		stats.NamedVal64{Name: stats.RebTxSize, Value: o.Hdr.ObjAttrs.Size},
+		stats.NamedVal64{Name: stats.RebTxSize, Value: size},
 	)
+	reb.bwReb.wait(ct.MpathInfo(), size, reb.xact().ChanAbort())
 	return
 }
 
@@ -425,13 +427,16 @@ func (reb *Manager) walkEC(fqn string, de fs.DirEntry) (err error) {
 	if de.IsDir() {
 		return nil
 	}
+	if reb.xact().WaitResumed() {
+		return cmn.NewAbortedError("interrupt walk - xaction aborted")
+	}
 
 	ct, err := cluster.NewCTFromFQN(fqn, reb.t.Bowner())
 	if err != nil {
 		return nil
 	}
 	// do not touch directories for buckets with EC disabled (for now)
-	if !ct.Bck().Props.EC.Enabled {
+	if !ct.Bck().Props.ECUsed() {
 		return filepath.SkipDir
 	}
 
@@ -31,9 +31,13 @@ import (
 type (
 	rebJogger struct {
 		joggerBase
-		smap *cluster.Smap
-		sema *cos.DynSemaphore
-		ver  int64
+		smap    *cluster.Smap
+		sema    *cos.DynSemaphore
+		ver     int64
+		mi      *fs.MountpathInfo
+		bck     string       // the bucket being traversed
+		pending []rebPending // see reb/ckpt.go
+		stalled bool         // ditto
 	}
 	rebArgs struct {
 		id     int64
@@ -233,12 +237,15 @@ func (reb *Manager) rebInit(md *rebArgs, notif *xaction.NotifXact) bool {
 	for i := 0; i < len(acks); i++ { // init lom acks
 		acks[i] = &lomAcks{mu: &sync.Mutex{}, q: make(map[string]*cluster.LOM, 64)}
 	}
+	reb.verErrs.reset()
+	reb.sendErrs.reset()
 
-	// 4. create persistent mark
+	// 4. create persistent mark and load the progress of the interrupted rebalance, if any
 	err := fs.PersistMarker(cmn.RebalanceMarker)
 	if err != nil {
 		glog.Errorf("Failed to create marker: %v", err)
 	}
+	reb.ckpt = loadRebCkpt(md.smap)
 
 	// 5. ready - can receive objects
 	reb.smap.Store(unsafe.Pointer(md.smap))
@@ -315,6 +322,9 @@ func (reb *Manager) rebWaitAck(md *rebArgs) (errCnt int) {
 		// poll for no more than maxwt while keeping track of the cumulative polling time via curwt
 		// (here and elsewhere)
 		for curwt < maxwt {
+			if reb.xact().Paused() {
+				curwt = 0 // do not time out while paused
+			}
 			cnt = 0
 			var logged bool
 			for _, lomack := range reb.lomAcks() {
@@ -388,6 +398,9 @@ func (reb *Manager) rebFini(md *rebArgs, err error) {
 	// prior to closing the streams
 	if q := reb.quiesce(md, md.config.Rebalance.Quiesce.D(), reb.nodesQuiescent); q != cluster.QuiAborted {
 		fs.RemoveMarker(cmn.RebalanceMarker)
+		reb.ckpt.remove()
+	} else {
+		reb.ckpt.save() // to resume from
 	}
 	reb.endStreams(err)
 	reb.filterGFN.Reset()
@@ -424,15 +437,22 @@ func (rj *rebJogger) jog(mpathInfo *fs.MountpathInfo) {
 	// sure that `Done` is called even if the jogger crashes to avoid hang up
 	defer rj.wg.Done()
 
+	if rj.m.ckpt.mpathDone(mpathInfo.Path) {
+		glog.Infof("%s: skipping %s (done prior to restart)", rj.m.t.Snode(), mpathInfo)
+		return
+	}
+	// sorted, to be able to resume from the checkpoint (see reb/ckpt.go)
 	opts := &fs.Options{
 		Mpath:    mpathInfo,
 		CTs:      []string{fs.ObjectType},
 		Callback: rj.walk,
-		Sorted:   false,
+		Sorted:   true,
 	}
+	rj.mi = mpathInfo
 	rj.m.t.Bowner().Get().Range(nil, nil, func(bck *cluster.Bck) bool {
 		opts.ErrCallback = nil
 		opts.Bck = bck.Bck
+		rj.bck = bck.String()
 		if err := fs.Walk(opts); err != nil {
 			if rj.xreb.Aborted() {
 				glog.Infof("aborting traversal")
@@ -449,13 +469,19 @@ func (rj *rebJogger) jog(mpathInfo *fs.MountpathInfo) {
 		rj.sema.Acquire(rj.sema.Size())
 		rj.sema.Release(rj.sema.Size())
 	}
+	rj.advance()
+	if len(rj.pending) == 0 && !rj.stalled && !rj.xreb.Aborted() {
+		rj.m.ckpt.finish(mpathInfo.Path)
+	}
 }
 
 // send completion
 func (rj *rebJogger) objSentCallback(hdr transport.ObjHdr, _ io.ReadCloser, arg interface{}, err error) {
 	rj.m.inQueue.Dec()
 	if err != nil {
-		rj.m.delLomAck(arg.(*cluster.LOM))
+		lom := arg.(*cluster.LOM)
+		rj.m.sendErrs.add(lom.Uname()) // (prior to delLomAck - see advance)
+		rj.m.delLomAck(lom)
 		if bool(glog.FastV(4, glog.SmoduleReb)) || !cos.IsErrConnectionRefused(err) {
 			si := rj.m.t.Snode()
 			glog.Errorf("%s: failed to send o[%s]: %v", si, hdr.FullName(), err)
@@ -475,6 +501,9 @@ func (rj *rebJogger) walk(fqn string, de fs.DirEntry) (err error) {
 	if de.IsDir() {
 		return nil
 	}
+	if rj.m.xact().WaitResumed() {
+		return cmn.NewAbortedError("traversal", rj.xreb.String())
+	}
 	lom := cluster.AllocLOMbyFQN(fqn) // NOTE: free on error or via (send => ... => delLomAck) path
 	err = rj._lwalk(lom)
 	if err != nil {
@@ -494,10 +523,28 @@ func (rj *rebJogger) _lwalk(lom *cluster.LOM) (err error) {
 		}
 		return cmn.ErrSkip
 	}
+	if last, ok := rj.m.ckpt.last(rj.mi.Path, rj.bck); ok && !fs.NameAfter(lom.ObjName, last) {
+		return cmn.ErrSkip // done prior to restart
+	}
+	if err = rj._migrate(lom); err == cmn.ErrSkip {
+		rj.track(lom, false /*sent*/)
+	}
+	return
+}
+
+func (rj *rebJogger) _migrate(lom *cluster.LOM) (err error) {
 	// skip EC.Enabled bucket - the job for EC rebalance
-	if lom.Bck().Props.EC.Enabled {
+	bprops := lom.Bprops()
+	if bprops.EC.Enabled && len(bprops.StorageClass.Classes) == 0 {
 		return filepath.SkipDir
 	}
+	// ditto for erasure coded objects of the bucket's EC storage classes
+	if bprops.ECUsed() {
+		mdFQN := lom.MpathInfo().MakePathFQN(lom.Bucket(), fs.ECMetaType, lom.ObjName)
+		if err := fs.Access(mdFQN); err == nil {
+			return cmn.ErrSkip
+		}
+	}
 	var tsi *cluster.Snode
 	tsi, err = cluster.HrwTarget(lom.Uname(), rj.smap)
 	if err != nil {
@@ -519,8 +566,14 @@ func (rj *rebJogger) _lwalk(lom *cluster.LOM) (err error) {
 	if roc, err = _prepSend(lom); err != nil {
 		return
 	}
-	// transmit
+	// (lom may be freed once sent)
+	mi, size := lom.MpathInfo(), lom.SizeBytes()
+	// transmit (previous versions, if any, go first while the object is still locked)
+	if lom.KeepsHistory() {
+		rj.sendVersions(lom, tsi)
+	}
 	rj.m.addLomAck(lom)
+	rj.track(lom, true /*sent*/)
 	if rj.sema == nil {
 		rj.doSend(lom, tsi, roc)
 	} else { // rebalance.multiplier > 1
@@ -530,6 +583,7 @@ func (rj *rebJogger) _lwalk(lom *cluster.LOM) (err error) {
 			rj.sema.Release()
 		}()
 	}
+	rj.m.bwReb.wait(mi, size, rj.xreb.ChanAbort())
 	return
 }
 
@@ -575,3 +629,49 @@ func (rj *rebJogger) doSend(lom *cluster.LOM, tsi *cluster.Snode, roc cos.ReadOp
 	rj.m.inQueue.Inc()
 	rj.m.dm.Send(o, roc, tsi)
 }
+
+// Previous versions are sent ahead of the object (same stream) and are not
+// acknowledged separately: ACK of the object means that they've been received,
+// and the sender then removes them (see delVersions).
+func (rj *rebJogger) sendVersions(lom *cluster.LOM, tsi *cluster.Snode) {
+	vlist, err := lom.Versions()
+	if err != nil {
+		glog.Errorf("%s: failed to list versions of %s: %v", rj.m.t.Snode(), lom, err)
+		rj.m.verErrs.add(lom.Uname())
+		return
+	}
+	ack := regularAck{rebID: rj.m.RebID(), daemonID: rj.m.t.SID()}
+	for _, vlom := range vlist {
+		fh, err := cos.NewFileHandle(vlom.FQN)
+		if err != nil {
+			glog.Errorf("%s: %v", vlom, err)
+			rj.m.verErrs.add(lom.Uname())
+			cluster.FreeLOM(vlom)
+			continue
+		}
+		o := transport.AllocSend()
+		o.Hdr.Bck = lom.Bucket()
+		o.Hdr.ObjName = lom.ObjName
+		o.Hdr.Opaque = ack.newPack(rebMsgVersion)
+		o.Hdr.ObjAttrs.CopyFrom(vlom.ObjAttrs())
+		o.Hdr.ObjAttrs.Atime = vlom.AtimeUnix() // non-current since
+		o.Callback, o.CmplArg = rj.verSentCallback, vlom
+		rj.m.inQueue.Inc()
+		rj.m.dm.Send(o, fh, tsi)
+	}
+}
+
+func (rj *rebJogger) verSentCallback(hdr transport.ObjHdr, _ io.ReadCloser, arg interface{}, err error) {
+	rj.m.inQueue.Dec()
+	cluster.FreeLOM(arg.(*cluster.LOM))
+	if err != nil {
+		glog.Errorf("%s: failed to send o[%s] version %s: %v", rj.m.t.Snode(), hdr.FullName(),
+			hdr.ObjAttrs.Ver, err)
+		rj.m.verErrs.add(hdr.Bck.MakeUname(hdr.ObjName)) // keep the versions
+		return
+	}
+	rj.m.statTracker.AddMany(
+		stats.NamedVal64{Name: stats.RebTxCount, Value: 1},
+		stats.NamedVal64{Name: stats.RebTxSize, Value: hdr.ObjAttrs.Size},
+	)
+}
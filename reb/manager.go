@@ -74,10 +74,19 @@ type (
 		xreb       atomic.Pointer // *xaction.Rebalance
 		stages     *nodeStages
 		ecClient   *http.Client
+		bwReb      *bwLimiter // rebalance bandwidth
+		bwRslv     *bwLimiter // resilver bandwidth
+		ckpt       *rebCkpt   // progress of the current rebalance
 		rebID      atomic.Int64
 		inQueue    atomic.Int64
 		onAir      atomic.Int64
 		laterx     atomic.Bool
+		verErrs    unameSet // objects whose previous versions failed to migrate (to be sent or received)
+		sendErrs   unameSet // objects that failed to be sent (see rebJogger.advance)
+	}
+	unameSet struct {
+		mu sync.Mutex
+		m  map[string]struct{}
 	}
 	lomAcks struct {
 		mu *sync.Mutex
@@ -112,6 +121,8 @@ func NewManager(t cluster.Target, config *cmn.Config, st stats.Tracker) *Manager
 		statTracker: st,
 		stages:      newNodeStages(),
 		ecClient:    ecClient,
+		bwReb:       newBwLimiter(st, func(config *cmn.Config) *cmn.BwidthConf { return &config.Rebalance.Bwidth }),
+		bwRslv:      newBwLimiter(st, func(config *cmn.Config) *cmn.BwidthConf { return &config.Resilver.Bwidth }),
 	}
 	rebcfg := &config.Rebalance
 	dmExtra := bundle.Extra{
@@ -155,6 +166,14 @@ func (reb *Manager) addLomAck(lom *cluster.LOM) {
 	lomAck.mu.Unlock()
 }
 
+func (reb *Manager) waitingAck(uname string, idx int) (ok bool) {
+	lomAck := reb.lomAcks()[idx]
+	lomAck.mu.Lock()
+	_, ok = lomAck.q[uname]
+	lomAck.mu.Unlock()
+	return
+}
+
 func (reb *Manager) delLomAck(lom *cluster.LOM) {
 	_, idx := lom.Hkey()
 	lomAck := reb.lomAcks()[idx]
@@ -166,6 +185,47 @@ func (reb *Manager) delLomAck(lom *cluster.LOM) {
 	lomAck.mu.Unlock()
 }
 
+//////////////
+// unameSet //
+//////////////
+
+func (s *unameSet) add(uname string) {
+	s.mu.Lock()
+	if s.m == nil {
+		s.m = make(map[string]struct{}, 16)
+	}
+	s.m[uname] = struct{}{}
+	s.mu.Unlock()
+}
+
+func (s *unameSet) has(uname string) (ok bool) {
+	s.mu.Lock()
+	_, ok = s.m[uname]
+	s.mu.Unlock()
+	return
+}
+
+func (s *unameSet) reset() {
+	s.mu.Lock()
+	s.m = nil
+	s.mu.Unlock()
+}
+
+// Previous versions (if any) precede the object on the wire (see sendVersions),
+// and the object is not acknowledged unless they all have been received.
+// Therefore, ACK means that the versions can be removed.
+func (reb *Manager) delVersions(lom *cluster.LOM) {
+	if !lom.KeepsHistory() || reb.verErrs.has(lom.Uname()) {
+		return
+	}
+	lom.Lock(true)
+	err := lom.DelAllVersions()
+	lom.Unlock(true)
+	if err != nil {
+		glog.Errorf("%s: failed to remove migrated versions of %s: %v", reb.t.Snode(), lom, err)
+	}
+}
+
 func (reb *Manager) logHdr(md *rebArgs) string {
 	stage := stages[reb.stages.stage.Load()]
 	return fmt.Sprintf("%s[g%d,v%d,%s]", reb.t.Snode(), md.id, md.smap.Version, stage)
@@ -267,6 +327,11 @@ func (reb *Manager) recvObjRegular(hdr transport.ObjHdr, smap *cluster.Smap, unp
 		glog.Errorf("%s target is not found in smap", tsid)
 		return
 	}
+	if reb.verErrs.has(lom.Uname()) {
+		glog.Errorf("%s: not acknowledging %s from %s: failed to receive previous version(s)",
+			reb.t.Snode(), lom, tsid)
+		return
+	}
 	if stage := reb.stages.stage.Load(); stage < rebStageFinStreams && stage != rebStageInactive {
 		ack := &regularAck{rebID: reb.RebID(), daemonID: reb.t.SID()}
 		hdr.Opaque = ack.NewPack()
@@ -277,6 +342,43 @@ func (reb *Manager) recvObjRegular(hdr transport.ObjHdr, smap *cluster.Smap, unp
 	}
 }
 
+// previous version of an object (see cluster/lom_ver.go)
+func (reb *Manager) recvObjVersion(hdr transport.ObjHdr, unpacker *cos.ByteUnpack, objReader io.Reader) {
+	defer cos.DrainReader(objReader)
+
+	ack := &regularAck{}
+	if err := unpacker.ReadAny(ack); err != nil {
+		glog.Errorf("Failed to parse acknowledgement: %v", err)
+		return
+	}
+	if ack.rebID != reb.RebID() {
+		glog.Warningf("received %s version %s: %s", hdr.FullName(), hdr.ObjAttrs.Ver, reb.rebIDMismatchMsg(ack.rebID))
+		return
+	}
+	lom := cluster.AllocLOM(hdr.ObjName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.Init(hdr.Bck); err != nil {
+		glog.Error(err)
+		reb.verErrs.add(hdr.Bck.MakeUname(hdr.ObjName))
+		return
+	}
+	lom.CopyAttrs(&hdr.ObjAttrs, false /*skip-checksum*/)
+	buf, slab := reb.t.MMSA().Alloc()
+	lom.Lock(true)
+	err := lom.SaveVersion(objReader, time.Unix(0, hdr.ObjAttrs.Atime), buf)
+	lom.Unlock(true)
+	slab.Free(buf)
+	if err != nil {
+		glog.Errorf("%s: failed to receive %s version %s: %v", reb.t.Snode(), lom, hdr.ObjAttrs.Ver, err)
+		reb.verErrs.add(lom.Uname())
+		return
+	}
+	reb.statTracker.AddMany(
+		stats.NamedVal64{Name: stats.RebRxCount, Value: 1},
+		stats.NamedVal64{Name: stats.RebRxSize, Value: hdr.ObjAttrs.Size},
+	)
+}
+
 func (reb *Manager) waitForSmap() (*cluster.Smap, error) {
 	smap := (*cluster.Smap)(reb.smap.Load())
 	if smap == nil {
@@ -328,6 +430,10 @@ func (reb *Manager) recvObj(hdr transport.ObjHdr, objReader io.Reader, err error
 		reb.recvObjRegular(hdr, smap, unpacker, objReader)
 		return
 	}
+	if act == rebMsgVersion {
+		reb.recvObjVersion(hdr, unpacker, objReader)
+		return
+	}
 
 	if act != rebMsgEC {
 		glog.Errorf("Invalid ACK type %d, expected %d", act, rebMsgEC)
@@ -415,6 +521,7 @@ func (reb *Manager) recvRegularAck(hdr transport.ObjHdr, unpacker *cos.ByteUnpac
 	// No immediate file deletion: let LRU cleanup the "misplaced" object
 	// TODO: mark the object "Deleted"
 	reb.delLomAck(lom)
+	reb.delVersions(lom)
 }
 
 func (reb *Manager) recvAck(hdr transport.ObjHdr, _ io.Reader, err error) {
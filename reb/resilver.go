@@ -25,6 +25,7 @@ type (
 	joggerCtx struct {
 		xact cluster.Xact
 		t    cluster.Target
+		bw   *bwLimiter
 	}
 )
 
@@ -51,10 +52,10 @@ func (reb *Manager) RunResilver(id string, skipGlobMisplaced bool, notifs ...*xa
 	slab, err := reb.t.MMSA().GetSlab(memsys.MaxPageSlabSize)
 	debug.AssertNoErr(err)
 
-	jctx := &joggerCtx{xact: xact, t: reb.t}
+	jctx := &joggerCtx{xact: xact, t: reb.t, bw: reb.bwRslv}
 	jg := mpather.NewJoggerGroup(&mpather.JoggerGroupOpts{
 		T:                     reb.t,
-		CTs:                   []string{fs.ObjectType, fs.ECSliceType},
+		CTs:                   []string{fs.ObjectType, fs.ECSliceType, fs.ObjVersionType},
 		VisitObj:              jctx.visitObj,
 		VisitCT:               jctx.visitCT,
 		Slab:                  slab,
@@ -80,8 +81,8 @@ func (reb *Manager) RunResilver(id string, skipGlobMisplaced bool, notifs ...*xa
 
 // Copies a slice and its metafile (if exists) to the current mpath. At the
 // end does proper cleanup: removes ether source files(on success), or
-// destination files(on copy failure)
-func _mvSlice(ct *cluster.CT, buf []byte) {
+// destination files(on copy failure). Returns the number of bytes copied.
+func _mvSlice(ct *cluster.CT, buf []byte) (size int64) {
 	uname := ct.Bck().MakeUname(ct.ObjectName())
 	destMpath, _, err := cluster.HrwMpath(uname)
 	if err != nil {
@@ -104,7 +105,7 @@ func _mvSlice(ct *cluster.CT, buf []byte) {
 	if glog.FastV(4, glog.SmoduleReb) {
 		glog.Infof("Resilver moving %q -> %q", ct.FQN(), destFQN)
 	}
-	if _, _, err = cos.CopyFile(ct.FQN(), destFQN, buf, cos.ChecksumNone); err != nil {
+	if size, _, err = cos.CopyFile(ct.FQN(), destFQN, buf, cos.ChecksumNone); err != nil {
 		glog.Errorf("Failed to copy %q -> %q: %v. Rolling back", ct.FQN(), destFQN, err)
 		if err = os.Remove(destMetaFQN); err != nil {
 			glog.Warningf("Failed to cleanup metafile copy %q: %v", destMetaFQN, err)
@@ -115,6 +116,31 @@ func _mvSlice(ct *cluster.CT, buf []byte) {
 	if errMeta != nil || errSlice != nil {
 		glog.Warningf("Failed to cleanup %q: %v, %v", ct.FQN(), errSlice, errMeta)
 	}
+	return
+}
+
+// Moves a previous version of an object to the object's (HRW) mpath
+func _mvVersion(ct *cluster.CT, buf []byte) {
+	contentResolver := fs.CSM.RegisteredContentTypes[fs.ObjVersionType]
+	objName, _, ok := contentResolver.ParseUniqueFQN(ct.ObjectName())
+	if !ok {
+		return
+	}
+	destMpath, _, err := cluster.HrwMpath(ct.Bck().MakeUname(objName))
+	if err != nil {
+		glog.Warning(err)
+		return
+	}
+	if destMpath.Path == ct.MpathInfo().Path {
+		return
+	}
+	destFQN := destMpath.MakePathFQN(ct.Bucket(), fs.ObjVersionType, ct.ObjectName())
+	if glog.FastV(4, glog.SmoduleReb) {
+		glog.Infof("Resilver moving %q -> %q", ct.FQN(), destFQN)
+	}
+	if err := cluster.MoveWithMD(ct.FQN(), destFQN, buf); err != nil {
+		glog.Errorf("Failed to move %q -> %q: %v", ct.FQN(), destFQN, err)
+	}
 }
 
 // Copies EC metafile to correct mpath. It returns FQNs of the source and
@@ -155,7 +181,7 @@ func (rj *joggerCtx) moveObject(lom *cluster.LOM, buf []byte) {
 
 	// First, copy metafile if EC is enables. Copy the object only if the
 	// metafile has been copies successfully
-	if lom.Bprops().EC.Enabled {
+	if lom.Bprops().ECUsed() {
 		newMpath, _, err := cluster.ResolveFQN(lom.HrwFQN)
 		if err != nil {
 			glog.Warningf("%s: %v", lom, err)
@@ -190,6 +216,8 @@ func (rj *joggerCtx) moveObject(lom *cluster.LOM, buf []byte) {
 	rj.xact.BytesAdd(size)
 	rj.xact.ObjectsInc()
 	// NOTE: Rely on LRU to remove "misplaced".
+
+	rj.bw.wait(lom.MpathInfo(), size, rj.xact.ChanAbort())
 }
 
 func (rj *joggerCtx) visitObj(lom *cluster.LOM, buf []byte) (err error) {
@@ -197,13 +225,19 @@ func (rj *joggerCtx) visitObj(lom *cluster.LOM, buf []byte) (err error) {
 	return nil
 }
 
-func (*joggerCtx) visitCT(ct *cluster.CT, buf []byte) (err error) {
+func (rj *joggerCtx) visitCT(ct *cluster.CT, buf []byte) (err error) {
+	if ct.ContentType() == fs.ObjVersionType {
+		_mvVersion(ct, buf)
+		return nil
+	}
 	debug.Assert(ct.ContentType() == fs.ECSliceType)
-	if !ct.Bck().Props.EC.Enabled {
+	if !ct.Bck().Props.ECUsed() {
 		// Since `%ec` directory is inside a bucket, it is safe to skip
 		// the entire `%ec` directory when EC is disabled for the bucket.
 		return filepath.SkipDir
 	}
-	_mvSlice(ct, buf)
+	if size := _mvSlice(ct, buf); size > 0 {
+		rj.bw.wait(ct.MpathInfo(), size, rj.xact.ChanAbort())
+	}
 	return nil
 }
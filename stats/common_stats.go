@@ -105,6 +105,7 @@ type (
 		StartedUp() bool
 		Add(name string, val int64)
 		Get(name string) int64
+		GetAvg(name string) int64
 		AddErrorHTTP(method string, val int64)
 		AddMany(namedVal64 ...NamedVal64)
 		CoreStats() *CoreStats
@@ -387,6 +388,18 @@ func (s *CoreStats) get(name string) (val int64) {
 	return
 }
 
+// average latency over the samples collected so far in the current stats interval
+func (s *CoreStats) getAvg(name string) (val int64) {
+	v := s.Tracker[name]
+	debug.Assert(v.kind == KindLatency)
+	v.RLock()
+	if v.numSamples > 0 {
+		val = v.Value / v.numSamples
+	}
+	v.RUnlock()
+	return
+}
+
 // NOTE naming convention: ".n" for the count and ".ns" for duration (nanoseconds)
 func (s *CoreStats) doAdd(name, nameSuffix string, val int64) {
 	v, ok := s.Tracker[name]
@@ -694,8 +707,9 @@ func (r *statsRunner) Collect(ch chan<- prometheus.Metric) {
 
 func (r *statsRunner) Name() string { return r.name }
 
-func (r *statsRunner) CoreStats() *CoreStats       { return r.Core }
-func (r *statsRunner) Get(name string) (val int64) { return r.Core.get(name) }
+func (r *statsRunner) CoreStats() *CoreStats          { return r.Core }
+func (r *statsRunner) Get(name string) (val int64)    { return r.Core.get(name) }
+func (r *statsRunner) GetAvg(name string) (val int64) { return r.Core.getAvg(name) }
 
 func (r *statsRunner) runcommon(logger statsLogger) error {
 	var (
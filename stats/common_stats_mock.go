@@ -21,6 +21,7 @@ func NewTrackerMock() Tracker {
 func (*TrackerMock) StartedUp() bool            { return true }
 func (*TrackerMock) Add(string, int64)          {}
 func (*TrackerMock) Get(string) int64           { return 0 }
+func (*TrackerMock) GetAvg(string) int64        { return 0 }
 func (*TrackerMock) AddErrorHTTP(string, int64) {}
 func (*TrackerMock) AddMany(...NamedVal64)      {}
 func (*TrackerMock) RegMetrics(*cluster.Snode)  {}
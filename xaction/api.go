@@ -27,6 +27,7 @@ type (
 		Owned      bool            // true: JTX-owned
 		RefreshCap bool            // true: refresh capacity stats upon completion
 		Mountpath  bool            // true: mountpath-traversing (jogger-based) xaction
+		Pausable   bool            // true: can be paused and resumed (see XactBase.Pause)
 	}
 
 	XactReqMsg struct {
@@ -35,6 +36,8 @@ type (
 		Bck         cmn.Bck   `json:"bck"`
 		OnlyRunning *bool     `json:"show_active"`
 		Force       *bool     `json:"force"`             // true: force LRU
+		DryRun      bool      `json:"dry_run,omitempty"` // true: only report what would be done (lifecycle)
+		Restart     bool      `json:"restart,omitempty"` // true: start over, discarding saved progress (scrub)
 		Buckets     []cmn.Bck `json:"buckets,omitempty"` // list of buckets on which LRU should run
 		Node        string    `json:"node,omitempty"`
 	}
@@ -48,6 +51,7 @@ type (
 		ObjCountX   int64     `json:"obj_count,string"`
 		BytesCountX int64     `json:"bytes_count,string"`
 		AbortedX    bool      `json:"aborted"`
+		PausedX     bool      `json:"paused,omitempty"`
 	}
 
 	BaseXactStatsExt struct {
@@ -71,23 +75,26 @@ var XactsDtor = map[string]XactDescriptor{
 	cmn.ActLRU:       {Type: XactTypeGlobal, Startable: true, Mountpath: true},
 	cmn.ActElection:  {Type: XactTypeGlobal, Startable: false},
 	cmn.ActResilver:  {Type: XactTypeGlobal, Startable: true, Mountpath: true},
-	cmn.ActRebalance: {Type: XactTypeGlobal, Startable: true, Metasync: true, Owned: false, Mountpath: true},
+	cmn.ActRebalance: {Type: XactTypeGlobal, Startable: true, Metasync: true, Owned: false, Mountpath: true, Pausable: true},
 	cmn.ActDownload:  {Type: XactTypeGlobal, Startable: false, Mountpath: true},
+	cmn.ActScrub:     {Type: XactTypeGlobal, Startable: true, Mountpath: true},
 
 	// xactions that run on a given bucket or buckets
 	cmn.ActECGet:          {Type: XactTypeBck, Startable: false},
 	cmn.ActECPut:          {Type: XactTypeBck, Startable: false},
 	cmn.ActECRespond:      {Type: XactTypeBck, Startable: false},
-	cmn.ActMakeNCopies:    {Type: XactTypeBck, Access: cmn.AccessRW, Startable: true, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true},
+	cmn.ActMakeNCopies:    {Type: XactTypeBck, Access: cmn.AccessRW, Startable: true, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, Pausable: true},
 	cmn.ActPutCopies:      {Type: XactTypeBck, Startable: false},
 	cmn.ActArchive:        {Type: XactTypeBck, Startable: false},
 	cmn.ActMoveBck:        {Type: XactTypeBck, Access: cmn.AccessMoveBucket, Startable: false, Metasync: true, Owned: false, Mountpath: true},
-	cmn.ActCopyBck:        {Type: XactTypeBck, Access: cmn.AccessRW, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true},
+	cmn.ActCopyBck:        {Type: XactTypeBck, Access: cmn.AccessRW, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, Pausable: true},
 	cmn.ActETLBck:         {Type: XactTypeBck, Access: cmn.AccessRW, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true},
-	cmn.ActECEncode:       {Type: XactTypeBck, Access: cmn.AccessRW, Startable: true, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true},
+	cmn.ActECEncode:       {Type: XactTypeBck, Access: cmn.AccessRW, Startable: true, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, Pausable: true},
+	cmn.ActReencode:       {Type: XactTypeBck, Access: cmn.AccessRW, Startable: true, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true},
 	cmn.ActEvictObjects:   {Type: XactTypeBck, Access: cmn.AccessObjDELETE, Startable: false, Mountpath: true},
 	cmn.ActDelete:         {Type: XactTypeBck, Access: cmn.AccessObjDELETE, Startable: false, Mountpath: true},
 	cmn.ActLoadLomCache:   {Type: XactTypeBck, Startable: true, Mountpath: true},
+	cmn.ActLifecycle:      {Type: XactTypeBck, Access: cmn.AccessObjDELETE, Startable: true, Mountpath: true},
 	cmn.ActPrefetch:       {Type: XactTypeBck, Access: cmn.AccessRW, Startable: true},
 	cmn.ActPromote:        {Type: XactTypeBck, Access: cmn.AccessPROMOTE, Startable: false, RefreshCap: true},
 	cmn.ActQueryObjects:   {Type: XactTypeBck, Access: cmn.AccessObjLIST, Startable: false, Metasync: false, Owned: true},
@@ -99,6 +106,7 @@ var XactsDtor = map[string]XactDescriptor{
 func IsValidKind(kind string) bool { _, ok := XactsDtor[kind]; return ok }
 func IsTypeBck(kind string) bool   { return XactsDtor[kind].Type == XactTypeBck }
 func IsMountpath(kind string) bool { return XactsDtor[kind].Mountpath }
+func IsPausable(kind string) bool  { return XactsDtor[kind].Pausable }
 
 ///////////////////
 // BaseXactStats //
@@ -112,6 +120,7 @@ func (b *BaseXactStats) EndTime() time.Time   { return b.EndTimeX }
 func (b *BaseXactStats) ObjCount() int64      { return b.ObjCountX }
 func (b *BaseXactStats) BytesCount() int64    { return b.BytesCountX }
 func (b *BaseXactStats) Aborted() bool        { return b.AbortedX }
+func (b *BaseXactStats) Paused() bool         { return b.PausedX }
 func (b *BaseXactStats) Running() bool        { return b.EndTimeX.IsZero() }
 func (b *BaseXactStats) Finished() bool       { return !b.EndTimeX.IsZero() }
 
@@ -20,6 +20,7 @@ type XactBckJog struct {
 func (r *XactBckJog) Init(id, kind string, bck *cluster.Bck, opts *mpather.JoggerGroupOpts) {
 	r.t = opts.T
 	r.InitBase(id, kind, bck)
+	opts.WaitResumed = r.WaitResumed
 	r.joggers = mpather.NewJoggerGroup(opts)
 }
 
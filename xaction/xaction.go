@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
@@ -31,6 +32,10 @@ type (
 		abrt    chan struct{}
 		aborted atomic.Bool
 		notif   *NotifXact
+		// pause/resume
+		pmu    sync.Mutex
+		resume chan struct{} // non-nil while paused
+		paused atomic.Bool
 	}
 	XactMarked struct {
 		Xact        cluster.Xact
@@ -62,6 +67,7 @@ func (xact *XactBase) Bck() *cluster.Bck          { return xact.bck }
 func (xact *XactBase) Finished() bool             { return xact.eutime.Load() != 0 }
 func (xact *XactBase) ChanAbort() <-chan struct{} { return xact.abrt }
 func (xact *XactBase) Aborted() bool              { return xact.aborted.Load() }
+func (xact *XactBase) Paused() bool               { return xact.paused.Load() }
 
 func (xact *XactBase) AbortedAfter(d time.Duration) (aborted bool) {
 	sleep := cos.CalcProbeFreq(d)
@@ -88,6 +94,9 @@ func (xact *XactBase) Quiesce(d time.Duration, cb cluster.QuiCB) cluster.QuiRes
 		if xact.Aborted() {
 			return cluster.QuiAborted
 		}
+		if xact.Paused() {
+			continue // time spent paused does not count
+		}
 		total += sleep
 		switch res := cb(total); res {
 		case cluster.QuiInactive:
@@ -190,6 +199,58 @@ func (xact *XactBase) Finish(err error) {
 	if xact.eutime.Load() == 0 {
 		xact._setEndTime(err)
 	}
+	xact.Resume()
+}
+
+// Pause keeps the xaction in place - with all its state and progress - until
+// resumed or aborted; it is up to the (pausable) xaction to call WaitResumed
+// between units of work.
+func (xact *XactBase) Pause() error {
+	if !XactsDtor[xact.kind].Pausable {
+		return fmt.Errorf("%s cannot be paused", xact)
+	}
+	if xact.Finished() || xact.Aborted() {
+		return fmt.Errorf("%s is not running", xact)
+	}
+	xact.pmu.Lock()
+	if xact.resume == nil {
+		xact.resume = make(chan struct{})
+		xact.paused.Store(true)
+		glog.Infoln("PAUSE: " + xact.String())
+	}
+	xact.pmu.Unlock()
+	return nil
+}
+
+func (xact *XactBase) Resume() {
+	xact.pmu.Lock()
+	if xact.resume != nil {
+		close(xact.resume)
+		xact.resume = nil
+		xact.paused.Store(false)
+		glog.Infoln("RESUME: " + xact.String())
+	}
+	xact.pmu.Unlock()
+}
+
+// WaitResumed blocks while the xaction is paused; returns true if aborted
+// in the meantime.
+func (xact *XactBase) WaitResumed() (aborted bool) {
+	if !xact.paused.Load() {
+		return false
+	}
+	xact.pmu.Lock()
+	resume := xact.resume
+	xact.pmu.Unlock()
+	if resume == nil {
+		return false
+	}
+	select {
+	case <-resume:
+		return false
+	case <-xact.abrt:
+		return true
+	}
 }
 
 func (*XactBase) Result() (interface{}, error) {
@@ -211,6 +272,7 @@ func (xact *XactBase) Stats() cluster.XactStats {
 		ObjCountX:   xact.ObjCount(),
 		BytesCountX: xact.BytesCount(),
 		AbortedX:    xact.Aborted(),
+		PausedX:     xact.Paused(),
 	}
 	if xact.Bck() != nil {
 		stats.BckX = xact.Bck().Bck
@@ -47,6 +47,14 @@ type (
 		Tag    string
 		Copies int
 	}
+
+	LifecycleArgs struct {
+		DryRun bool
+	}
+
+	ScrubArgs struct {
+		Restart bool
+	}
 )
 
 ////////////////
@@ -111,6 +119,14 @@ func (r *registry) renewECEncode(t cluster.Target, bck *cluster.Bck, uuid, phase
 	return r.renewBucketXact(cmn.ActECEncode, bck, Args{t, uuid, &ECEncodeArgs{Phase: phase}})
 }
 
+func RenewReencode(t cluster.Target, bck *cluster.Bck, uuid string) RenewRes {
+	return defaultReg.renewReencode(t, bck, uuid)
+}
+
+func (r *registry) renewReencode(t cluster.Target, bck *cluster.Bck, uuid string) RenewRes {
+	return r.renewBucketXact(cmn.ActReencode, bck, Args{T: t, UUID: uuid})
+}
+
 func RenewMakeNCopies(t cluster.Target, uuid, tag string) { defaultReg.renewMakeNCopies(t, uuid, tag) }
 
 func (r *registry) renewMakeNCopies(t cluster.Target, uuid, tag string) {
@@ -160,6 +176,14 @@ func (r *registry) renewBckMakeNCopies(t cluster.Target, bck *cluster.Bck, uuid,
 	return
 }
 
+func RenewLifecycle(t cluster.Target, bck *cluster.Bck, uuid string, dryRun bool) RenewRes {
+	return defaultReg.renewLifecycle(t, bck, uuid, dryRun)
+}
+
+func (r *registry) renewLifecycle(t cluster.Target, bck *cluster.Bck, uuid string, dryRun bool) RenewRes {
+	return r.renewBucketXact(cmn.ActLifecycle, bck, Args{t, uuid, &LifecycleArgs{DryRun: dryRun}})
+}
+
 func RenewDirPromote(t cluster.Target, bck *cluster.Bck, dir string, params *cmn.ActValPromote) RenewRes {
 	return defaultReg.renewDirPromote(t, bck, dir, params)
 }
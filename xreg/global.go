@@ -80,6 +80,15 @@ func (r *registry) renewLRU(id string) cluster.Xact {
 	return res.Entry.Get()
 }
 
+func RenewScrub(t cluster.Target, id string, bck *cluster.Bck, restart bool) RenewRes {
+	return defaultReg.renewScrub(t, id, bck, restart)
+}
+
+func (r *registry) renewScrub(t cluster.Target, id string, bck *cluster.Bck, restart bool) RenewRes {
+	e := r.globalXacts[cmn.ActScrub].New(Args{T: t, UUID: id, Custom: &ScrubArgs{Restart: restart}}, bck)
+	return r.renew(e, nil)
+}
+
 func RenewDownloader(t cluster.Target, statsT stats.Tracker) RenewRes {
 	return defaultReg.renewDownloader(t, statsT)
 }
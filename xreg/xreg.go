@@ -246,6 +246,26 @@ func (r *registry) doAbortByID(uuid string) (aborted bool) {
 	return true
 }
 
+// pause (resume) running xaction(s) by ID or, otherwise, by kind and (optional) bucket
+func DoPause(flt XactFilter) error  { return defaultReg.doPause(flt, true /*pause*/) }
+func DoResume(flt XactFilter) error { return defaultReg.doPause(flt, false /*pause*/) }
+
+func (r *registry) doPause(flt XactFilter, pause bool) (err error) {
+	r.entries.forEach(func(entry Renewable) bool {
+		xact := entry.Get()
+		if xact.Finished() || !matchEntry(entry, flt) {
+			return true
+		}
+		if !pause {
+			xact.Resume()
+		} else if err = xact.Pause(); err != nil {
+			return false
+		}
+		return true
+	})
+	return
+}
+
 func GetStats(flt XactFilter) ([]cluster.XactStats, error) { return defaultReg.getStats(flt) }
 
 func (r *registry) getStats(flt XactFilter) ([]cluster.XactStats, error) {
@@ -15,6 +15,7 @@ func init() {
 	xreg.RegGlobXact(&eleFactory{})
 	xreg.RegGlobXact(&rslvrFactory{})
 	xreg.RegGlobXact(&rebFactory{})
+	xreg.RegGlobXact(&scrFactory{})
 
 	xreg.RegBckXact(&MovFactory{})
 	xreg.RegBckXact(&evdFactory{kind: cmn.ActEvictObjects})
@@ -26,4 +27,5 @@ func init() {
 	xreg.RegBckXact(&proFactory{})
 	xreg.RegBckXact(&llcFactory{})
 	xreg.RegBckXact(&archFactory{})
+	xreg.RegBckXact(&lcyFactory{})
 }
@@ -0,0 +1,205 @@
+// Package xs contains eXtended actions (xactions) except storage services
+// (mirror, ec) and extensions (downloader, lru).
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xreg"
+)
+
+// Lifecycle: traverses the bucket and applies its lifecycle rules
+// (see cmn.LifecycleRule) to the objects - the first matching rule wins.
+// Runs periodically on every target (see ais/tgtlifecycle.go) and on demand;
+// in dry-run mode, only counts the objects that would be affected.
+
+type (
+	lcyFactory struct {
+		xreg.RenewBase
+		xact *xactLifecycle
+		args xreg.LifecycleArgs
+	}
+	xactLifecycle struct {
+		xaction.XactBckJog
+		rules  cmn.LifecycleRules
+		stats  []lcyRuleStats // by rule
+		dryRun bool
+		wg     sync.WaitGroup // pending EC encodings
+	}
+	lcyRuleStats struct {
+		objs  atomic.Int64
+		bytes atomic.Int64
+	}
+
+	LifecycleRuleStats struct {
+		Rule  string `json:"rule"`
+		Objs  int64  `json:"obj_count,string"`
+		Bytes int64  `json:"bytes_count,string"`
+	}
+	ExtLifecycleStats struct {
+		DryRun bool                 `json:"dry_run"`
+		Rules  []LifecycleRuleStats `json:"rules"`
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact   = (*xactLifecycle)(nil)
+	_ xreg.Renewable = (*lcyFactory)(nil)
+)
+
+////////////////
+// lcyFactory //
+////////////////
+
+func (*lcyFactory) New(args xreg.Args, bck *cluster.Bck) xreg.Renewable {
+	p := &lcyFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}, args: *args.Custom.(*xreg.LifecycleArgs)}
+	return p
+}
+
+func (p *lcyFactory) Start() error {
+	p.xact = newXactLifecycle(p.T, p.UUID, p.Bck, p.args.DryRun)
+	return nil
+}
+
+func (*lcyFactory) Kind() string        { return cmn.ActLifecycle }
+func (p *lcyFactory) Get() cluster.Xact { return p.xact }
+
+// keep the one that's already running
+func (*lcyFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+///////////////////
+// xactLifecycle //
+///////////////////
+
+func newXactLifecycle(t cluster.Target, uuid string, bck *cluster.Bck, dryRun bool) (r *xactLifecycle) {
+	rules := bck.Props.Lifecycle.Rules
+	r = &xactLifecycle{rules: rules, stats: make([]lcyRuleStats, len(rules)), dryRun: dryRun}
+	mpopts := &mpather.JoggerGroupOpts{
+		T:                     t,
+		Bck:                   bck.Bck,
+		CTs:                   []string{fs.ObjectType},
+		VisitObj:              r.visitObj,
+		DoLoad:                mpather.Load,
+		SkipGloballyMisplaced: true,
+		Throttle:              true,
+	}
+	r.XactBckJog.Init(uuid, cmn.ActLifecycle, bck, mpopts)
+	return
+}
+
+func (r *xactLifecycle) Run() {
+	if len(r.rules) == 0 {
+		r.Finish(nil)
+		return
+	}
+	r.XactBckJog.Run()
+	glog.Infoln(r.String())
+	err := r.XactBckJog.Wait()
+	r.wg.Wait()
+	r.Finish(err)
+}
+
+func (r *xactLifecycle) visitObj(lom *cluster.LOM, _ []byte) error {
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		return nil
+	}
+	now := time.Now()
+	i := r.rules.Match(lom.ObjName, now.Sub(finfo.ModTime()), now.Sub(lom.Atime()))
+	if i < 0 {
+		return nil
+	}
+	if action := r.rules[i].Action; !r.dryRun {
+		if err := r.apply(lom, action); err != nil {
+			glog.Errorf("%s: failed to %s %s: %v", r, action, lom, err)
+			return nil
+		}
+	}
+	r.stats[i].objs.Inc()
+	r.stats[i].bytes.Add(lom.SizeBytes())
+	r.ObjectsInc()
+	r.BytesAdd(lom.SizeBytes())
+	return nil
+}
+
+func (r *xactLifecycle) apply(lom *cluster.LOM, action string) (err error) {
+	switch action {
+	case cmn.LifecycleDelete:
+		_, err = r.Target().DeleteObject(lom, false /*evict*/)
+	case cmn.LifecycleEvict:
+		_, err = r.Target().EvictObject(lom)
+	case cmn.LifecycleMirror1:
+		if lom.HasCopies() {
+			err = delMirrored(lom)
+		}
+	case cmn.LifecycleEC:
+		if !lom.ECConf().Enabled {
+			return ec.ErrorECDisabled
+		}
+		var mdFQN string
+		if mdFQN, _, err = cluster.HrwFQN(lom.Bck(), fs.ECMetaType, lom.ObjName); err != nil {
+			return
+		}
+		if _, err = os.Stat(mdFQN); err == nil {
+			return // already erasure coded
+		}
+		if lom.HasCopies() {
+			if err = delMirrored(lom); err != nil {
+				return
+			}
+		}
+		r.wg.Add(1)
+		if err = ec.ECM.EncodeObject(lom, func(*cluster.LOM, error) { r.wg.Done() }); err != nil {
+			r.wg.Done()
+		}
+	}
+	if cmn.IsObjNotExist(err) {
+		err = nil
+	}
+	return
+}
+
+// remove all mirrored copies of the object
+func delMirrored(lom *cluster.LOM) error {
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	lom.Uncache(false /*delDirty*/)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return err
+	}
+	if !lom.HasCopies() {
+		return nil
+	}
+	if err := lom.DelAllCopies(); err != nil {
+		return err
+	}
+	return lom.Persist()
+}
+
+func (r *xactLifecycle) Stats() cluster.XactStats {
+	baseStats := &xaction.BaseXactStatsExt{BaseXactStats: *r.XactBckJog.Stats().(*xaction.BaseXactStats)}
+	ext := &ExtLifecycleStats{DryRun: r.dryRun, Rules: make([]LifecycleRuleStats, len(r.rules))}
+	for i := range r.rules {
+		ext.Rules[i] = LifecycleRuleStats{
+			Rule:  r.rules[i].String(),
+			Objs:  r.stats[i].objs.Load(),
+			Bytes: r.stats[i].bytes.Load(),
+		}
+	}
+	baseStats.Ext = ext
+	return baseStats
+}
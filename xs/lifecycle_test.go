@@ -0,0 +1,85 @@
+// Package xs contains eXtended actions (xactions) except storage services
+// (mirror, ec) and extensions (downloader, lru).
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/devtools/tutils"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xaction"
+)
+
+type (
+	lcyTargetMock struct {
+		*cluster.TargetMock
+		si      *cluster.Snode
+		smap    *cluster.Smap
+		deleted atomic.Int32
+	}
+	lcySownerMock struct {
+		smap *cluster.Smap
+	}
+)
+
+func (t *lcyTargetMock) Snode() *cluster.Snode  { return t.si }
+func (t *lcyTargetMock) Sowner() cluster.Sowner { return &lcySownerMock{t.smap} }
+
+func (o *lcySownerMock) Get() *cluster.Smap             { return o.smap }
+func (*lcySownerMock) Listeners() cluster.SmapListeners { return nil }
+
+func (t *lcyTargetMock) DeleteObject(_ *cluster.LOM, evict bool) (int, error) {
+	cos.Assert(!evict)
+	t.deleted.Inc()
+	return 0, nil
+}
+
+func TestLifecycle(t *testing.T) {
+	const objCnt = 100
+	var (
+		out = tutils.PrepareObjects(t, tutils.ObjectsDesc{
+			CTs:           []tutils.ContentTypeDesc{{Type: fs.ObjectType, ContentCnt: objCnt}},
+			MountpathsCnt: 3,
+			ObjectSize:    cos.KiB,
+		})
+		si    = &cluster.Snode{DaemonID: "target"}
+		tMock = &lcyTargetMock{
+			TargetMock: out.T.(*cluster.TargetMock),
+			si:         si,
+			smap:       &cluster.Smap{Tmap: cluster.NodeMap{si.ID(): si}},
+		}
+		props = *out.Bck.Props
+	)
+	cos.InitShortID(0)
+	tMock.smap.InitDigests()
+	props.Lifecycle.Rules = cmn.LifecycleRules{
+		{Age: cos.Duration(time.Hour), Action: cmn.LifecycleDelete},
+		{Age: cos.Duration(time.Nanosecond), Action: cmn.LifecycleDelete},
+	}
+	bck := cluster.NewBck(out.Bck.Name, out.Bck.Provider, out.Bck.Ns, &props)
+
+	for _, dryRun := range []bool{true, false} {
+		xact := newXactLifecycle(tMock, cos.GenUUID(), bck, dryRun)
+		xact.Run()
+		tassert.Fatalf(t, xact.Finished(), "%s: expected to finish", xact)
+
+		ext := xact.Stats().(*xaction.BaseXactStatsExt).Ext.(*ExtLifecycleStats)
+		tassert.Errorf(t, ext.DryRun == dryRun, "expected dry-run=%t", dryRun)
+		tassert.Errorf(t, ext.Rules[0].Objs == 0, "dry-run=%t: rule %q matched %d objects", dryRun,
+			ext.Rules[0].Rule, ext.Rules[0].Objs)
+		tassert.Errorf(t, ext.Rules[1].Objs == objCnt && ext.Rules[1].Bytes == objCnt*cos.KiB,
+			"dry-run=%t: rule %q: expected %d objects, got %d (%d bytes)", dryRun, ext.Rules[1].Rule,
+			objCnt, ext.Rules[1].Objs, ext.Rules[1].Bytes)
+	}
+	tassert.Errorf(t, tMock.deleted.Load() == objCnt, "expected %d objects deleted, got %d", objCnt,
+		tMock.deleted.Load())
+}
@@ -10,8 +10,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -388,23 +390,44 @@ func (r *ObjListXact) discardObsolete(token string) {
 }
 
 func (r *ObjListXact) traverseBucket(msg *cmn.SelectMsg) {
-	wi := walkinfo.NewWalkInfo(r.walkCtx(), r.t, msg)
+	var (
+		wi      = walkinfo.NewWalkInfo(r.walkCtx(), r.t, msg)
+		archDir = msg.IsFlagSet(cmn.SelectArchDir)
+		vers    = msg.IsFlagSet(cmn.SelectVersions) && r.Bck().IsAIS()
+		pending []*cmn.BucketEntry // archived files and versions (sorted) waiting for their turn
+	)
 	defer r.walkWg.Done()
+	send := func(entry *cmn.BucketEntry) error {
+		select {
+		case r.objCache <- entry:
+			/* do nothing */
+		case <-r.walkStopCh.Listen():
+			return errStopped
+		}
+		return nil
+	}
 	cb := func(fqn string, de fs.DirEntry) error {
 		entry, err := wi.Callback(fqn, de)
 		if err != nil || entry == nil {
 			return err
 		}
+		// keep the list sorted: archived files and versions go right before the names that follow
+		for len(pending) > 0 && pending[0].Name < entry.Name {
+			if err := send(pending[0]); err != nil {
+				return err
+			}
+			pending = pending[1:]
+		}
+		if archDir && entry.IsStatusOK() {
+			pending = r.addArchEntries(pending, fqn, entry, msg)
+		}
+		if vers && entry.IsStatusOK() {
+			pending = r.addVersionEntries(pending, fqn, entry, msg)
+		}
 		if entry.Name <= msg.StartAfter {
 			return nil
 		}
-		select {
-		case r.objCache <- entry:
-			/* do nothing */
-		case <-r.walkStopCh.Listen():
-			return errStopped
-		}
-		return nil
+		return send(entry)
 	}
 	opts := &fs.WalkBckOptions{
 		Options: fs.Options{
@@ -421,10 +444,103 @@ func (r *ObjListXact) traverseBucket(msg *cmn.SelectMsg) {
 		},
 	}
 
-	if err := fs.WalkBck(opts); err != nil {
+	err := fs.WalkBck(opts)
+	if err != nil {
 		if err != filepath.SkipDir && err != errStopped {
 			glog.Errorf("%s walk failed, err %v", r, err)
 		}
 	}
+	if err != errStopped {
+		for _, entry := range pending {
+			if send(entry) != nil {
+				break
+			}
+		}
+	}
 	close(r.objCache)
 }
+
+// Lists an archived object (as per its extension, see cos.ArchExtensions) and adds
+// the resulting "virtual directory" entries `objname/filename` to the sorted `pending`.
+// Corrupted (unreadable) archives are listed as regular objects.
+func (r *ObjListXact) addArchEntries(pending []*cmn.BucketEntry, fqn string, entry *cmn.BucketEntry,
+	msg *cmn.SelectMsg) []*cmn.BucketEntry {
+	mime, err := cos.Mime("", entry.Name)
+	if err != nil {
+		return pending
+	}
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return pending
+	}
+	var (
+		finfo   os.FileInfo
+		entries []cos.ArchEntry
+		l       = len(pending)
+	)
+	if finfo, err = fh.Stat(); err == nil {
+		entries, err = cos.ListArch(fh, mime, finfo.Size())
+	}
+	cos.Close(fh)
+	if err != nil {
+		if glog.FastV(4, glog.SmoduleAIS) {
+			glog.Warningf("%s: failed to list archive %s, err: %v", r, fqn, err)
+		}
+		return pending
+	}
+	for _, e := range entries {
+		name := entry.Name + "/" + strings.TrimPrefix(e.Name, "/")
+		if name <= msg.StartAfter || !cmn.ObjNameContainsPrefix(name, msg.Prefix) {
+			continue
+		}
+		ae := &cmn.BucketEntry{Name: name, Flags: entry.Flags | cmn.EntryInArch, Offset: e.Offset}
+		if msg.WantProp(cmn.GetPropsSize) {
+			ae.Size = e.Size
+		}
+		pending = append(pending, ae)
+	}
+	if len(pending) > l {
+		sort.Slice(pending, func(i, j int) bool { return pending[i].Name < pending[j].Name })
+	}
+	return pending
+}
+
+// Adds previous versions of the object, if any, to the sorted `pending`;
+// each version is listed as `objname?version=N` (see cmn.VersionEntryName).
+func (r *ObjListXact) addVersionEntries(pending []*cmn.BucketEntry, fqn string, entry *cmn.BucketEntry,
+	msg *cmn.SelectMsg) []*cmn.BucketEntry {
+	lom := cluster.AllocLOMbyFQN(fqn)
+	defer cluster.FreeLOM(lom)
+	if err := lom.Init(r.Bck().Bck); err != nil {
+		return pending
+	}
+	lom.Lock(false)
+	vlist, err := lom.Versions()
+	lom.Unlock(false)
+	if err != nil {
+		glog.Errorf("%s: failed to list versions of %s: %v", r, lom, err)
+		return pending
+	}
+	l := len(pending)
+	for _, vlom := range vlist {
+		name := cmn.VersionEntryName(entry.Name, vlom.Version())
+		if name > msg.StartAfter {
+			ve := &cmn.BucketEntry{Name: name, Flags: entry.Flags | cmn.EntryIsVer, Version: vlom.Version()}
+			if msg.WantProp(cmn.GetPropsSize) {
+				ve.Size = vlom.SizeBytes()
+			}
+			if msg.WantProp(cmn.GetPropsChecksum) && vlom.Checksum() != nil {
+				ve.Checksum = vlom.Checksum().Value()
+			}
+			if msg.WantProp(cmn.GetPropsAtime) {
+				ve.Atime = cos.FormatUnixNano(vlom.AtimeUnix(), msg.TimeFormat)
+			}
+			pending = append(pending, ve)
+		}
+		cluster.FreeLOM(vlom)
+	}
+	if len(pending) > l {
+		sort.Slice(pending, func(i, j int) bool { return pending[i].Name < pending[j].Name })
+	}
+	return pending
+}
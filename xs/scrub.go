@@ -0,0 +1,525 @@
+// Package xs contains eXtended actions (xactions) except storage services
+// (mirror, ec) and extensions (downloader, lru).
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/mirror"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xreg"
+)
+
+// Scrub: traverses a given bucket (or all buckets) and verifies the content
+// of the (local) objects against their stored checksums, as well as their
+// mirrored copies, EC metafiles, and EC slices. Namely:
+// - a corrupted object is restored from its good copy, if any, or else from
+//   EC slices (replicas) stored on other targets;
+// - corrupted copies are removed and then recreated from the (good) object;
+// - an EC metafile that does not match the object gets regenerated by
+//   erasure coding the object anew;
+// - a corrupted EC slice (or its damaged metafile) is regenerated by the
+//   object's main target that gets asked to erasure code the object anew;
+//   slices are never removed - neither corrupted nor those that cannot be
+//   verified.
+// Objects that cannot be repaired are reported via xaction stats.
+//
+// Scrubbing is throttled and resumable: each mountpath jogger periodically
+// records its progress (see cmn.ScrubFname), so that the next scrub picks up
+// where the previous (aborted) one left off - unless asked to restart.
+
+const (
+	scrubSaveInterval  = 30 * time.Second
+	scrubMaxReportObjs = 128 // max number of unrecoverable objects to report by name
+)
+
+type (
+	scrFactory struct {
+		xreg.RenewBase
+		xact *xactScrub
+		args xreg.ScrubArgs
+	}
+	xactScrub struct {
+		xaction.XactBckJog
+		smap    *cluster.Smap
+		ckpts   map[string]*scrubCkpt // mountpath => progress
+		restart bool
+		// stats
+		checked       atomic.Int64
+		corruptObjs   atomic.Int64
+		corruptCopies atomic.Int64
+		corruptMeta   atomic.Int64
+		corruptSlices atomic.Int64
+		repaired      atomic.Int64
+		unrecoverable atomic.Int64
+		mu            sync.Mutex
+		unrecObjs     []string
+	}
+	// per-mountpath progress: bucket => content type => the last scrubbed name
+	scrubCkpt struct {
+		Buckets map[string]cos.SimpleKVs `json:"buckets"`
+		fqn     string
+		saved   int64
+		dirty   bool
+	}
+
+	ExtScrubStats struct {
+		Checked           int64    `json:"checked,string"`        // objects and slices verified
+		CorruptObjs       int64    `json:"corrupt_objs,string"`   // objects with bad checksum
+		CorruptCopies     int64    `json:"corrupt_copies,string"` // mirrored copies with bad checksum
+		CorruptMeta       int64    `json:"corrupt_meta,string"`   // EC metafiles that are damaged or do not match the object
+		CorruptSlices     int64    `json:"corrupt_slices,string"` // EC slices with bad checksum
+		Repaired          int64    `json:"repaired,string"`
+		Unrecoverable     int64    `json:"unrecoverable,string"`
+		UnrecoverableObjs []string `json:"unrecoverable_objs,omitempty"` // (the first scrubMaxReportObjs)
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact   = (*xactScrub)(nil)
+	_ xreg.Renewable = (*scrFactory)(nil)
+)
+
+////////////////
+// scrFactory //
+////////////////
+
+func (*scrFactory) New(args xreg.Args, bck *cluster.Bck) xreg.Renewable {
+	p := &scrFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}, args: *args.Custom.(*xreg.ScrubArgs)}
+	return p
+}
+
+func (p *scrFactory) Start() error {
+	slab, err := p.T.MMSA().GetSlab(memsys.MaxPageSlabSize)
+	cos.AssertNoErr(err)
+	p.xact = newXactScrub(p.T, p.UUID, p.Bck, slab, p.args.Restart)
+	return nil
+}
+
+func (*scrFactory) Kind() string        { return cmn.ActScrub }
+func (p *scrFactory) Get() cluster.Xact { return p.xact }
+
+// keep the one that's already running
+func (*scrFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+///////////////
+// xactScrub //
+///////////////
+
+func newXactScrub(t cluster.Target, uuid string, bck *cluster.Bck, slab *memsys.Slab, restart bool) (r *xactScrub) {
+	r = &xactScrub{smap: t.Sowner().Get(), ckpts: make(map[string]*scrubCkpt), restart: restart}
+	mpopts := &mpather.JoggerGroupOpts{
+		T:        t,
+		CTs:      []string{fs.ObjectType, fs.ECSliceType},
+		VisitObj: r.visitObj,
+		VisitCT:  r.visitCT,
+		Slab:     slab,
+		Throttle: true,
+		Sorted:   true, // (resumable)
+	}
+	if bck != nil {
+		mpopts.Bck = bck.Bck
+	}
+	availablePaths, _ := fs.Get()
+	for _, mi := range availablePaths {
+		r.ckpts[mi.Path] = loadScrubCkpt(mi)
+	}
+	r.XactBckJog.Init(uuid, cmn.ActScrub, bck, mpopts)
+	return
+}
+
+func (r *xactScrub) Run() {
+	if r.restart {
+		for _, ckpt := range r.ckpts {
+			r.resetCkpt(ckpt)
+		}
+	}
+	r.XactBckJog.Run()
+	glog.Infoln(r.String())
+	err := r.XactBckJog.Wait()
+	for _, ckpt := range r.ckpts {
+		if err != nil || r.Aborted() {
+			ckpt.save()
+		} else {
+			r.resetCkpt(ckpt) // done
+		}
+	}
+	r.Finish(err)
+}
+
+func (r *xactScrub) visitObj(lom *cluster.LOM, buf []byte) error {
+	ckpt := r.ckpts[lom.MpathInfo().Path]
+	if ckpt.done(lom.Bck(), fs.ObjectType, lom.ObjName) {
+		return nil
+	}
+	err := r.scrubObj(lom, buf)
+	ckpt.update(lom.Bck(), fs.ObjectType, lom.ObjName)
+	if cos.IsErrOOS(err) {
+		return cmn.NewAbortedError(r.String(), err.Error())
+	}
+	return nil
+}
+
+func (r *xactScrub) scrubObj(lom *cluster.LOM, buf []byte) error {
+	var (
+		good, bad []string
+		badMain   bool
+		copies    int
+	)
+	lom.Lock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(true)
+		if !cmn.IsObjNotExist(err) {
+			r.report(lom.FullName(), err)
+		}
+		return nil
+	}
+	// copies and misplaced objects are checked by their respective owners
+	if lom.IsCopy() {
+		lom.Unlock(true)
+		return nil
+	}
+	if _, local, err := lom.HrwTarget(r.smap); err != nil || !local {
+		lom.Unlock(true)
+		return nil
+	}
+	if err := lom.ValidateContentChecksum(); err != nil {
+		if _, ok := err.(*cos.ErrBadCksum); !ok {
+			lom.Unlock(true)
+			glog.Errorf("%s: %s: %v", r, lom, err)
+			return err
+		}
+		badMain = true
+		r.corruptObjs.Inc()
+		glog.Errorf("%s: %v", r, err)
+	}
+	r.checked.Inc()
+	r.ObjectsInc()
+	r.BytesAdd(lom.SizeBytes())
+
+	// mirrored copies
+	copies = lom.NumCopies()
+	if lom.HasCopies() && !lom.Checksum().IsEmpty() {
+		for copyFQN := range lom.GetCopies() {
+			if copyFQN == lom.FQN {
+				continue
+			}
+			if ok, err := cksumMatches(copyFQN, lom.Checksum(), buf); ok {
+				good = append(good, copyFQN)
+			} else {
+				if err == nil {
+					r.corruptCopies.Inc()
+					glog.Errorf("%s: %s: bad checksum of the copy %q", r, lom, copyFQN)
+				}
+				bad = append(bad, copyFQN)
+			}
+		}
+	}
+	if badMain && len(good) > 0 {
+		if err := restoreFromCopy(lom, good[0], buf); err != nil {
+			glog.Errorf("%s: failed to restore %s from %q: %v", r, lom, good[0], err)
+		} else {
+			badMain = false
+			r.repaired.Inc()
+		}
+	}
+	if len(bad) > 0 && !badMain {
+		err := lom.DelCopies(bad...)
+		if err == nil {
+			err = lom.Persist()
+		}
+		if err != nil {
+			glog.Errorf("%s: %s: %v", r, lom, err)
+		}
+	}
+	lom.Uncache(true /*delDirty*/)
+	lom.Unlock(true)
+
+	switch {
+	case badMain:
+		// last resort
+		if err := r.restoreEC(lom); err != nil {
+			r.report(lom.FullName(), err)
+			return nil
+		}
+		r.repaired.Inc()
+	case len(bad) > 0:
+		if _, err := mirror.MakeNCopies(lom, copies, buf); err != nil {
+			glog.Errorf("%s: failed to recreate copies of %s: %v", r, lom, err)
+			return err
+		}
+		r.repaired.Inc()
+	}
+	return r.scrubMeta(lom)
+}
+
+// re-encode the object if its EC metafile is damaged or stale
+func (r *xactScrub) scrubMeta(lom *cluster.LOM) error {
+	if !lom.Bprops().ECUsed() || !lom.ECEnabled() {
+		return nil
+	}
+	mdFQN := lom.MpathInfo().MakePathFQN(lom.Bucket(), fs.ECMetaType, lom.ObjName)
+	md, err := ec.LoadMetadata(mdFQN)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // not encoded (yet)
+		}
+	} else if md.Size == lom.SizeBytes() && (lom.Checksum().IsEmpty() || md.ObjCksum == lom.Checksum().Value()) {
+		return nil
+	}
+	r.corruptMeta.Inc()
+	glog.Errorf("%s: %s: damaged or stale EC metadata %q (%v) - re-encoding", r, lom, mdFQN, err)
+	if err := ec.ECM.EncodeObject(lom); err != nil {
+		glog.Errorf("%s: failed to re-encode %s: %v", r, lom, err)
+		return err
+	}
+	r.repaired.Inc()
+	return nil
+}
+
+// Move the corrupted object aside and have EC restore it; move it back upon failure.
+// The object must not be locked.
+func (r *xactScrub) restoreEC(lom *cluster.LOM) (err error) {
+	if !lom.Bprops().ECUsed() {
+		return ec.ErrorECDisabled
+	}
+	workFQN := fs.CSM.GenContentFQN(lom, fs.WorkfileType, fs.WorkfileScrub)
+	lom.Lock(true)
+	err = cos.Rename(lom.FQN, workFQN)
+	lom.Unlock(true)
+	if err != nil {
+		return
+	}
+	if err = ec.ECM.RestoreObject(lom); err == nil {
+		if errRm := cos.RemoveFile(workFQN); errRm != nil {
+			glog.Error(errRm)
+		}
+		return
+	}
+	lom.Lock(true)
+	if _, errStat := os.Stat(lom.FQN); os.IsNotExist(errStat) {
+		if errRn := cos.Rename(workFQN, lom.FQN); errRn != nil {
+			glog.Errorf("%s: failed to put back %s: %v", r, lom, errRn)
+		}
+	}
+	lom.Unlock(true)
+	return
+}
+
+// EC slices: checksum vs metafile
+func (r *xactScrub) visitCT(ct *cluster.CT, buf []byte) error {
+	if ct.ContentType() != fs.ECSliceType {
+		return nil
+	}
+	ckpt := r.ckpts[ct.MpathInfo().Path]
+	if ckpt.done(ct.Bck(), fs.ECSliceType, ct.ObjectName()) {
+		return nil
+	}
+	defer ckpt.update(ct.Bck(), fs.ECSliceType, ct.ObjectName())
+
+	var (
+		name  = filepath.Join(ct.Bck().Name, ct.ObjectName())
+		mdFQN = ct.Make(fs.ECMetaType)
+	)
+	md, err := ec.LoadMetadata(mdFQN)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // orphan - not ours to judge
+		}
+		r.corruptMeta.Inc()
+	} else {
+		if md.CksumValue == "" || md.CksumType == cos.ChecksumNone {
+			return nil
+		}
+		r.checked.Inc()
+		ok, errCk := cksumMatches(ct.FQN(), cos.NewCksum(md.CksumType, md.CksumValue), buf)
+		if ok {
+			return nil
+		}
+		if errCk != nil {
+			r.report(name, fmt.Errorf("failed to verify EC slice %q: %v", ct.FQN(), errCk))
+			return nil
+		}
+		r.corruptSlices.Inc()
+		err = fmt.Errorf("bad checksum of EC slice %q", ct.FQN())
+	}
+	glog.Errorf("%s: %v - requesting %s to be re-encoded", r, err, name)
+	if err := r.reencodeCT(ct); err != nil {
+		r.report(name, err)
+	}
+	return nil
+}
+
+// Have the object's main target erasure code the object anew, which regenerates
+// all its slices and metafiles, including the damaged ones stored locally.
+func (r *xactScrub) reencodeCT(ct *cluster.CT) error {
+	tsi, err := cluster.HrwTarget(ct.Uname(), r.smap)
+	if err != nil {
+		return err
+	}
+	if tsi.ID() != cluster.T.Snode().ID() {
+		return ec.ECM.RequestEncode(ct.Bck(), ct.ObjectName(), tsi)
+	}
+	lom := cluster.AllocLOM(ct.ObjectName())
+	defer cluster.FreeLOM(lom)
+	if err := lom.Init(ct.Bucket()); err != nil {
+		return err
+	}
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		return err
+	}
+	return ec.ECM.EncodeObject(lom)
+}
+
+func (r *xactScrub) report(name string, err error) {
+	glog.Errorf("%s: %s is unrecoverable: %v", r, name, err)
+	r.unrecoverable.Inc()
+	r.mu.Lock()
+	if len(r.unrecObjs) < scrubMaxReportObjs {
+		r.unrecObjs = append(r.unrecObjs, name)
+	}
+	r.mu.Unlock()
+}
+
+// remove the progress made in the scope of this xaction
+func (r *xactScrub) resetCkpt(ckpt *scrubCkpt) {
+	if r.Bck() == nil {
+		ckpt.Buckets = make(map[string]cos.SimpleKVs)
+	} else {
+		delete(ckpt.Buckets, r.Bck().String())
+	}
+	ckpt.dirty = true
+	ckpt.save()
+}
+
+// total number of corrupted objects, copies, EC metafiles, and slices
+func (s *ExtScrubStats) Corrupted() int64 {
+	return s.CorruptObjs + s.CorruptCopies + s.CorruptMeta + s.CorruptSlices
+}
+
+func (r *xactScrub) Stats() cluster.XactStats {
+	baseStats := &xaction.BaseXactStatsExt{BaseXactStats: *r.XactBckJog.Stats().(*xaction.BaseXactStats)}
+	ext := &ExtScrubStats{
+		Checked:       r.checked.Load(),
+		CorruptObjs:   r.corruptObjs.Load(),
+		CorruptCopies: r.corruptCopies.Load(),
+		CorruptMeta:   r.corruptMeta.Load(),
+		CorruptSlices: r.corruptSlices.Load(),
+		Repaired:      r.repaired.Load(),
+		Unrecoverable: r.unrecoverable.Load(),
+	}
+	r.mu.Lock()
+	ext.UnrecoverableObjs = append(ext.UnrecoverableObjs, r.unrecObjs...)
+	r.mu.Unlock()
+	baseStats.Ext = ext
+	return baseStats
+}
+
+///////////////
+// scrubCkpt //
+///////////////
+
+func loadScrubCkpt(mi *fs.MountpathInfo) *scrubCkpt {
+	ckpt := &scrubCkpt{fqn: filepath.Join(mi.Path, cmn.ScrubFname), saved: mono.NanoTime()}
+	if _, err := jsp.Load(ckpt.fqn, ckpt, jsp.Plain()); err != nil && !os.IsNotExist(err) {
+		glog.Errorf("failed to load scrub progress %q: %v", ckpt.fqn, err)
+	}
+	if ckpt.Buckets == nil {
+		ckpt.Buckets = make(map[string]cos.SimpleKVs)
+	}
+	return ckpt
+}
+
+// (a mountpath added while scrubbing has no progress to keep)
+func (ckpt *scrubCkpt) done(bck *cluster.Bck, ty, name string) bool {
+	if ckpt == nil {
+		return false
+	}
+	last, ok := ckpt.Buckets[bck.String()][ty]
+	return ok && !fs.NameAfter(name, last)
+}
+
+func (ckpt *scrubCkpt) update(bck *cluster.Bck, ty, name string) {
+	if ckpt == nil {
+		return
+	}
+	uname := bck.String()
+	if ckpt.Buckets[uname] == nil {
+		ckpt.Buckets[uname] = make(cos.SimpleKVs, 2)
+	}
+	ckpt.Buckets[uname][ty] = name
+	ckpt.dirty = true
+	if mono.Since(ckpt.saved) > scrubSaveInterval {
+		ckpt.save()
+	}
+}
+
+func (ckpt *scrubCkpt) save() {
+	if !ckpt.dirty {
+		return
+	}
+	var err error
+	if len(ckpt.Buckets) == 0 {
+		if err = os.Remove(ckpt.fqn); os.IsNotExist(err) {
+			err = nil
+		}
+	} else {
+		err = jsp.Save(ckpt.fqn, ckpt, jsp.Plain(), nil)
+	}
+	if err != nil {
+		glog.Errorf("failed to save scrub progress %q: %v", ckpt.fqn, err)
+	}
+	ckpt.saved, ckpt.dirty = mono.NanoTime(), false
+}
+
+/////////////
+// helpers //
+/////////////
+
+func cksumMatches(fqn string, cksum *cos.Cksum, buf []byte) (bool, error) {
+	file, err := os.Open(fqn)
+	if err != nil {
+		return false, err
+	}
+	_, cksumHash, err := cos.CopyAndChecksum(io.Discard, file, buf, cksum.Ty())
+	cos.Close(file)
+	if err != nil {
+		return false, err
+	}
+	return cksumHash.Equal(cksum), nil
+}
+
+// NOTE: the object must be locked
+func restoreFromCopy(lom *cluster.LOM, copyFQN string, buf []byte) error {
+	workFQN := fs.CSM.GenContentFQN(lom, fs.WorkfileType, fs.WorkfileScrub)
+	if _, _, err := cos.CopyFile(copyFQN, workFQN, buf, cos.ChecksumNone); err != nil {
+		return err
+	}
+	if err := cos.Rename(workFQN, lom.FQN); err != nil {
+		if errRm := cos.RemoveFile(workFQN); errRm != nil {
+			glog.Error(errRm)
+		}
+		return err
+	}
+	return lom.Persist()
+}
@@ -0,0 +1,141 @@
+// Package xs contains eXtended actions (xactions) except storage services
+// (mirror, ec) and extensions (downloader, lru).
+/*
+ * Copyright (c) 2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/devtools/tutils"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+type scrubTargetMock struct {
+	*cluster.TargetMock
+	si *cluster.Snode
+}
+
+func (t *scrubTargetMock) Snode() *cluster.Snode { return t.si }
+
+func TestScrubCorruptedCopy(t *testing.T) {
+	var (
+		out = tutils.PrepareObjects(t, tutils.ObjectsDesc{
+			CTs:           []tutils.ContentTypeDesc{{Type: fs.ObjectType, ContentCnt: 1}},
+			MountpathsCnt: 2,
+			ObjectSize:    cos.KiB,
+		})
+		tMock = &scrubTargetMock{TargetMock: out.T.(*cluster.TargetMock), si: &cluster.Snode{DaemonID: "target"}}
+		smap  = &cluster.Smap{Tmap: cluster.NodeMap{tMock.si.ID(): tMock.si}}
+		buf   = make([]byte, cos.KiB)
+		lom   = &cluster.LOM{FQN: out.FQNs[fs.ObjectType][0]}
+	)
+	cluster.T = tMock
+	cos.InitShortID(0)
+	smap.InitDigests()
+	out.Bck.Props.Mirror = cmn.MirrorConf{Enabled: true, Copies: 2} // (shared with the BMD)
+
+	// checksum the object and make a copy
+	tassert.CheckFatal(t, lom.Init(cmn.Bck{}))
+	tassert.CheckFatal(t, lom.Load(false /*cache it*/, false /*locked*/))
+	cksum, err := lom.ComputeCksum()
+	tassert.CheckFatal(t, err)
+	lom.SetCksum(cksum.Clone())
+	tassert.CheckFatal(t, lom.Persist())
+	lom.Lock(true)
+	copyFQN := lom.BestMpath().MakePathFQN(lom.Bucket(), fs.ObjectType, lom.ObjName)
+	clone, err := lom.CopyObject(copyFQN, buf)
+	lom.Unlock(true)
+	tassert.CheckFatal(t, err)
+	cluster.FreeLOM(clone)
+
+	// corrupt the copy
+	b, err := os.ReadFile(lom.FQN)
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, os.WriteFile(copyFQN, bytes.Repeat([]byte{'x'}, len(b)), cos.PermRWR))
+
+	r := &xactScrub{smap: smap}
+	r.InitBase(cos.GenUUID(), cmn.ActScrub, nil)
+	tassert.CheckFatal(t, r.scrubObj(lom, buf))
+	tassert.Errorf(t, r.corruptCopies.Load() == 1, "expected 1 corrupted copy, got %d", r.corruptCopies.Load())
+	tassert.Errorf(t, r.repaired.Load() == 1, "expected 1 repaired, got %d", r.repaired.Load())
+
+	// the copy must be recreated - both on disk and in the object's metadata
+	lom = &cluster.LOM{FQN: lom.FQN}
+	tassert.CheckFatal(t, lom.Init(cmn.Bck{}))
+	tassert.CheckFatal(t, lom.Load(false /*cache it*/, false /*locked*/))
+	tassert.Fatalf(t, lom.NumCopies() == 2, "expected 2 copies, got %d", lom.NumCopies())
+	for fqn := range lom.GetCopies() {
+		ok, err := cksumMatches(fqn, lom.Checksum(), buf)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, ok, "copy %q: bad checksum", fqn)
+	}
+}
+
+// a slice that is corrupted, or cannot be verified, is never removed - its
+// main target is asked to re-encode the object, or else it gets reported
+func TestScrubDamagedSlice(t *testing.T) {
+	var (
+		out = tutils.PrepareObjects(t, tutils.ObjectsDesc{
+			CTs:           []tutils.ContentTypeDesc{{Type: fs.ECSliceType, ContentCnt: 1}},
+			MountpathsCnt: 1,
+			ObjectSize:    cos.KiB,
+		})
+		tMock = &scrubTargetMock{TargetMock: out.T.(*cluster.TargetMock), si: &cluster.Snode{DaemonID: "target"}}
+		smap  = &cluster.Smap{Tmap: cluster.NodeMap{tMock.si.ID(): tMock.si}}
+		buf   = make([]byte, cos.KiB)
+	)
+	cluster.T = tMock
+	smap.InitDigests()
+
+	ct, err := cluster.NewCTFromFQN(out.FQNs[fs.ECSliceType][0], tMock.Bowner())
+	tassert.CheckFatal(t, err)
+	b, err := os.ReadFile(ct.FQN())
+	tassert.CheckFatal(t, err)
+	_, cksum, err := cos.CopyAndChecksum(io.Discard, bytes.NewReader(b), nil, cos.ChecksumXXHash)
+	tassert.CheckFatal(t, err)
+	var (
+		mdFQN  = ct.Make(fs.ECMetaType)
+		goodMD = ec.NewMetadata()
+		badMD  = ec.NewMetadata()
+	)
+	goodMD.CksumType, goodMD.CksumValue = cos.ChecksumXXHash, cksum.Value()
+	badMD.CksumType, badMD.CksumValue = cos.ChecksumXXHash, "bad"
+
+	tests := []struct {
+		name        string
+		meta        []byte
+		corruptMeta int64
+		corruptCTs  int64
+		unrec       int64
+	}{
+		{"good", goodMD.NewPack(), 0, 0, 0},
+		{"corrupted slice", badMD.NewPack(), 0, 1, 1},
+		{"damaged metafile", []byte("garbage"), 1, 0, 1},
+	}
+	for _, test := range tests {
+		tassert.CheckFatal(t, os.WriteFile(mdFQN, test.meta, cos.PermRWR))
+		r := &xactScrub{smap: smap}
+		r.InitBase(cos.GenUUID(), cmn.ActScrub, nil)
+		tassert.CheckFatal(t, r.visitCT(ct, buf))
+		tassert.Errorf(t, r.corruptMeta.Load() == test.corruptMeta && r.corruptSlices.Load() == test.corruptCTs,
+			"%s: expected %d corrupted metafiles and %d slices, got %d and %d", test.name,
+			test.corruptMeta, test.corruptCTs, r.corruptMeta.Load(), r.corruptSlices.Load())
+		// the object (whose main target is this one) does not exist - cannot re-encode
+		tassert.Errorf(t, r.unrecoverable.Load() == test.unrec, "%s: expected %d unrecoverable, got %d",
+			test.name, test.unrec, r.unrecoverable.Load())
+		for _, fqn := range []string{ct.FQN(), mdFQN} {
+			_, err := os.Stat(fqn)
+			tassert.Errorf(t, err == nil, "%s: expected %q to remain: %v", test.name, fqn, err)
+		}
+	}
+}
@@ -272,3 +272,36 @@ func TestXactionQueryFinished(t *testing.T) {
 		f(t, test)
 	}
 }
+
+func TestXactionPauseResume(t *testing.T) {
+	xreg.Reset()
+	xreg.RegGlobXact(&lru.Factory{})
+	defer xreg.AbortAll()
+
+	xactLRU := xreg.RenewLRU(cos.GenUUID())
+	tassert.Fatalf(t, xactLRU != nil, "Xaction must be created")
+	err := xreg.DoPause(xreg.XactFilter{Kind: cmn.ActLRU})
+	tassert.Errorf(t, err != nil, "expected %s to be non-pausable", xactLRU)
+	tassert.Errorf(t, !xactLRU.Paused(), "expected %s not to be paused", xactLRU)
+
+	xreb := xs.NewRebalance(xaction.RebID2S(1), cmn.ActRebalance, nil, xreg.GetRebMarked)
+	tassert.CheckFatal(t, xreb.Pause())
+	tassert.Errorf(t, xreb.Paused() && xreb.XactBase.Stats().Paused(),
+		"expected %s to be paused", xreb)
+
+	resumed := make(chan bool, 1)
+	go func() { resumed <- !xreb.WaitResumed() }()
+	select {
+	case <-resumed:
+		t.Fatalf("%s: expected to wait while paused", xreb)
+	case <-time.After(100 * time.Millisecond):
+	}
+	xreb.Resume()
+	tassert.Errorf(t, <-resumed && !xreb.Paused(), "expected %s to resume", xreb)
+
+	// aborting releases the waiters
+	tassert.CheckFatal(t, xreb.Pause())
+	go func() { resumed <- !xreb.WaitResumed() }()
+	xreb.Abort()
+	tassert.Errorf(t, !<-resumed, "expected %s to abort while paused", xreb)
+}